@@ -0,0 +1,146 @@
+// Package agent implements zond and manager sides of gocc protocol:
+// subscription to channels, claiming tasks, reporting results and answering
+// alive checks.
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ad/gocc/api"
+	"github.com/ad/gocc/internal/sse"
+)
+
+// defaultTimeout limits protocol requests, subscription stream is not limited
+const defaultTimeout = 30 * time.Second
+
+// resubscribe calls subscribe again after delay until ctx is done
+func resubscribe(ctx context.Context, delay time.Duration, subscribe func() error) error {
+	for {
+		err := subscribe()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		log.Println("subscription closed:", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// sign sets uuid header and signature of request, header is X-ZondUuid or
+// X-MngrUuid. Without secret agent is identified by client certificate
+func sign(req *http.Request, header string, uuid string, secret string, path string, body []byte) error {
+	if secret == "" {
+		req.Header.Set(header, uuid)
+		return nil
+	}
+
+	buff := make([]byte, 16)
+	if _, err := rand.Read(buff); err != nil {
+		return err
+	}
+	nonce := hex.EncodeToString(buff)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set(header, uuid)
+	req.Header.Set(api.HeaderTimestamp, timestamp)
+	req.Header.Set(api.HeaderNonce, nonce)
+	req.Header.Set(api.HeaderSignature, api.Sign(secret, req.Method, path, timestamp, nonce, body))
+	return nil
+}
+
+// subscribe reads messages sent to agent with EventSource until stream is
+// closed, extra headers are added to subscription request
+func subscribe(ctx context.Context, httpClient *http.Client, server string, header string, uuid string, secret string, extra http.Header, fn func(api.Action)) error {
+	req, err := http.NewRequest(http.MethodGet, server+api.PathSubscribe, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	if err := sign(req, header, uuid, secret, api.PathSubscribe, nil); err != nil {
+		return err
+	}
+	for key, values := range extra {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	// stream must not be limited by client timeout
+	streamClient := *httpClient
+	streamClient.Timeout = 0
+
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("subscribe: %s", resp.Status)
+	}
+
+	return sse.Read(resp.Body, func(data []byte) error {
+		var action api.Action
+		if err := json.Unmarshal(data, &action); err != nil {
+			log.Println(err)
+			return nil
+		}
+		fn(action)
+		return nil
+	})
+}
+
+// post sends action to protocol endpoint and decodes answer into out
+func post(ctx context.Context, httpClient *http.Client, url string, header string, uuid string, secret string, action api.Action, out interface{}) error {
+	js, err := json.Marshal(action)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(js))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	if err := sign(req, header, uuid, secret, req.URL.Path, js); err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s %s", req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	// pong is answered with empty body when alive check is unknown
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("%s: %v", req.URL.Path, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/ad/gocc/api"
+)
+
+// Enrollment is client certificate of zond with its private key and CA
+// certificate, all in PEM
+type Enrollment struct {
+	UUID        string
+	Certificate []byte
+	Key         []byte
+	CA          []byte
+}
+
+// Enroll exchanges one-time token for client certificate, private key is
+// generated here and never leaves zond
+func Enroll(ctx context.Context, httpClient *http.Client, server string, token string) (*Enrollment, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "zond"}}, key)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := json.Marshal(api.EnrollRequest{Token: token, CSR: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}))})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimRight(server, "/")+api.PathZondEnroll, bytes.NewReader(js))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr api.ErrorResponse
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != nil {
+			return nil, fmt.Errorf("enroll: %s", apiErr.Error.Message)
+		}
+		return nil, fmt.Errorf("enroll: %s", resp.Status)
+	}
+
+	var res api.EnrollResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, err
+	}
+
+	return &Enrollment{
+		UUID:        res.UUID,
+		Certificate: []byte(res.Certificate),
+		Key:         pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		CA:          []byte(res.CA),
+	}, nil
+}
+
+// TLSClient returns http client presenting client certificate, CA of gocc
+// is trusted in addition to system roots, so internal TLS listener works
+func TLSClient(certPEM []byte, keyPEM []byte, caPEM []byte) (*http.Client, error) {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil || roots == nil {
+		roots = x509.NewCertPool()
+	}
+	if len(caPEM) > 0 && !roots.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("wrong CA certificate")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{Certificates: []tls.Certificate{cert}, RootCAs: roots}
+
+	return &http.Client{Transport: transport, Timeout: defaultTimeout}, nil
+}
+
+// CertificateUUID returns zond uuid from subject of client certificate
+func CertificateUUID(certPEM []byte) (string, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return "", errors.New("wrong certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+	return cert.Subject.CommonName, nil
+}
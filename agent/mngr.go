@@ -0,0 +1,221 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ad/gocc/api"
+)
+
+// Mngr is a manager connected to gocc, it executes measurements by fanning
+// them out as child tasks and aggregating results of children
+type Mngr struct {
+	Server     string
+	UUID       string
+	Secret     string
+	HTTPClient *http.Client
+
+	// Reconnect is delay before subscribing again after stream is closed
+	Reconnect time.Duration
+	// Wait limits time of waiting for child results, server resets
+	// measurement claimed by manager after 300 seconds
+	Wait time.Duration
+
+	mu      sync.Mutex
+	waiting map[string]chan api.Action
+}
+
+// NewMngr returns manager with uuid and secret issued on its registration
+func NewMngr(server string, uuid string, secret string) *Mngr {
+	return &Mngr{
+		Server:     strings.TrimRight(server, "/"),
+		UUID:       uuid,
+		Secret:     secret,
+		HTTPClient: &http.Client{Timeout: defaultTimeout},
+		Reconnect:  5 * time.Second,
+		Wait:       240 * time.Second,
+		waiting:    make(map[string]chan api.Action),
+	}
+}
+
+// Run subscribes to mngrtasks and personal channel and handles messages until ctx is done
+func (m *Mngr) Run(ctx context.Context) error {
+	return resubscribe(ctx, m.Reconnect, func() error {
+		return m.Subscribe(ctx, func(action api.Action) {
+			m.Handle(ctx, action)
+		})
+	})
+}
+
+// Subscribe reads messages sent to manager with EventSource until stream is closed
+func (m *Mngr) Subscribe(ctx context.Context, fn func(api.Action)) error {
+	return subscribe(ctx, m.HTTPClient, m.Server, api.HeaderMngrUUID, m.UUID, m.Secret, nil, fn)
+}
+
+// Handle answers alive checks, passes child results to waiting measurements
+// and starts new measurements, it never blocks
+func (m *Mngr) Handle(ctx context.Context, action api.Action) {
+	switch {
+	case action.Action == api.ActionAlive:
+		go func() {
+			if err := m.Pong(ctx, action.UUID); err != nil {
+				log.Println("pong:", err)
+			}
+		}()
+	case action.ParentUUID != "" && (action.Result != "" || action.Status == api.TaskStatusExpired):
+		m.childDone(action)
+	case action.Type == api.TypeMeasurement:
+		go func() {
+			if err := m.Process(ctx, action); err != nil && err != ErrNotClaimed {
+				log.Println(action.UUID, err)
+			}
+		}()
+	}
+}
+
+func (m *Mngr) childDone(child api.Action) {
+	m.mu.Lock()
+	results := m.waiting[child.ParentUUID]
+	m.mu.Unlock()
+
+	if results == nil {
+		return
+	}
+	select {
+	case results <- child:
+	default:
+	}
+}
+
+// Process claims measurement, creates child tasks and reports aggregated result
+func (m *Mngr) Process(ctx context.Context, action api.Action) error {
+	attempt, err := m.Block(ctx, action)
+	if err != nil {
+		return err
+	}
+	action.Attempt = attempt
+
+	// results may come before create request is answered, so wait for them first
+	results := make(chan api.Action, api.MaxChildTasks)
+	m.mu.Lock()
+	m.waiting[action.UUID] = results
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		delete(m.waiting, action.UUID)
+		m.mu.Unlock()
+	}()
+
+	children, err := m.CreateChildren(ctx, api.Action{ParentUUID: action.UUID, Count: action.Count})
+	if err != nil {
+		return m.SendResult(ctx, action, "error: "+err.Error())
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, m.Wait)
+	measurement := Aggregate(waitCtx, children, results)
+	cancel()
+
+	js, err := json.Marshal(measurement)
+	if err != nil {
+		return err
+	}
+
+	return m.SendResult(ctx, action, string(js))
+}
+
+// Aggregate collects results of children until all of them are done or ctx is done
+func Aggregate(ctx context.Context, children []api.Action, results <-chan api.Action) api.MeasurementResult {
+	pending := make(map[string]int, len(children))
+	measurement := api.MeasurementResult{Total: len(children), Children: make([]api.ChildResult, len(children))}
+	for i, child := range children {
+		pending[child.UUID] = i
+		measurement.Children[i] = api.ChildResult{UUID: child.UUID}
+	}
+
+	for len(pending) > 0 {
+		select {
+		case <-ctx.Done():
+			measurement.Missing = len(pending)
+			return measurement
+		case result := <-results:
+			i, ok := pending[result.UUID]
+			if !ok {
+				continue
+			}
+			delete(pending, result.UUID)
+
+			measurement.Children[i] = api.ChildResult{UUID: result.UUID, ZondUUID: result.ZondUUID, Result: result.Result, Updated: result.Updated, Status: result.Status}
+			if result.Status == api.TaskStatusExpired {
+				measurement.Missing++
+			} else if strings.HasPrefix(result.Result, "error") {
+				measurement.Failed++
+			} else {
+				measurement.Done++
+			}
+		}
+	}
+
+	return measurement
+}
+
+// Block claims measurement and returns attempt number of claim,
+// ErrNotClaimed is returned when it was taken by other manager
+func (m *Mngr) Block(ctx context.Context, action api.Action) (int64, error) {
+	var res api.Result
+	if err := m.post(ctx, api.PathMngrBlock, api.Action{MngrUUID: m.UUID, Action: action.Action, UUID: action.UUID}, &res); err != nil {
+		return 0, err
+	}
+	if res.Status != "ok" {
+		return 0, ErrNotClaimed
+	}
+	return res.Attempt, nil
+}
+
+// CreateChildren creates child tasks of claimed measurement, empty action,
+// param and count of request are taken from measurement
+func (m *Mngr) CreateChildren(ctx context.Context, req api.Action) ([]api.Action, error) {
+	req.MngrUUID = m.UUID
+
+	var res api.ChildTasksResponse
+	if err := m.post(ctx, api.PathMngrTaskCreate, req, &res); err != nil {
+		return nil, err
+	}
+	if res.Status != "ok" {
+		return nil, fmt.Errorf("child tasks rejected: %s", res.Message)
+	}
+	return res.Tasks, nil
+}
+
+func (m *Mngr) SendResult(ctx context.Context, action api.Action, result string) error {
+	var res api.Result
+	if err := m.post(ctx, api.PathMngrResult, api.Action{MngrUUID: m.UUID, Action: api.ActionResult, UUID: action.UUID, Attempt: action.Attempt, Result: result}, &res); err != nil {
+		return err
+	}
+	if res.Status != "ok" {
+		return fmt.Errorf("result rejected: %s", res.Message)
+	}
+	return nil
+}
+
+// Pong answers alive check with uuid of alive message
+func (m *Mngr) Pong(ctx context.Context, uuid string) error {
+	var res api.Result
+	if err := m.post(ctx, api.PathMngrPong, api.Action{MngrUUID: m.UUID, UUID: uuid}, &res); err != nil {
+		return err
+	}
+	if res.Status != "ok" {
+		return fmt.Errorf("pong rejected: %s", res.Message)
+	}
+	return nil
+}
+
+func (m *Mngr) post(ctx context.Context, path string, action api.Action, out interface{}) error {
+	return post(ctx, m.HTTPClient, m.Server+path, api.HeaderMngrUUID, m.UUID, m.Secret, action, out)
+}
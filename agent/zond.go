@@ -0,0 +1,184 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ad/gocc/api"
+)
+
+var (
+	// ErrNotClaimed is returned by Block when task was taken by other zond
+	ErrNotClaimed = errors.New("task not claimed")
+	// ErrBusy is returned by Block when server still counts previous task as processing
+	ErrBusy = errors.New("zond is busy")
+)
+
+// Executor runs claimed task and returns text result
+type Executor interface {
+	Execute(ctx context.Context, action api.Action) string
+}
+
+type ExecutorFunc func(ctx context.Context, action api.Action) string
+
+func (f ExecutorFunc) Execute(ctx context.Context, action api.Action) string {
+	return f(ctx, action)
+}
+
+// Zond is a probe connected to gocc
+type Zond struct {
+	Server     string
+	UUID       string
+	Secret     string
+	Executor   Executor
+	HTTPClient *http.Client
+
+	// Reconnect is delay before subscribing again after stream is closed
+	Reconnect time.Duration
+
+	// Networks are address families zond reaches, api.NetworkIPv4 and
+	// api.NetworkIPv6. Empty leaves gocc to take family of zond address
+	Networks []string
+}
+
+// NewZond returns zond with uuid and secret issued on its registration
+func NewZond(server string, uuid string, secret string, executor Executor) *Zond {
+	return &Zond{
+		Server:     strings.TrimRight(server, "/"),
+		UUID:       uuid,
+		Secret:     secret,
+		Executor:   executor,
+		HTTPClient: &http.Client{Timeout: defaultTimeout},
+		Reconnect:  5 * time.Second,
+	}
+}
+
+// Run subscribes to task channels and handles messages until ctx is done.
+// Tasks are executed one by one, server allows only one claimed task per zond.
+func (z *Zond) Run(ctx context.Context) error {
+	tasks := make(chan api.Action, 100)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case action := <-tasks:
+				z.Handle(ctx, action)
+			}
+		}
+	}()
+
+	return resubscribe(ctx, z.Reconnect, func() error {
+		return z.Subscribe(ctx, func(action api.Action) {
+			if action.Action == api.ActionAlive {
+				go z.Handle(ctx, action)
+				return
+			}
+			select {
+			case tasks <- action:
+			default:
+				log.Println("queue is full, skipped", action.UUID)
+			}
+		})
+	})
+}
+
+// Subscribe reads messages sent to zond with EventSource until stream is
+// closed, networks of zond are advertised with subscription
+func (z *Zond) Subscribe(ctx context.Context, fn func(api.Action)) error {
+	var extra http.Header
+	if len(z.Networks) > 0 {
+		extra = http.Header{}
+		extra.Set(api.HeaderZondNetwork, strings.Join(z.Networks, ","))
+	}
+	return subscribe(ctx, z.HTTPClient, z.Server, api.HeaderZondUUID, z.UUID, z.Secret, extra, fn)
+}
+
+// Handle answers alive checks and executes tasks, other messages are ignored
+func (z *Zond) Handle(ctx context.Context, action api.Action) {
+	switch action.Action {
+	case api.ActionAlive:
+		if err := z.Pong(ctx, action.UUID); err != nil {
+			log.Println("pong:", err)
+		}
+	case api.ActionDestinations, api.ActionUpdated, api.ActionResult, api.ActionOnline, api.ActionOffline, "":
+	default:
+		if err := z.Process(ctx, action); err != nil && err != ErrNotClaimed && err != ErrBusy {
+			log.Println(action.UUID, err)
+		}
+	}
+}
+
+// Process claims task, executes it and reports result
+func (z *Zond) Process(ctx context.Context, action api.Action) error {
+	attempt, err := z.Block(ctx, action)
+	if err != nil {
+		return err
+	}
+	action.Attempt = attempt
+
+	timeout := time.Duration(action.TimeOut) * time.Second
+	if timeout <= 0 {
+		timeout = 60 * time.Second
+	}
+	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	result := z.Executor.Execute(execCtx, action)
+	cancel()
+
+	if len(result) > api.MaxResultSize {
+		result = result[:api.MaxResultSize]
+	}
+
+	return z.SendResult(ctx, action, result)
+}
+
+// Block claims task and returns attempt number of claim, ErrNotClaimed or
+// ErrBusy is returned when task can't be taken
+func (z *Zond) Block(ctx context.Context, action api.Action) (int64, error) {
+	res, err := z.post(ctx, api.PathZondBlock, api.Action{ZondUUID: z.UUID, Action: action.Action, UUID: action.UUID})
+	if err != nil {
+		return 0, err
+	}
+	if res.Status != "ok" {
+		if res.Message == api.MessageBusy {
+			return 0, ErrBusy
+		}
+		return 0, ErrNotClaimed
+	}
+	return res.Attempt, nil
+}
+
+// SendResult reports result of claimed task, attempt of action must be the one Block returned
+func (z *Zond) SendResult(ctx context.Context, action api.Action, result string) error {
+	res, err := z.post(ctx, api.PathZondResult, api.Action{ZondUUID: z.UUID, Action: api.ActionResult, UUID: action.UUID, Attempt: action.Attempt, Result: result})
+	if err != nil {
+		return err
+	}
+	if res.Status != "ok" {
+		return fmt.Errorf("result rejected: %s", res.Message)
+	}
+	return nil
+}
+
+// Pong answers alive check with uuid of alive message
+func (z *Zond) Pong(ctx context.Context, uuid string) error {
+	res, err := z.post(ctx, api.PathZondPong, api.Action{ZondUUID: z.UUID, UUID: uuid})
+	if err != nil {
+		return err
+	}
+	if res.Status != "ok" {
+		return fmt.Errorf("pong rejected: %s", res.Message)
+	}
+	return nil
+}
+
+func (z *Zond) post(ctx context.Context, path string, action api.Action) (api.Result, error) {
+	var res api.Result
+	err := post(ctx, z.HTTPClient, z.Server+path, api.HeaderZondUUID, z.UUID, z.Secret, action, &res)
+	return res, err
+}
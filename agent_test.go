@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ad/gocc/agent"
+	"github.com/ad/gocc/api"
+)
+
+func TestAgentZondProcess(t *testing.T) {
+	c, srv := newTestClient(t, "alice")
+	ctx := context.Background()
+
+	created, err := c.CreateZond(ctx, "z1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := c.CreateTask(ctx, api.TaskCreateRequest{Action: "ping", Param: "8.8.8.8", QueueTTL: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var executed api.Action
+	zond := agent.NewZond(srv.URL, created.UUID, created.Secret, agent.ExecutorFunc(func(ctx context.Context, action api.Action) string {
+		executed = action
+		return "pong from z1"
+	}))
+	if err := zond.Process(ctx, *task); err != nil {
+		t.Fatal(err)
+	}
+	if executed.UUID != task.UUID || executed.Attempt != 1 {
+		t.Errorf("executed %+v, want attempt 1 of %s", executed, task.UUID)
+	}
+
+	done, err := c.GetTask(ctx, task.UUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if done.Result != "pong from z1" || done.ZondUUID != created.UUID {
+		t.Errorf("task after result = %+v", done)
+	}
+
+	// finished task can't be claimed again
+	if _, err := zond.Block(ctx, *task); err != agent.ErrNotClaimed {
+		t.Errorf("Block of finished task: %v, want %v", err, agent.ErrNotClaimed)
+	}
+}
+
+func TestAgentZondBadSecret(t *testing.T) {
+	c, srv := newTestClient(t, "alice")
+	ctx := context.Background()
+
+	created, err := c.CreateZond(ctx, "z1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := c.CreateTask(ctx, api.TaskCreateRequest{Action: "ping", Param: "8.8.8.8", QueueTTL: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zond := agent.NewZond(srv.URL, created.UUID, "wrong", agent.ExecutorFunc(func(context.Context, api.Action) string {
+		t.Error("task with bad signature executed")
+		return ""
+	}))
+	if err := zond.Process(ctx, *task); err == nil {
+		t.Error("Process with bad signature succeeded")
+	}
+}
+
+func TestAgentPong(t *testing.T) {
+	c, srv := newTestClient(t, "alice")
+	ctx := context.Background()
+
+	createdZond, err := c.CreateZond(ctx, "z1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	createdMngr, err := c.CreateMngr(ctx, "m1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zond := agent.NewZond(srv.URL, createdZond.UUID, createdZond.Secret, nil)
+	mngr := agent.NewMngr(srv.URL, createdMngr.UUID, createdMngr.Secret)
+	pongs := []struct {
+		name  string
+		uuid  string
+		agent func(ctx context.Context, uuid string) error
+	}{
+		{"zond", createdZond.UUID, zond.Pong},
+		{"manager", createdMngr.UUID, mngr.Pong},
+	}
+
+	for _, pong := range pongs {
+		Client.Set(pong.uuid+"/alive", "check1", 0)
+		if err := pong.agent(ctx, "check1"); err != nil {
+			t.Errorf("%s pong: %v", pong.name, err)
+		}
+		if Client.Exists(pong.uuid+"/alive").Val() != 0 {
+			t.Errorf("%s alive check not cleared", pong.name)
+		}
+		// answered check is unknown
+		if err := pong.agent(ctx, "check1"); err == nil {
+			t.Errorf("%s pong of answered check succeeded", pong.name)
+		}
+	}
+}
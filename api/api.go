@@ -0,0 +1,312 @@
+package api
+
+// Machine-readable error codes returned in the "code" field of Error.
+const (
+	ErrCodeBadRequest   = "bad_request"
+	ErrCodeInvalidJSON  = "invalid_json"
+	ErrCodeMissingParam = "missing_param"
+	ErrCodeInvalidParam = "invalid_param"
+	ErrCodeUnauthorized = "unauthorized"
+	ErrCodeForbidden    = "forbidden"
+	ErrCodeNotFound     = "not_found"
+	ErrCodeConflict     = "conflict"
+	ErrCodeNoZonds      = "no_zonds"
+	ErrCodeInternal     = "internal_error"
+)
+
+// Error is the error part of the envelope returned by every API endpoint.
+type Error struct {
+	HTTPStatus int    `json:"-"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+type ErrorResponse struct {
+	Status string `json:"status"`
+	Error  *Error `json:"error"`
+}
+
+type StatusResponse struct {
+	Status string `json:"status"`
+}
+
+type TaskCreateRequest struct {
+	Action string `json:"action"`
+	// Param is IPv4 or IPv6 address or hostname, http(s) url for head with
+	// IPv6 host in brackets, host or host@resolver for dns
+	Param string `json:"param"`
+	// Params are structured parameters of action, e.g. {"version": 1,
+	// "host": "example.com", "count": 5} for ping, used instead of Param
+	Params Params `json:"params,omitempty"`
+	Type   string `json:"type"`
+	Count  int64  `json:"count"`
+	// Dest is empty for any zond, zond:uuid:, zond:city:, zond:country: or
+	// zond:asn: prefixed value, or label selector like country=DE,net=mobile,!ipv6
+	Dest   string `json:"dest"`
+	Repeat string `json:"repeat"`
+	// QueueTTL is how many seconds task is kept in queue waiting for online
+	// zond of its destination, task without eligible zonds is rejected when
+	// it is 0 and server has no default
+	QueueTTL int64 `json:"queue_ttl,omitempty"`
+}
+
+// TaskCreateResponse is answer to task creation, Eligible is count of online
+// zonds matching destination at the moment of creation
+type TaskCreateResponse struct {
+	Status   string `json:"status"`
+	UUID     string `json:"uuid"`
+	Task     Action `json:"task"`
+	Eligible int    `json:"eligible"`
+}
+
+// TaskBulkRequest creates task for every target with shared settings, it is
+// sent as JSON or as multipart form with targets file of one target per line
+type TaskBulkRequest struct {
+	Action   string   `json:"action"`
+	Targets  []string `json:"targets"`
+	Type     string   `json:"type"`
+	Count    int64    `json:"count"`
+	Dest     string   `json:"dest"`
+	Repeat   string   `json:"repeat"`
+	QueueTTL int64    `json:"queue_ttl,omitempty"`
+	// Params are structured parameters of tasks, one task per item created
+	// after targets, each is checked like Params of TaskCreateRequest
+	Params []Params `json:"params,omitempty"`
+}
+
+// TaskBulkResult is outcome of one target or params item of bulk request,
+// UUID is set when task is created and Error when target is rejected.
+// Target of params item is its legacy param
+type TaskBulkResult struct {
+	Target string `json:"target"`
+	UUID   string `json:"uuid,omitempty"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// TaskBulkResponse is answer to bulk request, created tasks have Batch set
+// to its id, so they can be listed with batch filter
+type TaskBulkResponse struct {
+	Status   string           `json:"status"`
+	Batch    string           `json:"batch"`
+	Created  int              `json:"created"`
+	Failed   int              `json:"failed"`
+	Eligible int              `json:"eligible"`
+	Results  []TaskBulkResult `json:"results"`
+}
+
+type TaskResponse struct {
+	Status string `json:"status"`
+	Task   Action `json:"task"`
+}
+
+// TaskListResponse is page of list, next_cursor continues list after it and
+// is set while there are more entries. Page is 0 on pages read by cursor
+type TaskListResponse struct {
+	Status     string   `json:"status"`
+	Results    []Action `json:"results"`
+	Count      int64    `json:"count"`
+	Pages      int      `json:"pages"`
+	Page       int      `json:"page"`
+	HasPrev    bool     `json:"has_prev"`
+	HasNext    bool     `json:"has_next"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
+
+type CreateRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateResponse is answer to creation of zond or manager, Secret signs
+// requests of agent and is shown only once. EnrollToken is exchanged by
+// zond for client certificate when certificate enrollment is enabled
+type CreateResponse struct {
+	Status      string `json:"status"`
+	UUID        string `json:"uuid"`
+	Secret      string `json:"secret,omitempty"`
+	EnrollToken string `json:"enroll_token,omitempty"`
+}
+
+// ZondCreateLegacyResponse is answer of legacy /api/zond/create, it keeps
+// "UUID" key clients of the unversioned api read
+type ZondCreateLegacyResponse struct {
+	Status      string `json:"status"`
+	UUID        string `json:"UUID"`
+	Secret      string `json:"secret,omitempty"`
+	EnrollToken string `json:"enroll_token,omitempty"`
+}
+
+// EnrollRequest exchanges one-time token for client certificate of zond,
+// CSR is PEM certificate request for key generated by zond
+type EnrollRequest struct {
+	Token string `json:"token"`
+	CSR   string `json:"csr"`
+}
+
+// EnrollResponse has PEM client certificate of zond and CA certificate
+type EnrollResponse struct {
+	Status      string `json:"status"`
+	UUID        string `json:"uuid"`
+	Certificate string `json:"certificate"`
+	CA          string `json:"ca"`
+}
+
+// UpdateRequest changes name or state of zond or manager, omitted fields are
+// kept. Labels replace all labels of zond, managers have no labels
+type UpdateRequest struct {
+	Name     *string            `json:"name,omitempty"`
+	Disabled *bool              `json:"disabled,omitempty"`
+	Labels   *map[string]string `json:"labels,omitempty"`
+}
+
+// ZondResponse is answer to zond update, Secret and EnrollToken are set
+// only when credentials are rotated
+type ZondResponse struct {
+	Status      string `json:"status"`
+	Zond        Zond   `json:"zond"`
+	Secret      string `json:"secret,omitempty"`
+	EnrollToken string `json:"enroll_token,omitempty"`
+}
+
+// MngrResponse is answer to manager update, Secret is set only when credentials are rotated
+type MngrResponse struct {
+	Status string `json:"status"`
+	Mngr   Mngr   `json:"manager"`
+	Secret string `json:"secret,omitempty"`
+}
+
+type ZondListResponse struct {
+	Status     string `json:"status"`
+	Results    []Zond `json:"results"`
+	Count      int64  `json:"count"`
+	Pages      int    `json:"pages"`
+	Page       int    `json:"page"`
+	HasPrev    bool   `json:"has_prev"`
+	HasNext    bool   `json:"has_next"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type MngrListResponse struct {
+	Status     string `json:"status"`
+	Results    []Mngr `json:"results"`
+	Count      int64  `json:"count"`
+	Pages      int    `json:"pages"`
+	Page       int    `json:"page"`
+	HasPrev    bool   `json:"has_prev"`
+	HasNext    bool   `json:"has_next"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// TaskAttempt is claim of task by zond or manager. Outcome is reported when
+// agent sent result of this attempt, claimed while claim is active and
+// returned when task went back to queue
+type TaskAttempt struct {
+	Attempt  int64  `json:"attempt"`
+	Agent    string `json:"agent"`
+	Claimed  int64  `json:"claimed"`
+	Deadline int64  `json:"deadline"`
+	Outcome  string `json:"outcome"`
+}
+
+// TaskZond is zond that ran task, geo data is taken when zond claimed it
+type TaskZond struct {
+	UUID    string            `json:"uuid"`
+	Name    string            `json:"name,omitempty"`
+	City    string            `json:"city,omitempty"`
+	Country string            `json:"country,omitempty"`
+	ASN     string            `json:"asn,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+// TaskDetailResponse is task with everything known about its run. Fields are
+// parsed from result as in export, Runs are newest other runs of schedule
+type TaskDetailResponse struct {
+	Status     string            `json:"status"`
+	Task       Action            `json:"task"`
+	TaskStatus string            `json:"task_status"`
+	Fields     map[string]string `json:"fields"`
+	Zond       *TaskZond         `json:"zond,omitempty"`
+	Created    int64             `json:"created"`
+	Deadline   int64             `json:"deadline"`
+	Claimed    int64             `json:"claimed,omitempty"`
+	Finished   int64             `json:"finished,omitempty"`
+	Attempts   []TaskAttempt     `json:"attempts"`
+	Children   []Action          `json:"children"`
+	Runs       []Action          `json:"runs"`
+}
+
+// DNSZondAnswer is answer of one zond in DNSConsistencyResponse
+type DNSZondAnswer struct {
+	Task     string    `json:"task"`
+	Zond     *TaskZond `json:"zond,omitempty"`
+	Resolver string    `json:"resolver,omitempty"`
+	DNSSEC   string    `json:"dnssec,omitempty"`
+	// RTT is time of query in milliseconds
+	RTT float64 `json:"rtt_ms,omitempty"`
+}
+
+// DNSAnswerGroup is set of zonds that got the same answer. Answers are
+// sorted values without TTL, failed queries are grouped by RCode or Error
+type DNSAnswerGroup struct {
+	Answers []string        `json:"answers"`
+	RCode   string          `json:"rcode,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Zonds   []DNSZondAnswer `json:"zonds"`
+	// Majority is true for the group most zonds are in, other groups are
+	// inconsistent with it
+	Majority bool `json:"majority"`
+}
+
+// DNSConsistencyResponse compares answers of child tasks of dns measurement.
+// Groups are ordered by count of zonds, Missing counts children without
+// result: queued, running or expired
+type DNSConsistencyResponse struct {
+	Status     string           `json:"status"`
+	Task       Action           `json:"task"`
+	Consistent bool             `json:"consistent"`
+	Answered   int              `json:"answered"`
+	Missing    int              `json:"missing"`
+	Groups     []DNSAnswerGroup `json:"groups"`
+}
+
+// RetentionPolicy tells how long finished tasks are kept. MaxAge is in
+// seconds since creation of task, MaxRuns is count of newest finished runs
+// kept per schedule, 0 keeps everything
+type RetentionPolicy struct {
+	MaxAge  int64 `json:"max_age,omitempty"`
+	MaxRuns int64 `json:"max_runs,omitempty"`
+}
+
+// RetentionResponse is retention policy of user and policies of its
+// schedules by schedule uuid, Default is policy of server
+type RetentionResponse struct {
+	Status    string                     `json:"status"`
+	Policy    RetentionPolicy            `json:"policy"`
+	Default   RetentionPolicy            `json:"default"`
+	Schedules map[string]RetentionPolicy `json:"schedules"`
+}
+
+// CompactionReport tells what compaction removed, or would remove when
+// DryRun is set. Indexes are user/tasks entries of missing tasks, Done are
+// tasks-done entries of removed or missing tasks
+type CompactionReport struct {
+	Status   string `json:"status"`
+	DryRun   bool   `json:"dry_run"`
+	Users    int    `json:"users"`
+	Tasks    int64  `json:"tasks"`
+	Bytes    int64  `json:"bytes"`
+	Archived int64  `json:"archived"`
+	Indexes  int64  `json:"indexes"`
+	Done     int64  `json:"done"`
+}
+
+// ExportRecord is line of JSON Lines export of tasks, Fields are values
+// parsed from result of task by its action
+type ExportRecord struct {
+	Action
+	Schedule string            `json:"schedule,omitempty"`
+	Fields   map[string]string `json:"fields,omitempty"`
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Headers of signed agent requests, agent uuid is sent in HeaderZondUUID or HeaderMngrUUID
+const (
+	HeaderTimestamp = "X-Timestamp"
+	HeaderNonce     = "X-Nonce"
+	HeaderSignature = "X-Signature"
+)
+
+// SignatureMaxSkew is allowed difference in seconds between timestamp of
+// request and server time, nonces are remembered twice as long
+const SignatureMaxSkew = 300
+
+// MaxNonceLength limits length of HeaderNonce
+const MaxNonceLength = 64
+
+// SignatureBase is string signed by agent: method, path without query,
+// unix timestamp, nonce and hex sha256 of body, separated by newlines.
+// Subscriptions are signed as GET of PathSubscribe with empty body
+func SignatureBase(method string, path string, timestamp string, nonce string, body []byte) string {
+	sum := sha256.Sum256(body)
+	return strings.Join([]string{strings.ToUpper(method), path, timestamp, nonce, hex.EncodeToString(sum[:])}, "\n")
+}
+
+// Sign returns hex HMAC-SHA256 of SignatureBase with agent secret
+func Sign(secret string, method string, path string, timestamp string, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(SignatureBase(method, path, timestamp, nonce, body)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,320 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ParamsVersion is version of task parameters written by this package,
+// params without version are taken as version 1
+const ParamsVersion = 1
+
+// DefaultResolver is resolver of dns tasks without one
+const DefaultResolver = "8.8.8.8"
+
+var (
+	// ErrUnknownAction is returned for parameters of action without params schema
+	ErrUnknownAction = errors.New("unknown action")
+	// ErrParamsVersion is returned for params of newer version than ParamsVersion
+	ErrParamsVersion = errors.New("unsupported params version")
+)
+
+// Params is versioned structured parameters of task in JSON, its fields
+// depend on action: PingParams, TracerouteParams, HeadParams, DNSParams,
+// TCPParams, TLSParams or HTTPParams.
+// Server carries params to agents unchanged, Action.Param keeps the legacy
+// string for agents that don't read params
+type Params json.RawMessage
+
+// MarshalJSON returns params as they are
+func (p Params) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return []byte("null"), nil
+	}
+	return p, nil
+}
+
+// UnmarshalJSON keeps copy of params
+func (p *Params) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*p = nil
+		return nil
+	}
+	*p = append((*p)[0:0], data...)
+	return nil
+}
+
+// TaskParams are structured parameters of one action
+type TaskParams interface {
+	// Legacy returns Action.Param of the same task
+	Legacy() string
+	// fromLegacy fills params from Action.Param
+	fromLegacy(param string)
+	version() *int
+}
+
+// PingParams are parameters of ping, zero values are defaults of agent
+type PingParams struct {
+	Version int    `json:"version"`
+	Host    string `json:"host"`
+	// Count of echo requests
+	Count int `json:"count,omitempty"`
+	// Size of payload in bytes
+	Size int `json:"size,omitempty"`
+	// Timeout in seconds
+	Timeout int64 `json:"timeout,omitempty"`
+}
+
+func (p *PingParams) Legacy() string { return p.Host }
+
+func (p *PingParams) version() *int { return &p.Version }
+
+func (p *PingParams) fromLegacy(param string) { p.Host = param }
+
+// TracerouteParams are parameters of traceroute, zero values are defaults of agent
+type TracerouteParams struct {
+	Version int    `json:"version"`
+	Host    string `json:"host"`
+	// MaxTTL is the last hop probed
+	MaxTTL int `json:"max_ttl,omitempty"`
+	// Timeout is wait for each probe in seconds
+	Timeout int64 `json:"timeout,omitempty"`
+}
+
+func (p *TracerouteParams) Legacy() string { return p.Host }
+
+func (p *TracerouteParams) version() *int { return &p.Version }
+
+func (p *TracerouteParams) fromLegacy(param string) { p.Host = param }
+
+// HeadParams are parameters of head
+type HeadParams struct {
+	Version int    `json:"version"`
+	URL     string `json:"url"`
+	// ExpectStatus fails task when status code differs, 0 takes any
+	ExpectStatus int `json:"expect_status,omitempty"`
+	// Timeout in seconds
+	Timeout int64 `json:"timeout,omitempty"`
+}
+
+func (p *HeadParams) Legacy() string { return p.URL }
+
+func (p *HeadParams) version() *int { return &p.Version }
+
+func (p *HeadParams) fromLegacy(param string) { p.URL = param }
+
+// LocalResolver is resolver of dns params asking resolver configured on zond
+const LocalResolver = "local"
+
+// Transports of dns queries
+const (
+	DNSTransportUDP = "udp"
+	DNSTransportTCP = "tcp"
+	// DNSTransportDoT is DNS over TLS, RFC 7858
+	DNSTransportDoT = "dot"
+	// DNSTransportDoH is DNS over HTTPS, RFC 8484
+	DNSTransportDoH = "doh"
+)
+
+// DNSParams are parameters of dns
+type DNSParams struct {
+	Version int    `json:"version"`
+	Host    string `json:"host"`
+	// Resolver is ip address, hostname without dashes or LocalResolver,
+	// DefaultResolver when empty
+	Resolver string `json:"resolver,omitempty"`
+	// Type is record type: A, AAAA, CNAME, MX, TXT, NS, SOA, CAA or PTR.
+	// PTR of ip address asks for its reverse name. Empty resolves ip address to names and
+	// hostname to addresses
+	Type string `json:"type,omitempty"`
+	// Transport is DNSTransportUDP when empty, UDP answers that don't fit
+	// are asked again over TCP
+	Transport string `json:"transport,omitempty"`
+	// Port of resolver, default of transport when 0: 53, 853 for DoT and
+	// 443 for DoH
+	Port int `json:"port,omitempty"`
+	// Path of DoH url, /dns-query when empty
+	Path string `json:"path,omitempty"`
+	// NoRecursion clears recursion desired flag of query
+	NoRecursion bool `json:"no_recursion,omitempty"`
+	// DNSSEC asks resolver for DNSSEC validation, see DNSResult.DNSSEC
+	DNSSEC bool `json:"dnssec,omitempty"`
+	// Timeout of query in seconds
+	Timeout int64 `json:"timeout,omitempty"`
+}
+
+// Legacy returns host-resolver, agents split it on the last "-"
+func (p *DNSParams) Legacy() string {
+	resolver := p.Resolver
+	if resolver == "" {
+		resolver = DefaultResolver
+	}
+	return p.Host + "-" + resolver
+}
+
+func (p *DNSParams) version() *int { return &p.Version }
+
+func (p *DNSParams) fromLegacy(param string) {
+	p.Host, p.Resolver = param, DefaultResolver
+	if i := strings.LastIndex(param, "-"); i > 0 {
+		p.Host, p.Resolver = param[:i], param[i+1:]
+	}
+}
+
+// TCPParams are parameters of tcp, connection to port of host
+type TCPParams struct {
+	Version int    `json:"version"`
+	Host    string `json:"host"`
+	Port    int    `json:"port"`
+	// Timeout of connect in seconds
+	Timeout int64 `json:"timeout,omitempty"`
+}
+
+// Legacy returns host:port, IPv6 host is in brackets
+func (p *TCPParams) Legacy() string { return net.JoinHostPort(p.Host, strconv.Itoa(p.Port)) }
+
+func (p *TCPParams) version() *int { return &p.Version }
+
+func (p *TCPParams) fromLegacy(param string) {
+	p.Host = param
+	if host, port, err := net.SplitHostPort(param); err == nil {
+		p.Host = host
+		p.Port, _ = strconv.Atoi(port)
+	}
+}
+
+// DefaultTLSPort is port of tls tasks without one
+const DefaultTLSPort = 443
+
+// TLSParams are parameters of tls, handshake with port of host
+type TLSParams struct {
+	Version int    `json:"version"`
+	Host    string `json:"host"`
+	// Port is DefaultTLSPort when 0
+	Port int `json:"port,omitempty"`
+	// SNI is server name sent in handshake and checked in certificate,
+	// host when empty. Empty SNI of ip address host is not sent
+	SNI string `json:"sni,omitempty"`
+	// Timeout of connect and handshake in seconds
+	Timeout int64 `json:"timeout,omitempty"`
+}
+
+// Legacy returns host:port, IPv6 host is in brackets
+func (p *TLSParams) Legacy() string {
+	port := p.Port
+	if port == 0 {
+		port = DefaultTLSPort
+	}
+	return net.JoinHostPort(p.Host, strconv.Itoa(port))
+}
+
+func (p *TLSParams) version() *int { return &p.Version }
+
+func (p *TLSParams) fromLegacy(param string) {
+	p.Host, p.Port = param, DefaultTLSPort
+	if host, port, err := net.SplitHostPort(param); err == nil {
+		p.Host = host
+		p.Port, _ = strconv.Atoi(port)
+	}
+}
+
+// HTTPParams are parameters of http, request to url checked by assertions
+type HTTPParams struct {
+	Version int    `json:"version"`
+	URL     string `json:"url"`
+	// Method is GET when empty
+	Method  string            `json:"method,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+	// MaxRedirects is number of redirects followed, 0 reports redirect itself
+	MaxRedirects int `json:"max_redirects,omitempty"`
+	// Timeout of whole request in seconds, ConnectTimeout of connect only
+	Timeout        int64 `json:"timeout,omitempty"`
+	ConnectTimeout int64 `json:"connect_timeout,omitempty"`
+	// Assert lists checks of response, result passes when all of them pass
+	Assert *HTTPAssertions `json:"assert,omitempty"`
+}
+
+// HTTPAssertions are checks of http response, empty ones are skipped
+type HTTPAssertions struct {
+	// Status is list of accepted status codes
+	Status []int `json:"status,omitempty"`
+	// Headers must contain value, empty value checks header is present
+	Headers map[string]string `json:"headers,omitempty"`
+	// BodyContains is substring and BodyRegex is regular expression of body
+	BodyContains string `json:"body_contains,omitempty"`
+	BodyRegex    string `json:"body_regex,omitempty"`
+	// MaxLatency is highest total time of request in milliseconds
+	MaxLatency int64 `json:"max_latency_ms,omitempty"`
+}
+
+func (p *HTTPParams) Legacy() string { return p.URL }
+
+func (p *HTTPParams) version() *int { return &p.Version }
+
+func (p *HTTPParams) fromLegacy(param string) { p.URL = param }
+
+// NewParams returns empty parameters of action
+func NewParams(action string) (TaskParams, error) {
+	switch action {
+	case "ping":
+		return &PingParams{}, nil
+	case "traceroute":
+		return &TracerouteParams{}, nil
+	case "head":
+		return &HeadParams{}, nil
+	case "dns":
+		return &DNSParams{}, nil
+	case "tcp":
+		return &TCPParams{}, nil
+	case "tls":
+		return &TLSParams{}, nil
+	case "http":
+		return &HTTPParams{}, nil
+	}
+	return nil, ErrUnknownAction
+}
+
+// LegacyParams returns parameters of action with legacy param
+func LegacyParams(action string, param string) (TaskParams, error) {
+	p, err := NewParams(action)
+	if err != nil {
+		return nil, err
+	}
+	p.fromLegacy(param)
+	*p.version() = ParamsVersion
+	return p, nil
+}
+
+// DecodeParams returns parameters of task, tasks created without params get
+// them from legacy Param. Params of newer version give ErrParamsVersion
+func DecodeParams(a Action) (TaskParams, error) {
+	if len(a.Params) == 0 {
+		return LegacyParams(a.Action, a.Param)
+	}
+
+	p, err := NewParams(a.Action)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(a.Params, p); err != nil {
+		return nil, err
+	}
+	return p, CheckVersion(p)
+}
+
+// CheckVersion sets current version to params without one and rejects
+// params of newer version
+func CheckVersion(p TaskParams) error {
+	v := p.version()
+	if *v == 0 {
+		*v = ParamsVersion
+	}
+	if *v < 0 || *v > ParamsVersion {
+		return ErrParamsVersion
+	}
+	return nil
+}
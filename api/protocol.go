@@ -0,0 +1,104 @@
+package api
+
+// Headers identifying agents on every request and subscription
+const (
+	HeaderZondUUID = "X-ZondUuid"
+	HeaderMngrUUID = "X-MngrUuid"
+)
+
+// HeaderZondNetwork is sent by zond on subscription with comma separated
+// address families it reaches, e.g. "ipv4,ipv6". Without it families are
+// taken from address zond connects from
+const HeaderZondNetwork = "X-Zond-Network"
+
+// Address families of zond networks
+const (
+	NetworkIPv4 = "ipv4"
+	NetworkIPv6 = "ipv6"
+)
+
+// ChannelNetwork is channel of zonds reaching address family, tasks to
+// IPv6 targets without other destination are published there
+func ChannelNetwork(family string) string {
+	return "Net:" + family
+}
+
+// Endpoints of zond and manager protocol
+const (
+	PathSubscribe  = "/sub"
+	PathZondBlock  = "/zond/task/block"
+	PathZondResult = "/zond/task/result"
+	PathZondPong   = "/zond/pong"
+	PathMngrBlock  = "/mngr/task/block"
+	PathMngrResult = "/mngr/task/result"
+	PathMngrPong   = "/mngr/pong"
+
+	PathMngrTaskCreate = "/mngr/task/create"
+	PathZondEnroll     = "/zond/enroll"
+)
+
+// Values of Action.Type
+const (
+	TypeTask        = "task"
+	TypeMeasurement = "measurement"
+)
+
+// ChannelMngr is personal channel of manager, it receives alive checks and
+// results of child tasks created by manager
+func ChannelMngr(uuid string) string {
+	return "mngr" + uuid
+}
+
+// Service values of Action.Action, any other value is a task to execute
+const (
+	ActionAlive        = "alive"
+	ActionResult       = "result"
+	ActionDestinations = "destinations"
+	ActionUpdated      = "updated"
+	ActionOnline       = "online"
+	ActionOffline      = "offline"
+)
+
+// Values of Result.Message answered to block and result requests
+const (
+	MessageOK           = "ok"
+	MessageTaskNotFound = "task not found"
+	MessageBusy         = "only one task at time is allowed"
+
+	// results are checked against claim of authenticated agent
+	MessageWrongAgent     = "agent does not match credentials"
+	MessageClaimExpired   = "claim expired"
+	MessageWrongAttempt   = "wrong attempt"
+	MessageResultTooLarge = "result is too large"
+)
+
+// MaxResultSize limits result of zond task in bytes, longer results are rejected
+const MaxResultSize = 64 * 1024
+
+// MaxChildTasks limits count of child tasks created by manager for one measurement
+const MaxChildTasks = 100
+
+// ChildTasksResponse is answer to PathMngrTaskCreate
+type ChildTasksResponse struct {
+	Status  string   `json:"status"`
+	Message string   `json:"message"`
+	Tasks   []Action `json:"tasks"`
+}
+
+// MeasurementResult is stored as JSON in Result of measurement task,
+// manager aggregates it from results of child tasks
+type MeasurementResult struct {
+	Total    int           `json:"total"`
+	Done     int           `json:"done"`
+	Failed   int           `json:"failed"`
+	Missing  int           `json:"missing"`
+	Children []ChildResult `json:"children"`
+}
+
+type ChildResult struct {
+	UUID     string `json:"uuid"`
+	ZondUUID string `json:"zond"`
+	Result   string `json:"result"`
+	Updated  int64  `json:"updated"`
+	Status   string `json:"status,omitempty"`
+}
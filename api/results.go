@@ -0,0 +1,189 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// TCPResult is result of tcp task, zond reports it as JSON
+type TCPResult struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+	// Address is ip:port connection was made to
+	Address   string `json:"address,omitempty"`
+	Connected bool   `json:"connected"`
+	// ConnectTime is time of connect in milliseconds
+	ConnectTime float64 `json:"connect_ms,omitempty"`
+	// Error of connect, e.g. connection refused or timeout
+	Error string `json:"error,omitempty"`
+}
+
+// TLSResult is result of tls task, zond reports it as JSON. Certificates are
+// reported even when they don't verify
+type TLSResult struct {
+	Host       string `json:"host"`
+	Port       int    `json:"port"`
+	ServerName string `json:"sni,omitempty"`
+	// Address is ip:port handshake was made with
+	Address string `json:"address,omitempty"`
+	// Protocol is negotiated version, e.g. TLS 1.3
+	Protocol    string `json:"protocol,omitempty"`
+	CipherSuite string `json:"cipher_suite,omitempty"`
+	// HandshakeTime is time of connect and handshake in milliseconds
+	HandshakeTime float64 `json:"handshake_ms,omitempty"`
+	// Verified is true when chain verifies with system roots for server name
+	Verified    bool   `json:"verified"`
+	VerifyError string `json:"verify_error,omitempty"`
+	// Expires is unix time leaf certificate expires at, DaysLeft counts
+	// whole days until then and is negative for expired certificate
+	Expires  int64 `json:"expires,omitempty"`
+	DaysLeft int64 `json:"days_left"`
+	// OCSPStapled is true when server stapled OCSP response, OCSPStatus is
+	// good, revoked or unknown when it could be parsed
+	OCSPStapled bool   `json:"ocsp_stapled"`
+	OCSPStatus  string `json:"ocsp_status,omitempty"`
+	// Chain is certificates sent by server, leaf first
+	Chain []TLSCertificate `json:"chain,omitempty"`
+	// Error of connect or handshake
+	Error string `json:"error,omitempty"`
+}
+
+// TLSCertificate is certificate of TLSResult chain
+type TLSCertificate struct {
+	Subject string `json:"subject"`
+	Issuer  string `json:"issuer"`
+	Serial  string `json:"serial"`
+	// NotBefore and NotAfter are unix times
+	NotBefore int64 `json:"not_before"`
+	NotAfter  int64 `json:"not_after"`
+	// DNSNames and IPAddresses are subject alternative names
+	DNSNames           []string `json:"dns_names,omitempty"`
+	IPAddresses        []string `json:"ip_addresses,omitempty"`
+	SignatureAlgorithm string   `json:"signature_algorithm"`
+	// SHA256 is hex fingerprint of certificate
+	SHA256 string `json:"sha256"`
+}
+
+// HTTPResult is result of http task, zond reports it as JSON. Failed
+// assertions are result with Passed false, not task error
+type HTTPResult struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// FinalURL is url of response after redirects
+	FinalURL   string `json:"final_url,omitempty"`
+	Redirects  int    `json:"redirects,omitempty"`
+	Address    string `json:"address,omitempty"`
+	Proto      string `json:"proto,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+	// Headers of response, dropped when result would be too large
+	Headers  map[string][]string `json:"headers,omitempty"`
+	BodySize int64               `json:"body_size"`
+	// BodyTruncated is set when body is longer than zond reads, BodySize
+	// is then the read part
+	BodyTruncated bool       `json:"body_truncated,omitempty"`
+	Timing        HTTPTiming `json:"timing"`
+	// Passed is true when request succeeded and all assertions passed
+	Passed     bool            `json:"passed"`
+	Assertions []HTTPAssertion `json:"assertions,omitempty"`
+	// Error of request, e.g. dns failure or timeout
+	Error string `json:"error,omitempty"`
+}
+
+// HTTPTiming is timing of the last request of HTTPResult in milliseconds,
+// phases not made, e.g. TLS of http url, are 0. Total is time of the whole
+// check with redirects and reading of body
+type HTTPTiming struct {
+	DNS       float64 `json:"dns_ms"`
+	Connect   float64 `json:"connect_ms"`
+	TLS       float64 `json:"tls_ms"`
+	FirstByte float64 `json:"first_byte_ms"`
+	Total     float64 `json:"total_ms"`
+}
+
+// HTTPAssertion is outcome of one check of HTTPAssertions
+type HTTPAssertion struct {
+	// Check is status, header:<name>, body_contains, body_regex or max_latency_ms
+	Check    string `json:"check"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Passed   bool   `json:"passed"`
+}
+
+// DNSSEC statuses of DNSResult
+const (
+	// DNSSECSecure is answer validated by resolver
+	DNSSECSecure = "secure"
+	// DNSSECInsecure is answer of unsigned zone or of resolver not validating
+	DNSSECInsecure = "insecure"
+	// DNSSECBogus is answer failing validation, resolver returns it only
+	// when validation is disabled
+	DNSSECBogus = "bogus"
+)
+
+// DNSResult is result of dns task, zond reports it as JSON. Zonds written
+// before it report text, see ParseDNSResult
+type DNSResult struct {
+	Host      string `json:"host"`
+	Type      string `json:"type,omitempty"`
+	Resolver  string `json:"resolver"`
+	Transport string `json:"transport,omitempty"`
+	// Server is address query was sent to, e.g. resolver of zond for
+	// LocalResolver or url for DoH
+	Server string `json:"server,omitempty"`
+	// RCode is response code, e.g. NOERROR or NXDOMAIN
+	RCode              string `json:"rcode,omitempty"`
+	Authoritative      bool   `json:"authoritative,omitempty"`
+	RecursionAvailable bool   `json:"recursion_available,omitempty"`
+	// DNSSEC is one of DNSSECSecure, DNSSECInsecure and DNSSECBogus when
+	// params asked for it
+	DNSSEC  string      `json:"dnssec,omitempty"`
+	Answers []DNSRecord `json:"answers"`
+	// RTT is time of query in milliseconds
+	RTT float64 `json:"rtt_ms,omitempty"`
+	// Error of query, e.g. timeout
+	Error string `json:"error,omitempty"`
+}
+
+// DNSRecord is record of DNSResult answer, Value is in zone file format,
+// e.g. "10 mx.example.com." for MX
+type DNSRecord struct {
+	Name  string `json:"name,omitempty"`
+	Type  string `json:"type"`
+	TTL   uint32 `json:"ttl"`
+	Value string `json:"value"`
+}
+
+// Values returns values of answers
+func (r DNSResult) Values() []string {
+	values := make([]string, len(r.Answers))
+	for i, answer := range r.Answers {
+		values[i] = answer.Value
+	}
+	return values
+}
+
+// ParseDNSResult returns result of dns task, text results of older zonds,
+// "host @resolver" followed by answer per line or error, are converted
+func ParseDNSResult(result string) (DNSResult, error) {
+	var r DNSResult
+	if strings.HasPrefix(result, "{") {
+		err := json.Unmarshal([]byte(result), &r)
+		return r, err
+	}
+
+	r.Answers = []DNSRecord{}
+	if strings.HasPrefix(result, "error") {
+		r.Error = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(result, "error"), ":"))
+		return r, nil
+	}
+	lines := strings.Split(result, "\n")
+	if s := strings.SplitN(lines[0], " @", 2); len(s) == 2 {
+		r.Host, r.Resolver = s[0], s[1]
+	}
+	for _, line := range lines[1:] {
+		if line != "" {
+			r.Answers = append(r.Answers, DNSRecord{Value: line})
+		}
+	}
+	return r, nil
+}
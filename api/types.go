@@ -0,0 +1,91 @@
+// Package api contains messages and request/response types shared by
+// gocc server, client package and reference agents.
+package api
+
+// Action is a task, measurement or service message (alive) sent to
+// zonds and managers, and reported back by them with result.
+type Action struct {
+	ZondUUID string `json:"zond"`
+	MngrUUID string `json:"manager"`
+	Creator  string `json:"creator"`
+	Type     string `json:"type"` // task/measurement
+	Count    int64  `json:"count"`
+	TimeOut  int64  `json:"timeout"`
+	Action   string `json:"action"`
+	Param    string `json:"param"`
+	// Params are structured parameters of action, Param is the same target
+	// in legacy form
+	Params     Params `json:"params,omitempty"`
+	Result     string `json:"result"`
+	ParentUUID string `json:"parent"`
+	Created    int64  `json:"created"`
+	Updated    int64  `json:"updated"`
+	Target     string `json:"target"`
+	Repeat     string `json:"repeat"`
+	UUID       string `json:"uuid"`
+	Status     string `json:"status,omitempty"`
+	// Attempt is number of claim of task, block answer tells it and result
+	// may repeat it, so result of previous claim is not taken
+	Attempt int64 `json:"attempt,omitempty"`
+	// QueueTTL is how many seconds after Created task is kept in queue before
+	// it expires, 0 is default of server
+	QueueTTL int64 `json:"queue_ttl,omitempty"`
+	// Batch is id of bulk request task was created by
+	Batch string `json:"batch,omitempty"`
+}
+
+// Task statuses stored in Action.Status, empty status means task is not finished yet
+const (
+	TaskStatusCanceled = "canceled"
+	// TaskStatusExpired is status of task no zond claimed before its deadline
+	TaskStatusExpired = "expired"
+)
+
+type Result struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Attempt int64  `json:"attempt,omitempty"`
+}
+
+type Zond struct {
+	Creator  string `json:"creator"`
+	UUID     string `json:"uuid"`
+	Name     string `json:"name"`
+	Created  int64  `json:"created"`
+	Updated  int64  `json:"updated"`
+	Disabled bool   `json:"disabled,omitempty"`
+	// Labels are free-form key=value labels matched by label selector of task dest
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type Mngr struct {
+	Creator  string `json:"creator"`
+	UUID     string `json:"uuid"`
+	Name     string `json:"name"`
+	Created  int64  `json:"created"`
+	Updated  int64  `json:"updated"`
+	IP       string `json:"ip"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+type Channels struct {
+	Action    string   `json:"action"`
+	Zonds     []string `json:"zonds"`
+	Countries []string `json:"countries"`
+	Cities    []string `json:"cities"`
+	ASNs      []string `json:"asns"`
+	Mngrs     []string `json:"mngrs"`
+	// Labels are values of labels of online zonds by label key
+	Labels map[string][]string `json:"labels"`
+}
+
+// Geodata struct
+type Geodata struct {
+	City                         string  `json:"city"`
+	Country                      string  `json:"country"`
+	CountryCode                  string  `json:"country_code"`
+	Longitude                    float64 `json:"lon"`
+	Latitude                     float64 `json:"lat"`
+	AutonomousSystemNumber       uint    `json:"asn"`
+	AutonomousSystemOrganization string  `json:"provider"`
+}
@@ -2,20 +2,18 @@ package main
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
-	"strconv"
 	"time"
 
+	"github.com/ad/gocc/api"
 	"github.com/gorilla/csrf"
 	uuid "github.com/nu7hatch/gouuid"
 )
 
-func ApiMngrCreateHandler(w http.ResponseWriter, r *http.Request) {
-	// if r.Method == "POST" {
-	name := r.FormValue("name")
-
+// CreateMngr registers new manager owned by user, secret of manager is returned
+// only here and is not shown again
+func CreateMngr(userUUID string, name string) (Mngr, string) {
 	u, _ := uuid.NewV4()
 	var UUID = u.String()
 	var msec = time.Now().Unix()
@@ -24,94 +22,171 @@ func ApiMngrCreateHandler(w http.ResponseWriter, r *http.Request) {
 		name = UUID
 	}
 
+	secret := NewAgentSecret()
+	Client.Set(agentSecretKey(UUID), secret, 0)
 	Client.SAdd("mngrs", UUID)
 
-	userUUID, _ := Client.Get("user/uuid/" + r.Header.Get("X-Forwarded-User")).Result()
-	if userUUID == "" {
-		u, _ := uuid.NewV4()
-		userUUID = u.String()
-		Client.Set(fmt.Sprintf("user/uuid/%s", r.Header.Get("X-Forwarded-User")), userUUID, 0)
-	}
-
-	zond := Mngr{UUID: UUID, Name: name, Created: msec, Creator: userUUID}
-	js, _ := json.Marshal(zond)
+	mngr := Mngr{UUID: UUID, Name: name, Created: msec, Creator: userUUID}
+	js, _ := json.Marshal(mngr)
 
 	Client.Set("mngrs/"+UUID, string(js), 0)
-	Client.SAdd("user/mngrs/"+userUUID, UUID)
+	IndexAdd(listMngrs, userUUID, UUID, msec)
 
 	log.Println("Manager created", UUID)
 
-	// if r.Header.Get("X-Requested-With") == "xmlhttprequest" {
-	// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-	fmt.Fprintf(w, `{"status": "ok", "uuid": "%s"}`, UUID)
-	// } else {
-	// 	ShowCreateForm(w, r, Uuid)
-	// }
-	// } else {
-	// 	ShowCreateForm(w, r, "")
-	// }
+	return mngr, secret
 }
 
+// Deprecated: use ApiV1MngrCreateHandler
+func ApiMngrCreateHandler(w http.ResponseWriter, r *http.Request) {
+	mngr, secret := CreateMngr(GetUserUUID(r), r.FormValue("name"))
+
+	WriteJSON(w, http.StatusOK, CreateResponse{Status: "ok", UUID: mngr.UUID, Secret: secret})
+}
+
+// ListUserMngrs returns page of managers of user created in range of query
+func ListUserMngrs(userUUID string, query ListQuery) MngrListResponse {
+	page := readList(listMngrs, userUUID, query, nil)
+
+	results := []Mngr{}
+	for _, js := range page.Entries {
+		var t Mngr
+		if err := json.Unmarshal([]byte(js), &t); err != nil {
+			log.Println(err.Error())
+		}
+		results = append(results, t)
+	}
+
+	return MngrListResponse{Status: "ok", Results: results, Count: page.Count, Pages: page.Pages, Page: page.Page, HasPrev: page.HasPrev, HasNext: page.HasNext, NextCursor: page.NextCursor}
+}
+
+// Deprecated: use ApiV1MngrListHandler
 func ApiShowMyMngrs(w http.ResponseWriter, r *http.Request) {
-	var perPage int = 20
-	page, _ := strconv.ParseInt(r.FormValue("page"), 10, 0)
-	if page <= 0 {
-		page = 1
+	query, apiErr := ListQueryFromRequest(r)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	w.Header().Set("X-CSRF-Token", csrf.Token(r))
+	WriteJSON(w, http.StatusOK, ListUserMngrs(GetUserUUID(r), query))
+}
+
+// GetUserMngr returns manager, managers of other users are reported as not found
+func GetUserMngr(userUUID string, mngrUUID string) (Mngr, *ApiError) {
+	var mngr Mngr
+
+	if !Client.SIsMember("user/mngrs/"+userUUID, mngrUUID).Val() {
+		return mngr, NewApiError(http.StatusNotFound, api.ErrCodeNotFound, "manager not found")
 	}
-	userUuid, _ := Client.Get("user/uuid/" + r.Header.Get("X-Forwarded-User")).Result()
-	if userUuid == "" {
-		u, _ := uuid.NewV4()
-		userUuid = u.String()
-		Client.Set(fmt.Sprintf("user/uuid/%s", r.Header.Get("X-Forwarded-User")), userUuid, 0)
+
+	js, _ := Client.Get("mngrs/" + mngrUUID).Result()
+	if err := json.Unmarshal([]byte(js), &mngr); err != nil {
+		log.Println(err.Error())
+		return mngr, NewApiError(http.StatusInternalServerError, api.ErrCodeInternal, "manager is broken")
 	}
 
-	count, _ := Client.SCard("user/mngrs/" + userUuid).Result()
-	currentPage, pages, hasPrev, hasNext := GetPaginator(int(page), int(count), perPage)
+	return mngr, nil
+}
+
+func saveMngr(mngr Mngr) {
+	js, _ := json.Marshal(mngr)
+	Client.Set("mngrs/"+mngr.UUID, string(js), 0)
+}
 
-	var results []Mngr
-	if count > 0 {
-		// log.Println(count)
-		var cursor = uint64(int64(perPage) * int64(currentPage-1))
-		// var cursorNew uint64
-		var keys []string
-		var err error
-		keys, _, err = Client.SScan("user/mngrs/"+userUuid, cursor, "", int64(perPage)).Result()
+// UpdateMngr renames manager and disables or enables it. Disabled manager is
+// kept out of mngrs set, so it can't authorize or subscribe
+func UpdateMngr(userUUID string, mngrUUID string, req UpdateRequest) (Mngr, *ApiError) {
+	mngr, apiErr := GetUserMngr(userUUID, mngrUUID)
+	if apiErr != nil {
+		return mngr, apiErr
+	}
 
-		if err != nil {
-			log.Println(err)
+	if req.Labels != nil {
+		return mngr, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "managers have no labels")
+	}
+
+	if req.Name != nil {
+		if len(*req.Name) == 0 {
+			return mngr, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "name can't be empty")
+		}
+		mngr.Name = *req.Name
+	}
+
+	if req.Disabled != nil && *req.Disabled != mngr.Disabled {
+		mngr.Disabled = *req.Disabled
+		if mngr.Disabled {
+			Client.SRem("mngrs", mngr.UUID)
+			DisconnectMngr(mngr.UUID)
+			log.Println("Manager disabled", mngr.UUID)
 		} else {
-			// log.Println(keys)
-			for i, val := range keys {
-				keys[i] = "mngrs/" + val
-			}
-
-			items, _ := Client.MGet(keys...).Result()
-			for _, val := range items {
-				if val != nil {
-					var t Mngr
-					err := json.Unmarshal([]byte(val.(string)), &t)
-					if err != nil {
-						log.Println(err.Error())
-					}
-					results = append(results, t)
-				}
-			}
-			// log.Println(len(results), count, results)
+			Client.SAdd("mngrs", mngr.UUID)
+			log.Println("Manager enabled", mngr.UUID)
 		}
-		// log.Println(len(results), count, currentPage, cursor, cursorNew, perPage)
 	}
 
-	varmap := map[string]interface{}{
-		"results":  results,
-		"count":    count,
-		"pages":    pages,
-		"page":     page,
-		"has_prev": hasPrev,
-		"has_next": hasNext,
+	mngr.Updated = time.Now().Unix()
+	saveMngr(mngr)
+
+	return mngr, nil
+}
+
+// DeleteMngr removes manager, its claimed measurements are returned to queue
+func DeleteMngr(userUUID string, mngrUUID string) *ApiError {
+	mngr, apiErr := GetUserMngr(userUUID, mngrUUID)
+	if apiErr != nil {
+		return apiErr
 	}
 
-	js, _ := json.Marshal(varmap)
+	Client.SRem("mngrs", mngr.UUID)
+	IndexRemove(listMngrs, userUUID, mngr.UUID)
+	Client.Del("mngrs/"+mngr.UUID, agentSecretKey(mngr.UUID))
+	DisconnectMngr(mngr.UUID)
 
-	w.Header().Set("X-CSRF-Token", csrf.Token(r))
-	fmt.Fprintf(w, `%s`, js)
+	log.Println("Manager deleted", mngr.UUID)
+
+	return nil
+}
+
+// RotateMngr replaces uuid and secret of manager, old credentials stop working at once
+func RotateMngr(userUUID string, mngrUUID string) (Mngr, string, *ApiError) {
+	mngr, apiErr := GetUserMngr(userUUID, mngrUUID)
+	if apiErr != nil {
+		return mngr, "", apiErr
+	}
+
+	u, _ := uuid.NewV4()
+	mngr.UUID = u.String()
+	secret := NewAgentSecret()
+	Client.Set(agentSecretKey(mngr.UUID), secret, 0)
+	mngr.Updated = time.Now().Unix()
+	saveMngr(mngr)
+
+	IndexAdd(listMngrs, userUUID, mngr.UUID, mngr.Created)
+	if !mngr.Disabled {
+		Client.SAdd("mngrs", mngr.UUID)
+	}
+
+	Client.SRem("mngrs", mngrUUID)
+	IndexRemove(listMngrs, userUUID, mngrUUID)
+	Client.Del("mngrs/"+mngrUUID, agentSecretKey(mngrUUID))
+	DisconnectMngr(mngrUUID)
+
+	log.Println("Manager", mngrUUID, "rotated to", mngr.UUID)
+
+	return mngr, secret, nil
+}
+
+// DisconnectMngr drops manager from online set, closes its subscription and
+// returns its claimed measurements to queue
+func DisconnectMngr(mngrUUID string) {
+	wasOnline := Client.SRem("mngr-online", mngrUUID).Val() == 1
+	Client.Del(mngrUUID + "/alive")
+
+	RequeueAgentTasks(mngrUUID)
+
+	go Delete(*nchanaddr + "/pub/" + api.ChannelMngr(mngrUUID))
+	if wasOnline {
+		PublishMngrState(mngrUUID, api.ActionOffline)
+	}
 }
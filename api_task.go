@@ -1,190 +1,751 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/ad/gocc/api"
 	"github.com/gorilla/csrf"
 	uuid "github.com/nu7hatch/gouuid"
 )
 
-func ApiTaskCreateHandler(w http.ResponseWriter, r *http.Request) {
-	ip := r.FormValue("ip")
+var taskTypes = map[string]bool{
+	"ping":       true,
+	"head":       true,
+	"dns":        true,
+	"traceroute": true,
+	"tcp":        true,
+	"tls":        true,
+	"http":       true,
+}
 
-	if len(ip) == 0 {
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Fprintf(w, `{"status": "error", "error": "Missing required IP param"}`)
-		return
+var taskMainTypes = map[string]bool{
+	"task":        true,
+	"measurement": true,
+}
+
+var repeatTypes = map[string]int{
+	"5min":   300,
+	"10min":  600,
+	"30min":  1800,
+	"1hour":  3600,
+	"3hour":  10800,
+	"6hour":  21600,
+	"12hour": 43200,
+	"1day":   86400,
+	"1week":  604800,
+}
+
+// TaskCreateRequestFromForm reads legacy form values of /api/task/create
+func TaskCreateRequestFromForm(r *http.Request) TaskCreateRequest {
+	taskCount, err := strconv.ParseInt(r.FormValue("taskcount"), 10, 64)
+	if err != nil {
+		taskCount = 1
+	}
+	queueTTL, _ := strconv.ParseInt(r.FormValue("queuettl"), 10, 64)
+
+	var params api.Params
+	if r.FormValue("params") != "" {
+		params = api.Params(r.FormValue("params"))
 	}
 
-	taskType := r.FormValue("type")
-	taskTypes := map[string]bool{
-		"ping":       true,
-		"head":       true,
-		"dns":        true,
-		"traceroute": true,
+	return TaskCreateRequest{
+		Action:   r.FormValue("type"),
+		Param:    r.FormValue("ip"),
+		Params:   params,
+		Type:     r.FormValue("maintype"),
+		Count:    taskCount,
+		Dest:     r.FormValue("dest"),
+		Repeat:   r.FormValue("repeat"),
+		QueueTTL: queueTTL,
 	}
+}
 
-	taskMainType := "task"
-	taskMainTypes := map[string]bool{
-		"task":        true,
-		"measurement": true,
+// validHost returns IPv4 or IPv6 address in canonical form or hostname,
+// false when host is neither
+func validHost(host string) (string, bool) {
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String(), true
 	}
-	if taskMainTypes[r.FormValue("maintype")] {
-		taskMainType = r.FormValue("maintype")
+	if !hostnameRegex.MatchString(host) {
+		return "", false
 	}
+	return host, true
+}
 
-	taskCount, err := strconv.ParseInt(r.FormValue("taskcount"), 10, 64)
+// validateHTTPURL checks http(s) url of head and http tasks against RFC 3986,
+// IPv6 host must be in brackets. Userinfo and fragment are not sent by zonds,
+// so they are rejected
+func validateHTTPURL(param string) (string, *ApiError) {
+	if !strings.HasPrefix(param, "http://") && !strings.HasPrefix(param, "https://") {
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "must start with http(s)://")
+	}
+	if !uriRegex.MatchString(param) {
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "url contains characters not allowed by RFC 3986, percent-encode them")
+	}
+
+	u, err := url.Parse(param)
+	if err != nil || u.Host == "" || u.User != nil || u.Fragment != "" {
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong url")
+	}
+	if strings.Count(u.Host, ":") > 1 && !strings.HasPrefix(u.Host, "[") {
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "IPv6 address in url must be in brackets, e.g. http://[2001:db8::1]/")
+	}
+
+	host, ok := validHost(u.Hostname())
+	if !ok {
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong ip/hostname")
+	}
+	if port := u.Port(); port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong port")
+		}
+		host = net.JoinHostPort(host, port)
+	} else if ipFamily(host) == api.NetworkIPv6 {
+		host = "[" + host + "]"
+	}
+	u.Host = host
+
+	return u.String(), nil
+}
+
+// validateTCPParam checks host:port target of tcp task, IPv6 host must be
+// in brackets
+func validateTCPParam(param string) (string, *ApiError) {
+	host, port, err := net.SplitHostPort(param)
 	if err != nil {
-		taskCount = 1
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "must be host:port, IPv6 address in brackets, e.g. [2001:db8::1]:443")
+	}
+
+	host, ok := validHost(host)
+	if !ok {
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong ip/hostname")
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong port")
 	}
 
-	if taskTypes[taskType] {
-		if taskType == "head" {
-			// check http(s)://hostname
-			if strings.HasPrefix(ip, "http://") || strings.HasPrefix(ip, "https://") {
-				s := strings.SplitN(ip, "://", 2)
-				proto, addr := s[0], s[1]
+	return net.JoinHostPort(host, strconv.Itoa(n)), nil
+}
 
-				if !ipv4Regex.MatchString(addr) && !hostnameRegex.MatchString(addr) {
-					w.WriteHeader(http.StatusBadRequest)
-					fmt.Fprintf(w, `{"status": "error", "error": "wrong ip/hostname"}`)
-					return
-				} else {
-					ip = proto + "://" + addr
-				}
-			} else {
-				w.WriteHeader(http.StatusBadRequest)
-				fmt.Fprintf(w, `{"status": "error", "error": "must start with http(s)://"}`)
-				return
-			}
-		} else if taskType == "dns" {
-			// check ip/hostname-resolver
-			var resolverAddress = "8.8.8.8"
-			if strings.Count(ip, "-") == 1 {
-				s := strings.SplitN(ip, "-", 2)
-				ip, resolverAddress = s[0], s[1]
-			}
-			if ipv4Regex.MatchString(ip) || hostnameRegex.MatchString(ip) {
-				if resolverAddress == "8.8.8.8" || ipv4Regex.MatchString(resolverAddress) || hostnameRegex.MatchString(resolverAddress) {
-					ip = ip + "-" + resolverAddress
-				} else {
-					w.WriteHeader(http.StatusBadRequest)
-					fmt.Fprintf(w, `{"status": "error", "error": "wrong resolver"}`)
-					return
-				}
-			} else {
-				w.WriteHeader(http.StatusBadRequest)
-				fmt.Fprintf(w, `{"status": "error", "error": "wrong ip/hostname"}`)
-				return
-			}
-		} else {
-			// check ip/hostname
-			if !ipv4Regex.MatchString(ip) && !hostnameRegex.MatchString(ip) {
-				w.WriteHeader(http.StatusBadRequest)
-				fmt.Fprintf(w, `{"status": "error", "error": "wrong ip/hostname"}`)
-				return
-			}
+// validateTLSParam checks host with optional port of tls task and returns
+// host:port, port is api.DefaultTLSPort when missing
+func validateTLSParam(param string) (string, *ApiError) {
+	if _, _, err := net.SplitHostPort(param); err != nil {
+		host := strings.TrimSuffix(strings.TrimPrefix(param, "["), "]")
+		param = net.JoinHostPort(host, strconv.Itoa(api.DefaultTLSPort))
+	}
+	return validateTCPParam(param)
+}
+
+// splitDNSParam splits dns target into host and resolver. Resolver follows
+// "@", e.g. example.com@2001:4860:4860::8888, legacy form host-resolver is
+// split on the last "-" only when resolver is ip address, so hostnames with
+// dashes are kept whole
+func splitDNSParam(param string) (string, string) {
+	if i := strings.LastIndex(param, "@"); i >= 0 {
+		return param[:i], param[i+1:]
+	}
+	if i := strings.LastIndex(param, "-"); i > 0 && net.ParseIP(param[i+1:]) != nil {
+		return param[:i], param[i+1:]
+	}
+	return param, api.DefaultResolver
+}
+
+// validateDNSParam checks host and resolver of dns task, they are stored as
+// host-resolver which zonds split on the last "-"
+func validateDNSParam(param string) (string, *ApiError) {
+	host, resolverAddress := splitDNSParam(param)
+
+	host, ok := validHost(host)
+	if !ok {
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong ip/hostname")
+	}
+
+	resolverAddress, ok = validHost(resolverAddress)
+	if !ok {
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong resolver")
+	}
+	if strings.Contains(resolverAddress, "-") {
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "resolver hostname can't contain '-', use its ip address")
+	}
+
+	return host + "-" + resolverAddress, nil
+}
+
+// ValidateTaskParam checks and normalizes target of task depending on its
+// type. Targets are IPv4 or IPv6 addresses or hostnames, head takes http(s)
+// url, dns takes host with optional resolver, tcp takes host:port, tls
+// host with optional port and http url
+func ValidateTaskParam(taskType string, ip string) (string, *ApiError) {
+	if len(ip) == 0 {
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeMissingParam, "Missing required IP param")
+	}
+
+	if !taskTypes[taskType] {
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong task type")
+	}
+
+	switch taskType {
+	case "head", "http":
+		return validateHTTPURL(ip)
+	case "dns":
+		return validateDNSParam(ip)
+	case "tcp":
+		return validateTCPParam(ip)
+	case "tls":
+		return validateTLSParam(ip)
+	}
+
+	host, ok := validHost(ip)
+	if !ok {
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong ip/hostname")
+	}
+	return host, nil
+}
+
+// Limits of structured task parameters
+const (
+	maxPingCount     = 10
+	maxPingSize      = 65507
+	maxTraceTTL      = 64
+	maxParamsTimeout = 60
+	maxHTTPRedirects = 10
+	maxHTTPBody      = 8 * 1024
+)
+
+// httpMethods are methods of http params
+var httpMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// dnsTypes are record types of dns params
+var dnsTypes = map[string]bool{
+	"A":     true,
+	"AAAA":  true,
+	"CNAME": true,
+	"MX":    true,
+	"TXT":   true,
+	"NS":    true,
+	"SOA":   true,
+	"CAA":   true,
+	"PTR":   true,
+}
+
+// dnsTransports are transports of dns params
+var dnsTransports = map[string]bool{
+	api.DNSTransportUDP: true,
+	api.DNSTransportTCP: true,
+	api.DNSTransportDoT: true,
+	api.DNSTransportDoH: true,
+}
+
+// paramRange is allowed range of numeric field of params
+type paramRange struct {
+	name  string
+	value int64
+	min   int64
+	max   int64
+}
+
+// checkParamRanges rejects the first field of params outside of its range
+func checkParamRanges(ranges ...paramRange) *ApiError {
+	for _, r := range ranges {
+		if r.value < r.min || r.value > r.max {
+			return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, fmt.Sprintf("%s must be from %d to %d", r.name, r.min, r.max))
 		}
+	}
+	return nil
+}
 
-		dest := r.FormValue("dest")
-		destination := "tasks"
-		if len(dest) > 4 && strings.Count(dest, ":") == 2 {
-			target := strings.Join(strings.Split(dest, ":")[2:], ":")
-			if strings.HasPrefix(dest, "zond:uuid:") {
-				test, _ := Client.SIsMember("Zond-online", target).Result()
-				if test {
-					destination = "zond:" + target
-				}
-			} else if strings.HasPrefix(dest, "zond:city:") {
-				// FIXME: check if destination is available
-				destination = "City:" + target
-			} else if strings.HasPrefix(dest, "zond:country:") {
-				// FIXME: check if destination is available
-				destination = "Country:" + target
-			} else if strings.HasPrefix(dest, "zond:asn:") {
-				// FIXME: check if destination is available
-				destination = "ASN:" + target
+// validateParams checks and normalizes structured params of task
+func validateParams(params api.TaskParams) *ApiError {
+	wrongHost := NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong ip/hostname")
+
+	switch p := params.(type) {
+	case *api.PingParams:
+		host, ok := validHost(p.Host)
+		if !ok {
+			return wrongHost
+		}
+		p.Host = host
+		return checkParamRanges(
+			paramRange{"count", int64(p.Count), 0, maxPingCount},
+			paramRange{"size", int64(p.Size), 0, maxPingSize},
+			paramRange{"timeout", p.Timeout, 0, maxParamsTimeout},
+		)
+	case *api.TracerouteParams:
+		host, ok := validHost(p.Host)
+		if !ok {
+			return wrongHost
+		}
+		p.Host = host
+		return checkParamRanges(
+			paramRange{"max_ttl", int64(p.MaxTTL), 0, maxTraceTTL},
+			paramRange{"timeout", p.Timeout, 0, maxParamsTimeout},
+		)
+	case *api.HeadParams:
+		headURL, apiErr := validateHTTPURL(p.URL)
+		if apiErr != nil {
+			return apiErr
+		}
+		p.URL = headURL
+		if p.ExpectStatus != 0 {
+			if apiErr := checkParamRanges(paramRange{"expect_status", int64(p.ExpectStatus), 100, 599}); apiErr != nil {
+				return apiErr
 			}
 		}
+		return checkParamRanges(paramRange{"timeout", p.Timeout, 0, maxParamsTimeout})
+	case *api.DNSParams:
+		return validateDNSParams(p)
+	case *api.TCPParams:
+		host, ok := validHost(p.Host)
+		if !ok {
+			return wrongHost
+		}
+		p.Host = host
+		return checkParamRanges(
+			paramRange{"port", int64(p.Port), 1, 65535},
+			paramRange{"timeout", p.Timeout, 0, maxParamsTimeout},
+		)
+	case *api.TLSParams:
+		host, ok := validHost(p.Host)
+		if !ok {
+			return wrongHost
+		}
+		p.Host = host
+		if p.Port == 0 {
+			p.Port = api.DefaultTLSPort
+		}
+		if p.SNI != "" && !hostnameRegex.MatchString(p.SNI) {
+			return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong sni, must be hostname")
+		}
+		return checkParamRanges(
+			paramRange{"port", int64(p.Port), 1, 65535},
+			paramRange{"timeout", p.Timeout, 0, maxParamsTimeout},
+		)
+	case *api.HTTPParams:
+		return validateHTTPParams(p)
+	}
+	return nil
+}
 
-		repeatType := r.FormValue("repeat")
-		repeatTypes := map[string]int{
-			"5min":   300,
-			"10min":  600,
-			"30min":  1800,
-			"1hour":  3600,
-			"3hour":  10800,
-			"6hour":  21600,
-			"12hour": 43200,
-			"1day":   86400,
-			"1week":  604800,
+// validateDNSParams checks query and resolver of dns params, zond-local
+// resolver is asked over UDP or TCP only
+func validateDNSParams(p *api.DNSParams) *ApiError {
+	host, ok := validHost(p.Host)
+	if !ok {
+		return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong ip/hostname")
+	}
+	p.Host = host
+	if p.Resolver != "" {
+		resolverAddress, ok := validHost(p.Resolver)
+		if !ok || strings.Contains(resolverAddress, "-") {
+			return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong resolver, use ip address, hostname without '-' or "+api.LocalResolver)
 		}
+		p.Resolver = resolverAddress
+	}
+	p.Type = strings.ToUpper(p.Type)
+	if p.Type != "" && !dnsTypes[p.Type] {
+		return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong record type "+p.Type)
+	}
 
-		if repeatTypes[repeatType] <= 0 {
-			repeatType = "single"
+	p.Transport = strings.ToLower(p.Transport)
+	if p.Transport != "" && !dnsTransports[p.Transport] {
+		return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong transport "+p.Transport+", use udp, tcp, dot or doh")
+	}
+	encrypted := p.Transport == api.DNSTransportDoT || p.Transport == api.DNSTransportDoH
+	if p.Resolver == api.LocalResolver && encrypted {
+		return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, api.LocalResolver+" resolver is asked over udp or tcp")
+	}
+	if p.Path != "" && (p.Transport != api.DNSTransportDoH || !strings.HasPrefix(p.Path, "/") || !uriRegex.MatchString(p.Path)) {
+		return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "path is url path of doh transport")
+	}
+
+	return checkParamRanges(
+		paramRange{"port", int64(p.Port), 0, 65535},
+		paramRange{"timeout", p.Timeout, 0, maxParamsTimeout},
+	)
+}
+
+// validateHTTPHeaders checks names and values of request or asserted headers
+func validateHTTPHeaders(field string, headers map[string]string) *ApiError {
+	for name, value := range headers {
+		if !httpTokenRegex.MatchString(name) || strings.ContainsAny(value, "\r\n") {
+			return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, fmt.Sprintf("wrong %s header %q", field, name))
+		}
+	}
+	return nil
+}
+
+// validateHTTPParams checks request and assertions of http params
+func validateHTTPParams(p *api.HTTPParams) *ApiError {
+	httpURL, apiErr := validateHTTPURL(p.URL)
+	if apiErr != nil {
+		return apiErr
+	}
+	p.URL = httpURL
+
+	p.Method = strings.ToUpper(p.Method)
+	if p.Method == "" {
+		p.Method = http.MethodGet
+	}
+	if !httpMethods[p.Method] {
+		return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong method "+p.Method)
+	}
+	if apiErr := validateHTTPHeaders("request", p.Headers); apiErr != nil {
+		return apiErr
+	}
+	if len(p.Body) > maxHTTPBody {
+		return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, fmt.Sprintf("body is longer than %d bytes", maxHTTPBody))
+	}
+	if apiErr := checkParamRanges(
+		paramRange{"max_redirects", int64(p.MaxRedirects), 0, maxHTTPRedirects},
+		paramRange{"timeout", p.Timeout, 0, maxParamsTimeout},
+		paramRange{"connect_timeout", p.ConnectTimeout, 0, maxParamsTimeout},
+	); apiErr != nil {
+		return apiErr
+	}
+
+	if p.Assert == nil {
+		return nil
+	}
+	for _, status := range p.Assert.Status {
+		if apiErr := checkParamRanges(paramRange{"assert.status", int64(status), 100, 599}); apiErr != nil {
+			return apiErr
 		}
-		u, _ := uuid.NewV4()
-		var UUID = u.String()
-		var msec = time.Now().Unix()
+	}
+	if apiErr := validateHTTPHeaders("asserted", p.Assert.Headers); apiErr != nil {
+		return apiErr
+	}
+	if _, err := regexp.Compile(p.Assert.BodyRegex); err != nil {
+		return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong body_regex: "+err.Error())
+	}
+	return checkParamRanges(paramRange{"assert.max_latency_ms", p.Assert.MaxLatency, 0, maxParamsTimeout * 1000})
+}
+
+// ValidateTaskParams checks structured params or legacy param of task and
+// returns legacy param with params of the same target, so agents reading
+// either get the same task. Unknown fields of params are rejected
+func ValidateTaskParams(taskType string, param string, params api.Params) (string, api.Params, *ApiError) {
+	if len(params) == 0 {
+		ip, apiErr := ValidateTaskParam(taskType, param)
+		if apiErr != nil {
+			return "", nil, apiErr
+		}
+		p, _ := api.LegacyParams(taskType, ip)
+		js, _ := json.Marshal(p)
+		return ip, js, nil
+	}
+
+	if param != "" {
+		return "", nil, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "param and params can't be used together")
+	}
+	p, err := api.NewParams(taskType)
+	if err != nil {
+		return "", nil, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong task type")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(params))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(p); err != nil {
+		return "", nil, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong params: "+err.Error())
+	}
+	if err := api.CheckVersion(p); err != nil {
+		return "", nil, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, fmt.Sprintf("%s, version %d is supported", err.Error(), api.ParamsVersion))
+	}
+	if apiErr := validateParams(p); apiErr != nil {
+		return "", nil, apiErr
+	}
+
+	js, _ := json.Marshal(p)
+	return p.Legacy(), js, nil
+}
 
-		Client.SAdd("tasks-new", UUID)
+// geoChannels are nchan channels of zond geo data and hashes of their values by zond uuid
+var geoChannels = map[string]string{
+	"City:":    "zond:city",
+	"Country:": "zond:country",
+	"ASN:":     "zond:asn",
+}
 
-		// users, _ := client.SMembers("tasks-new").Result()
-		// usersCount, _ := client.SCard("tasks-new").Result()
-		// log.Println("tasks-new", users, usersCount)
+// ResolveDestination converts dest from request to nchan channel name, label
+// selector is converted to labels: target published to every matching zond
+func ResolveDestination(dest string) (string, *ApiError) {
+	dest = strings.TrimSpace(dest)
+	if dest == "" || dest == "tasks" {
+		return "tasks", nil
+	}
 
-		userUUID, _ := Client.Get("user/uuid/" + r.Header.Get("X-Forwarded-User")).Result()
-		if userUUID == "" {
-			u, _ := uuid.NewV4()
-			userUUID = u.String()
-			Client.Set(fmt.Sprintf("user/uuid/%s", r.Header.Get("X-Forwarded-User")), userUUID, 0)
+	if isLabelSelector(dest) {
+		selector, err := ParseLabelSelector(dest)
+		if err != nil {
+			return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong dest: "+err.Error())
 		}
+		return labelsTargetPrefix + selector.String(), nil
+	}
+
+	s := strings.SplitN(dest, ":", 3)
+	if len(s) != 3 || len(s[2]) == 0 {
+		return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong dest")
+	}
+	target := s[2]
 
-		action := Action{Action: taskType, Param: ip, UUID: UUID, Created: msec, Creator: userUUID, Target: destination, Repeat: repeatType, Type: taskMainType, Count: taskCount, TimeOut: 60}
-		js, _ := json.Marshal(action)
+	switch s[1] {
+	case "uuid":
+		if !Client.SIsMember("zonds", target).Val() {
+			return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "unknown zond "+target)
+		}
+		return "zond:" + target, nil
+	case "city":
+		return "City:" + target, nil
+	case "country":
+		return "Country:" + target, nil
+	case "asn":
+		return "ASN:" + target, nil
+	}
 
-		Client.Set("task/"+UUID, string(js), 0)
-		Client.SAdd("user/tasks/"+userUUID, UUID)
-		if repeatType != "single" {
-			t := time.Now()
-			tnew := t.Add(time.Duration(repeatTypes[repeatType]) * time.Second).Unix()
-			t300 := (tnew - (tnew % 300))
-			log.Println("next start will be at ", strconv.FormatInt(t300, 10))
+	return "", NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong dest")
+}
 
-			Client.SAdd("tasks-repeatable-"+strconv.FormatInt(t300, 10), string(js))
+// EligibleZonds returns online zonds receiving tasks published to target and
+// reaching address family of task, any family when it is empty
+func EligibleZonds(target string, family string) []string {
+	var zonds []string
+	for _, zondUUID := range targetZonds(target) {
+		if ZondReaches(zondUUID, family) {
+			zonds = append(zonds, zondUUID)
 		}
+	}
+	return zonds
+}
 
-		if taskMainType != "task" {
-			go Post("http://127.0.0.1:80/pub/mngrtasks", string(js))
-		} else {
-			go Post("http://127.0.0.1:80/pub/"+destination, string(js))
+// targetZonds returns online zonds receiving tasks published to target
+func targetZonds(target string) []string {
+	if strings.HasPrefix(target, labelsTargetPrefix) {
+		selector, err := ParseLabelSelector(strings.TrimPrefix(target, labelsTargetPrefix))
+		if err != nil {
+			log.Println(err.Error())
+			return nil
 		}
-		log.Println(ip, taskType, UUID)
-	} else {
-		// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Fprintf(w, `{"status": "error", "error": "wrong task type"}`)
-		return
+		return MatchingZonds(selector)
+	}
+
+	if strings.HasPrefix(target, "zond:") {
+		zondUUID := strings.TrimPrefix(target, "zond:")
+		if Client.SIsMember("Zond-online", zondUUID).Val() {
+			return []string{zondUUID}
+		}
+		return nil
+	}
+
+	online, _ := Client.SMembers("Zond-online").Result()
+	sort.Strings(online)
+	if target == "tasks" {
+		return online
 	}
 
-	fmt.Fprintf(w, `{"status": "ok"}`)
+	for prefix, hash := range geoChannels {
+		if strings.HasPrefix(target, prefix) {
+			values, _ := Client.HGetAll(hash).Result()
+			var zonds []string
+			for _, zondUUID := range online {
+				if value, ok := values[zondUUID]; ok && value == strings.TrimPrefix(target, prefix) {
+					zonds = append(zonds, zondUUID)
+				}
+			}
+			return zonds
+		}
+	}
+
+	return nil
 }
 
-func ApiTaskRepeatableRemoveHandler(w http.ResponseWriter, r *http.Request) {
-	uuid := r.FormValue("uuid")
+// isLabelSelector tells label selector from legacy dest values
+func isLabelSelector(dest string) bool {
+	dest = strings.TrimSpace(dest)
+	return dest != "" && dest != "tasks" && !strings.HasPrefix(dest, "zond:")
+}
+
+// taskSettings are validated settings of task request shared by all its
+// targets, eligible is count of online zonds of destination
+type taskSettings struct {
+	mainType    string
+	count       int64
+	destination string
+	repeat      string
+	queueTTL    int64
+	eligible    int
+}
+
+// resolveTaskSettings validates type, count, destination, schedule and queue
+// ttl of task request, only zonds reaching family are eligible. Task for
+// zonds is rejected without eligible ones unless it may wait in queue
+func resolveTaskSettings(req TaskCreateRequest, family string) (taskSettings, *ApiError) {
+	settings := taskSettings{mainType: "task", count: req.Count, repeat: req.Repeat, queueTTL: req.QueueTTL}
+
+	if taskMainTypes[req.Type] {
+		settings.mainType = req.Type
+	}
+	if settings.count <= 0 {
+		settings.count = 1
+	}
+
+	var apiErr *ApiError
+	settings.destination, apiErr = ResolveDestination(req.Dest)
+	if apiErr != nil {
+		return settings, apiErr
+	}
+
+	if settings.queueTTL < 0 {
+		return settings, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "queue_ttl can't be negative")
+	}
+	if settings.queueTTL == 0 {
+		settings.queueTTL = *defaultQueueTTL
+	}
+
+	settings.eligible = len(EligibleZonds(settings.destination, family))
+	// measurement is claimed by manager, not zond, so zonds are not required
+	if settings.mainType == api.TypeTask && settings.eligible == 0 && settings.queueTTL == 0 {
+		return settings, NewApiError(http.StatusConflict, api.ErrCodeNoZonds, "no online zonds for destination, set queue_ttl to wait for them")
+	}
+	if settings.queueTTL == 0 {
+		settings.queueTTL = *taskTTL
+	}
+
+	if repeatTypes[settings.repeat] <= 0 {
+		settings.repeat = "single"
+	}
 
-	if len(uuid) != 36 || strings.Count(uuid, "-") != 4 {
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Fprintf(w, `{"status": "error", "error": "Missing required UUID param"}`)
+	return settings, nil
+}
+
+// saveNewTask stores, schedules and publishes task with validated param and params
+func saveNewTask(userUUID string, taskType string, ip string, params api.Params, settings taskSettings, batch string) Action {
+	u, _ := uuid.NewV4()
+	var UUID = u.String()
+	var msec = time.Now().Unix()
+
+	Client.SAdd("tasks-new", UUID)
+
+	action := Action{Action: taskType, Param: ip, Params: params, UUID: UUID, Created: msec, Creator: userUUID, Target: settings.destination, Repeat: settings.repeat, Type: settings.mainType, Count: settings.count, TimeOut: 60, QueueTTL: settings.queueTTL, Batch: batch}
+	js, _ := json.Marshal(action)
+
+	Client.Set("task/"+UUID, string(js), 0)
+	IndexAdd(listTasks, userUUID, UUID, msec)
+	if settings.repeat != "single" {
+		t := time.Now()
+		tnew := t.Add(time.Duration(repeatTypes[settings.repeat]) * time.Second).Unix()
+		t300 := (tnew - (tnew % 300))
+		log.Println("next start will be at ", strconv.FormatInt(t300, 10))
+
+		Client.SAdd("tasks-repeatable-"+strconv.FormatInt(t300, 10), string(js))
+	}
+
+	PublishTask(action, string(js))
+	log.Println(ip, taskType, UUID, "eligible zonds:", settings.eligible)
+
+	return action
+}
+
+// CreateTask validates request, stores new task and publishes it to zonds or
+// managers. Count of online zonds eligible for destination is returned, task
+// without them is rejected unless it may wait in queue
+func CreateTask(userUUID string, req TaskCreateRequest) (Action, int, *ApiError) {
+	ip, params, apiErr := ValidateTaskParams(req.Action, req.Param, req.Params)
+	if apiErr != nil {
+		return Action{}, 0, apiErr
+	}
+
+	settings, apiErr := resolveTaskSettings(req, TaskFamily(req.Action, ip))
+	if apiErr != nil {
+		return Action{}, 0, apiErr
+	}
+
+	return saveNewTask(userUUID, req.Action, ip, params, settings, ""), settings.eligible, nil
+}
+
+// Deprecated: use ApiV1TaskCreateHandler
+func ApiTaskCreateHandler(w http.ResponseWriter, r *http.Request) {
+	action, eligible, apiErr := CreateTask(GetUserUUID(r), TaskCreateRequestFromForm(r))
+	if apiErr != nil {
+		WriteError(w, apiErr)
 		return
 	}
 
+	WriteJSON(w, http.StatusOK, TaskCreateResponse{Status: "ok", UUID: action.UUID, Task: action, Eligible: eligible})
+}
+
+// GetUserTask returns task, tasks of other users are reported as not found
+func GetUserTask(userUUID string, taskUUID string) (Action, *ApiError) {
+	var task Action
+
+	js, _ := Client.Get("task/" + taskUUID).Result()
+	if js == "" {
+		return task, NewApiError(http.StatusNotFound, api.ErrCodeNotFound, "task not found")
+	}
+
+	err := json.Unmarshal([]byte(js), &task)
+	if err != nil {
+		log.Println(err.Error())
+		return task, NewApiError(http.StatusInternalServerError, api.ErrCodeInternal, "task is broken")
+	}
+
+	if task.Creator != userUUID {
+		return Action{}, NewApiError(http.StatusNotFound, api.ErrCodeNotFound, "task not found")
+	}
+
+	return task, nil
+}
+
+// CancelTask removes queued task from tasks-new, so it can't be claimed anymore
+func CancelTask(userUUID string, taskUUID string) (Action, *ApiError) {
+	task, apiErr := GetUserTask(userUUID, taskUUID)
+	if apiErr != nil {
+		return task, apiErr
+	}
+
+	count := Client.SRem("tasks-new", taskUUID)
+	if count.Val() != int64(1) {
+		return task, NewApiError(http.StatusConflict, api.ErrCodeConflict, "task is already processing or done")
+	}
+
+	task.Status = api.TaskStatusCanceled
+	task.Updated = time.Now().Unix()
+
+	js, _ := json.Marshal(task)
+	Client.Set("task/"+taskUUID, string(js), 0)
+
+	log.Println("Task canceled", taskUUID)
+
+	return task, nil
+}
+
+// RemoveRepeatableTask removes scheduled runs of repeatable task owned by user
+func RemoveRepeatableTask(userUUID string, taskUUID string) *ApiError {
+	if len(taskUUID) != 36 || strings.Count(taskUUID, "-") != 4 {
+		return NewApiError(http.StatusBadRequest, api.ErrCodeMissingParam, "Missing required UUID param")
+	}
+
+	var found bool
+
 	titles, _ := Client.Keys("tasks-repeatable-*").Result()
 	count := len(titles)
 
@@ -203,92 +764,87 @@ func ApiTaskRepeatableRemoveHandler(w http.ResponseWriter, r *http.Request) {
 					if err != nil {
 						log.Println(err.Error())
 					}
-					if t.UUID == uuid {
+					if t.UUID == taskUUID && t.Creator == userUUID {
 						Client.SRem(val, item)
+						found = true
 					}
 				}
 			}
 		}
 	}
 
-	fmt.Fprintf(w, `{"status": "ok"}`)
+	if !found {
+		return NewApiError(http.StatusNotFound, api.ErrCodeNotFound, "task not found")
+	}
+
+	return nil
 }
 
-func ApiShowMyTasks(w http.ResponseWriter, r *http.Request) {
-	var perPage int = 20
+// Deprecated: use ApiV1TaskRepeatableRemoveHandler
+func ApiTaskRepeatableRemoveHandler(w http.ResponseWriter, r *http.Request) {
+	if apiErr := RemoveRepeatableTask(GetUserUUID(r), r.FormValue("uuid")); apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, ApiStatusResponse{Status: "ok"})
+}
+
+// PageFromRequest returns page number from query, starting from 1
+func PageFromRequest(r *http.Request) int {
 	page, _ := strconv.ParseInt(r.FormValue("page"), 10, 0)
 	if page <= 0 {
 		page = 1
 	}
-	userUuid, _ := Client.Get("user/uuid/" + r.Header.Get("X-Forwarded-User")).Result()
-	if userUuid == "" {
-		u, _ := uuid.NewV4()
-		userUuid = u.String()
-		Client.Set(fmt.Sprintf("user/uuid/%s", r.Header.Get("X-Forwarded-User")), userUuid, 0)
-	}
-
-	count, _ := Client.SCard("user/tasks/" + userUuid).Result()
-	currentPage, pages, hasPrev, hasNext := GetPaginator(int(page), int(count), perPage)
-
-	var results []Action
-	if count > 0 {
-		// log.Println(count)
-		var cursor = uint64(int64(perPage) * int64(currentPage-1))
-		// var cursorNew uint64
-		var keys []string
-		var err error
-		keys, _, err = Client.SScan("user/tasks/"+userUuid, cursor, "", int64(perPage)).Result()
-
-		if err != nil {
-			log.Println(err)
-		} else {
-			for i, val := range keys {
-				keys[i] = "task/" + val
-			}
+	return int(page)
+}
 
-			items, _ := Client.MGet(keys...).Result()
-			for _, val := range items {
-				var t Action
-				err := json.Unmarshal([]byte(val.(string)), &t)
-				if err != nil {
-					log.Println(err.Error())
-				}
-				results = append(results, t)
+// ListUserTasks returns page of tasks of user passing filter of query
+func ListUserTasks(userUUID string, query ListQuery) TaskListResponse {
+	var match func(js string) bool
+	if !query.Filter.matchesAll() {
+		match = func(js string) bool {
+			var t Action
+			if err := json.Unmarshal([]byte(js), &t); err != nil {
+				log.Println(err.Error())
+				return false
 			}
-			// log.Println(len(results), count, results)
+			return query.Filter.Match(t)
 		}
-		// log.Println(len(results), count, currentPage, cursor, cursorNew, perPage)
 	}
 
-	varmap := map[string]interface{}{
-		"results":  results,
-		"count":    count,
-		"pages":    pages,
-		"page":     page,
-		"has_prev": hasPrev,
-		"has_next": hasNext,
-	}
+	page := readList(listTasks, userUUID, query, match)
 
-	js, _ := json.Marshal(varmap)
+	results := []Action{}
+	for _, js := range page.Entries {
+		var t Action
+		if err := json.Unmarshal([]byte(js), &t); err != nil {
+			log.Println(err.Error())
+		}
+		results = append(results, t)
+	}
 
-	w.Header().Set("X-CSRF-Token", csrf.Token(r))
-	fmt.Fprintf(w, `%s`, js)
+	return TaskListResponse{Status: "ok", Results: results, Count: page.Count, Pages: page.Pages, Page: page.Page, HasPrev: page.HasPrev, HasNext: page.HasNext, NextCursor: page.NextCursor}
 }
 
-func ApiShowRepeatableTasks(w http.ResponseWriter, r *http.Request) {
-	userUuid, _ := Client.Get("user/uuid/" + r.Header.Get("X-Forwarded-User")).Result()
-	if userUuid == "" {
-		u, _ := uuid.NewV4()
-		userUuid = u.String()
-		Client.Set(fmt.Sprintf("user/uuid/%s", r.Header.Get("X-Forwarded-User")), userUuid, 0)
+// Deprecated: use ApiV1TaskListHandler
+func ApiShowMyTasks(w http.ResponseWriter, r *http.Request) {
+	query, apiErr := ListQueryFromRequest(r)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
 	}
 
+	w.Header().Set("X-CSRF-Token", csrf.Token(r))
+	WriteJSON(w, http.StatusOK, ListUserTasks(GetUserUUID(r), query))
+}
+
+// ListRepeatableTasks returns scheduled repeatable tasks created by user
+func ListRepeatableTasks(userUUID string) []Action {
 	titles, _ := Client.Keys("tasks-repeatable-*").Result()
 	count := len(titles)
 
-	// log.Println(count, titles)
-
-	var results []Action
+	results := []Action{}
 	if count > 0 {
 		var keys []string
 		var err error
@@ -297,27 +853,26 @@ func ApiShowRepeatableTasks(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				log.Println(err)
 			} else {
-				// log.Println(keys)
 				for _, val := range keys {
 					var t Action
 					err := json.Unmarshal([]byte(val), &t)
 					if err != nil {
 						log.Println(err.Error())
+					} else if t.Creator == userUUID {
+						results = append(results, t)
 					}
-					results = append(results, t)
 				}
 			}
 		}
-		// log.Println(len(results), count, results)
 	}
 
-	varmap := map[string]interface{}{
-		"results": results,
-		"count":   count,
-	}
+	return results
+}
 
-	js, _ := json.Marshal(varmap)
+// Deprecated: use ApiV1TaskRepeatableListHandler
+func ApiShowRepeatableTasks(w http.ResponseWriter, r *http.Request) {
+	results := ListRepeatableTasks(GetUserUUID(r))
 
 	w.Header().Set("X-CSRF-Token", csrf.Token(r))
-	fmt.Fprintf(w, `%s`, js)
+	WriteJSON(w, http.StatusOK, TaskListResponse{Status: "ok", Results: results, Count: int64(len(results)), Pages: 1, Page: 1})
 }
@@ -0,0 +1,469 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ad/gocc/api"
+	"github.com/gorilla/csrf"
+	"github.com/gorilla/mux"
+	uuid "github.com/nu7hatch/gouuid"
+)
+
+const maxRequestBody = 1 << 20
+
+func NewApiError(httpStatus int, code string, message string) *ApiError {
+	return &ApiError{HTTPStatus: httpStatus, Code: code, Message: message}
+}
+
+// WriteJSON sends v as JSON body with given HTTP status code.
+func WriteJSON(w http.ResponseWriter, status int, v interface{}) {
+	js, err := json.Marshal(v)
+	if err != nil {
+		log.Println(err)
+		status = http.StatusInternalServerError
+		js = []byte(`{"status":"error","error":{"code":"` + api.ErrCodeInternal + `","message":"Error converting results to json"}}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(js)
+}
+
+// WriteError sends error envelope, status code is taken from the error itself.
+func WriteError(w http.ResponseWriter, apiErr *ApiError) {
+	status := apiErr.HTTPStatus
+	if status == 0 {
+		status = http.StatusBadRequest
+	}
+	WriteJSON(w, status, ApiErrorResponse{Status: "error", Error: apiErr})
+}
+
+// DecodeJSONBody fills v from request body, unknown fields are rejected
+func DecodeJSONBody(r *http.Request, v interface{}) *ApiError {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		return NewApiError(http.StatusUnsupportedMediaType, api.ErrCodeInvalidJSON, "Content-Type must be application/json")
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxRequestBody+1))
+	if err != nil {
+		return NewApiError(http.StatusBadRequest, api.ErrCodeBadRequest, "Error reading request body")
+	}
+	if len(body) > maxRequestBody {
+		return NewApiError(http.StatusRequestEntityTooLarge, api.ErrCodeBadRequest, "Request body too large")
+	}
+
+	if len(body) == 0 {
+		return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidJSON, "Empty request body")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidJSON, err.Error())
+	}
+
+	return nil
+}
+
+// legacyUserUUIDPrefix is key prefix of user uuids created by legacy zond
+// creation before user/uuid/ was used everywhere
+const legacyUserUUIDPrefix = "user/UUID/"
+
+// GetUserUUID returns uuid of user from X-Forwarded-User, creating it on
+// first access. User known only by legacy key keeps its uuid
+func GetUserUUID(r *http.Request) string {
+	user := r.Header.Get("X-Forwarded-User")
+	userUUID, _ := Client.Get("user/uuid/" + user).Result()
+	if userUUID != "" {
+		return userUUID
+	}
+
+	userUUID, _ = Client.Get(legacyUserUUIDPrefix + user).Result()
+	if userUUID == "" {
+		u, _ := uuid.NewV4()
+		userUUID = u.String()
+	}
+	Client.Set(fmt.Sprintf("user/uuid/%s", user), userUUID, 0)
+	return userUUID
+}
+
+// migrateScanCount is COUNT hint of SCAN over legacy user keys
+const migrateScanCount = 500
+
+// MigrateLegacyUserUUIDs moves users from legacy user/UUID/ keys to
+// user/uuid/. Zonds of user having both uuids are moved to the current one
+func MigrateLegacyUserUUIDs() {
+	var cursor uint64
+	for {
+		keys, next, err := Client.Scan(cursor, legacyUserUUIDPrefix+"*", migrateScanCount).Result()
+		if err != nil {
+			log.Println(err.Error())
+			return
+		}
+
+		for _, key := range keys {
+			user := strings.TrimPrefix(key, legacyUserUUIDPrefix)
+			legacyUUID, _ := Client.Get(key).Result()
+			Client.SetNX("user/uuid/"+user, legacyUUID, 0)
+			userUUID, _ := Client.Get("user/uuid/" + user).Result()
+			if legacyUUID != "" && userUUID != legacyUUID {
+				moveUserZonds(legacyUUID, userUUID)
+			}
+			Client.Del(key)
+			log.Println("legacy user uuid of", user, "migrated")
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return
+		}
+	}
+}
+
+// moveUserZonds gives zonds of one user uuid to another
+func moveUserZonds(fromUUID string, toUUID string) {
+	zonds, _ := Client.SMembers(userListKey(listZonds, fromUUID)).Result()
+	for _, zondUUID := range zonds {
+		js, _ := Client.Get("zonds/" + zondUUID).Result()
+		var zond Zond
+		if err := json.Unmarshal([]byte(js), &zond); err != nil {
+			log.Println(err.Error())
+			continue
+		}
+
+		zond.Creator = toUUID
+		moved, _ := json.Marshal(zond)
+		Client.Set("zonds/"+zondUUID, string(moved), 0)
+		IndexRemove(listZonds, fromUUID, zondUUID)
+		IndexAdd(listZonds, toUUID, zondUUID, zond.Created)
+	}
+}
+
+// ApiAuth rejects requests that did not pass nginx auth_request
+func ApiAuth(f http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Forwarded-User") == "" {
+			WriteError(w, NewApiError(http.StatusUnauthorized, api.ErrCodeUnauthorized, "Not authorized"))
+			return
+		}
+
+		f.ServeHTTP(w, r)
+	}
+}
+
+// Deprecated marks legacy /api/* routes as aliases of their /api/v1 successor
+func Deprecated(successor string, f http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "<"+successor+">; rel=\"successor-version\"")
+
+		f.ServeHTTP(w, r)
+	}
+}
+
+func ApiV1TaskCreateHandler(w http.ResponseWriter, r *http.Request) {
+	var req TaskCreateRequest
+	if apiErr := DecodeJSONBody(r, &req); apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	action, eligible, apiErr := CreateTask(GetUserUUID(r), req)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, TaskCreateResponse{Status: "ok", UUID: action.UUID, Task: action, Eligible: eligible})
+}
+
+// ApiV1TaskBulkHandler creates tasks for list of targets sent as JSON or
+// uploaded as text file in multipart form
+func ApiV1TaskBulkHandler(w http.ResponseWriter, r *http.Request) {
+	var req TaskBulkRequest
+	var apiErr *ApiError
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		req, apiErr = TaskBulkRequestFromForm(w, r)
+	} else {
+		apiErr = DecodeJSONBody(r, &req)
+	}
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	resp, apiErr := CreateTaskBatch(GetUserUUID(r), req)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+func ApiV1TaskListHandler(w http.ResponseWriter, r *http.Request) {
+	query, apiErr := ListQueryFromRequest(r)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	w.Header().Set("X-CSRF-Token", csrf.Token(r))
+	WriteJSON(w, http.StatusOK, ListUserTasks(GetUserUUID(r), query))
+}
+
+func ApiV1TaskGetHandler(w http.ResponseWriter, r *http.Request) {
+	task, apiErr := GetUserTask(GetUserUUID(r), mux.Vars(r)["uuid"])
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, TaskResponse{Status: "ok", Task: task})
+}
+
+// ApiV1TaskDetailHandler returns task with its attempts, zond, child tasks and other runs
+func ApiV1TaskDetailHandler(w http.ResponseWriter, r *http.Request) {
+	detail, apiErr := GetTaskDetail(GetUserUUID(r), mux.Vars(r)["uuid"])
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, detail)
+}
+
+// ApiV1TaskDNSHandler compares answers zonds got for child tasks of dns measurement
+func ApiV1TaskDNSHandler(w http.ResponseWriter, r *http.Request) {
+	consistency, apiErr := GetDNSConsistency(GetUserUUID(r), mux.Vars(r)["uuid"])
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, consistency)
+}
+
+func ApiV1TaskCancelHandler(w http.ResponseWriter, r *http.Request) {
+	task, apiErr := CancelTask(GetUserUUID(r), mux.Vars(r)["uuid"])
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, TaskResponse{Status: "ok", Task: task})
+}
+
+func ApiV1TaskRepeatableListHandler(w http.ResponseWriter, r *http.Request) {
+	results := ListRepeatableTasks(GetUserUUID(r))
+
+	w.Header().Set("X-CSRF-Token", csrf.Token(r))
+	WriteJSON(w, http.StatusOK, TaskListResponse{Status: "ok", Results: results, Count: int64(len(results)), Pages: 1, Page: 1})
+}
+
+func ApiV1TaskRepeatableRemoveHandler(w http.ResponseWriter, r *http.Request) {
+	if apiErr := RemoveRepeatableTask(GetUserUUID(r), mux.Vars(r)["uuid"]); apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, ApiStatusResponse{Status: "ok"})
+}
+
+func ApiV1ZondCreateHandler(w http.ResponseWriter, r *http.Request) {
+	var req CreateRequest
+	if apiErr := DecodeJSONBody(r, &req); apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	zond, secret := CreateZond(GetUserUUID(r), req.Name)
+	WriteJSON(w, http.StatusCreated, CreateResponse{Status: "ok", UUID: zond.UUID, Secret: secret, EnrollToken: enrollToken(zond.UUID)})
+}
+
+func ApiV1ZondListHandler(w http.ResponseWriter, r *http.Request) {
+	query, apiErr := ListQueryFromRequest(r)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	w.Header().Set("X-CSRF-Token", csrf.Token(r))
+	WriteJSON(w, http.StatusOK, ListUserZonds(GetUserUUID(r), query))
+}
+
+func ApiV1ZondUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	var req UpdateRequest
+	if apiErr := DecodeJSONBody(r, &req); apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	zond, apiErr := UpdateZond(GetUserUUID(r), mux.Vars(r)["uuid"], req)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, ZondResponse{Status: "ok", Zond: zond})
+}
+
+func ApiV1ZondDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	if apiErr := DeleteZond(GetUserUUID(r), mux.Vars(r)["uuid"]); apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, ApiStatusResponse{Status: "ok"})
+}
+
+func ApiV1ZondRotateHandler(w http.ResponseWriter, r *http.Request) {
+	zond, secret, apiErr := RotateZond(GetUserUUID(r), mux.Vars(r)["uuid"])
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, ZondResponse{Status: "ok", Zond: zond, Secret: secret, EnrollToken: enrollToken(zond.UUID)})
+}
+
+func ApiV1ZondEnrollTokenHandler(w http.ResponseWriter, r *http.Request) {
+	zondUUID := mux.Vars(r)["uuid"]
+	token, apiErr := IssueEnrollToken(GetUserUUID(r), zondUUID)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, CreateResponse{Status: "ok", UUID: zondUUID, EnrollToken: token})
+}
+
+func ApiV1MngrCreateHandler(w http.ResponseWriter, r *http.Request) {
+	var req CreateRequest
+	if apiErr := DecodeJSONBody(r, &req); apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	mngr, secret := CreateMngr(GetUserUUID(r), req.Name)
+	WriteJSON(w, http.StatusCreated, CreateResponse{Status: "ok", UUID: mngr.UUID, Secret: secret})
+}
+
+func ApiV1MngrListHandler(w http.ResponseWriter, r *http.Request) {
+	query, apiErr := ListQueryFromRequest(r)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	w.Header().Set("X-CSRF-Token", csrf.Token(r))
+	WriteJSON(w, http.StatusOK, ListUserMngrs(GetUserUUID(r), query))
+}
+
+func ApiV1MngrUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	var req UpdateRequest
+	if apiErr := DecodeJSONBody(r, &req); apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	mngr, apiErr := UpdateMngr(GetUserUUID(r), mux.Vars(r)["uuid"], req)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, MngrResponse{Status: "ok", Mngr: mngr})
+}
+
+func ApiV1MngrDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	if apiErr := DeleteMngr(GetUserUUID(r), mux.Vars(r)["uuid"]); apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, ApiStatusResponse{Status: "ok"})
+}
+
+func ApiV1MngrRotateHandler(w http.ResponseWriter, r *http.Request) {
+	mngr, secret, apiErr := RotateMngr(GetUserUUID(r), mux.Vars(r)["uuid"])
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, MngrResponse{Status: "ok", Mngr: mngr, Secret: secret})
+}
+
+func ApiV1TokenHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-CSRF-Token", csrf.Token(r))
+	WriteJSON(w, http.StatusOK, ApiStatusResponse{Status: "ok"})
+}
+
+func ApiV1RetentionGetHandler(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, GetRetention(GetUserUUID(r)))
+}
+
+func ApiV1RetentionSetHandler(w http.ResponseWriter, r *http.Request) {
+	var req RetentionPolicy
+	if apiErr := DecodeJSONBody(r, &req); apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	resp, apiErr := SetUserRetention(GetUserUUID(r), req)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+func ApiV1ScheduleRetentionHandler(w http.ResponseWriter, r *http.Request) {
+	var req RetentionPolicy
+	if apiErr := DecodeJSONBody(r, &req); apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	resp, apiErr := SetScheduleRetention(GetUserUUID(r), mux.Vars(r)["uuid"], req)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+// ApiV1RetentionReportHandler reports what compaction would remove from tasks of user now
+func ApiV1RetentionReportHandler(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, CompactUser(GetUserUUID(r), true))
+}
+
+// ApiV1TaskExportHandler streams tasks of user as CSV or JSON Lines
+func ApiV1TaskExportHandler(w http.ResponseWriter, r *http.Request) {
+	format := r.FormValue("format")
+	if format == "" {
+		format = exportCSV
+	}
+	if format != exportCSV && format != exportJSONL && format != exportNDJSON {
+		WriteError(w, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "format must be csv, jsonl or ndjson"))
+		return
+	}
+
+	filter, apiErr := TaskFilterFromRequest(r)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	ExportTasks(w, GetUserUUID(r), format, filter)
+}
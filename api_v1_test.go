@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLegacyZondCreateResponse(t *testing.T) {
+	useTestRedis(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/zond/create", strings.NewReader("name=z1"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Forwarded-User", "alice")
+	w := httptest.NewRecorder()
+	ApiZondCreateHandler(w, req)
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp["UUID"] == nil || resp["UUID"] == "" {
+		t.Errorf("legacy response has no UUID key: %s", w.Body.String())
+	}
+}
+
+func TestMigrateLegacyUserUUIDs(t *testing.T) {
+	useTestRedis(t)
+
+	// user known only by legacy key keeps its uuid
+	Client.Set(legacyUserUUIDPrefix+"bob", "bob-legacy", 0)
+	// user having both uuids gets zonds of legacy one
+	Client.Set(legacyUserUUIDPrefix+"carol", "carol-legacy", 0)
+	Client.Set("user/uuid/carol", "carol-current", 0)
+	zond, _ := CreateZond("carol-legacy", "old")
+
+	MigrateLegacyUserUUIDs()
+
+	if got := Client.Get("user/uuid/bob").Val(); got != "bob-legacy" {
+		t.Errorf("bob uuid = %q, want bob-legacy", got)
+	}
+	if Client.Exists(legacyUserUUIDPrefix+"bob", legacyUserUUIDPrefix+"carol").Val() != 0 {
+		t.Error("legacy keys are not removed")
+	}
+
+	if !Client.SIsMember(userListKey(listZonds, "carol-current"), zond.UUID).Val() {
+		t.Error("zond is not moved to current uuid of user")
+	}
+	if Client.SCard(userListKey(listZonds, "carol-legacy")).Val() != 0 {
+		t.Error("zond is left at legacy uuid of user")
+	}
+	var moved Zond
+	json.Unmarshal([]byte(Client.Get("zonds/"+zond.UUID).Val()), &moved)
+	if moved.Creator != "carol-current" {
+		t.Errorf("creator = %q, want carol-current", moved.Creator)
+	}
+}
+
+func TestGetUserUUIDLegacyKey(t *testing.T) {
+	useTestRedis(t)
+
+	Client.Set(legacyUserUUIDPrefix+"dave", "dave-legacy", 0)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Forwarded-User", "dave")
+
+	if got := GetUserUUID(req); got != "dave-legacy" {
+		t.Errorf("GetUserUUID = %q, want dave-legacy", got)
+	}
+}
+
+func TestTaskExportAuth(t *testing.T) {
+	useTestRedis(t)
+	handler := NewHandler(NewRouter())
+
+	for user, status := range map[string]int{"": http.StatusUnauthorized, "alice": http.StatusOK} {
+		req := httptest.NewRequest(http.MethodGet, "/api/task/export", nil)
+		req.Header.Set("X-Forwarded-User", user)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != status {
+			t.Errorf("export as %q: status %d, want %d", user, w.Code, status)
+		}
+	}
+}
+
+func TestTaskBulkAliasAuth(t *testing.T) {
+	useTestRedis(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/task/bulk", strings.NewReader(`{"action": "ping", "targets": ["8.8.8.8"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("bulk without user: status %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestTaskDetailAuth(t *testing.T) {
+	useTestRedis(t)
+	handler := NewHandler(NewRouter())
+
+	action, _, apiErr := CreateTask("alice", TaskCreateRequest{Action: "ping", Param: "8.8.8.8", QueueTTL: 60})
+	if apiErr != nil {
+		t.Fatal(apiErr.Message)
+	}
+
+	for path, status := range map[string]int{
+		"/api/v1/tasks/" + action.UUID + "/detail": http.StatusUnauthorized,
+		// detail is not served by unversioned api
+		"/api/task/" + action.UUID: http.StatusNotFound,
+	} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if w.Code != status {
+			t.Errorf("%s without user: status %d, want %d", path, w.Code, status)
+		}
+	}
+}
@@ -2,20 +2,18 @@ package main
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
-	"strconv"
 	"time"
 
+	"github.com/ad/gocc/api"
 	"github.com/gorilla/csrf"
 	uuid "github.com/nu7hatch/gouuid"
 )
 
-func ApiZondCreateHandler(w http.ResponseWriter, r *http.Request) {
-	// if r.Method == "POST" {
-	name := r.FormValue("name")
-
+// CreateZond registers new zond owned by user, secret of zond is returned
+// only here and is not shown again
+func CreateZond(userUUID string, name string) (Zond, string) {
 	u, _ := uuid.NewV4()
 	var UUID = u.String()
 	var msec = time.Now().Unix()
@@ -24,94 +22,192 @@ func ApiZondCreateHandler(w http.ResponseWriter, r *http.Request) {
 		name = UUID
 	}
 
+	secret := NewAgentSecret()
+	Client.Set(agentSecretKey(UUID), secret, 0)
 	Client.SAdd("zonds", UUID)
 
-	userUUID, _ := Client.Get("user/UUID/" + r.Header.Get("X-Forwarded-User")).Result()
-	if userUUID == "" {
-		u, _ := uuid.NewV4()
-		userUUID = u.String()
-		Client.Set(fmt.Sprintf("user/UUID/%s", r.Header.Get("X-Forwarded-User")), userUUID, 0)
-	}
-
 	zond := Zond{UUID: UUID, Name: name, Created: msec, Creator: userUUID}
 	js, _ := json.Marshal(zond)
 
 	Client.Set("zonds/"+UUID, string(js), 0)
-	Client.SAdd("user/zonds/"+userUUID, UUID)
+	IndexAdd(listZonds, userUUID, UUID, msec)
 
 	log.Println("Zond created", UUID)
 
-	// if r.Header.Get("X-Requested-With") == "xmlhttprequest" {
-	// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-	fmt.Fprintf(w, `{"status": "ok", "UUID": "%s"}`, UUID)
-	// } else {
-	// 	ShowCreateForm(w, r, UUID)
-	// }
-	// } else {
-	// 	ShowCreateForm(w, r, "")
-	// }
+	return zond, secret
+}
+
+// enrollToken returns one-time certificate enrollment token of new zond,
+// it is empty when enrollment is disabled
+func enrollToken(zondUUID string) string {
+	if ca == nil {
+		return ""
+	}
+	return NewEnrollToken(zondUUID)
+}
+
+// Deprecated: use ApiV1ZondCreateHandler
+func ApiZondCreateHandler(w http.ResponseWriter, r *http.Request) {
+	zond, secret := CreateZond(GetUserUUID(r), r.FormValue("name"))
+
+	WriteJSON(w, http.StatusOK, ZondCreateLegacyResponse{Status: "ok", UUID: zond.UUID, Secret: secret, EnrollToken: enrollToken(zond.UUID)})
+}
+
+// ListUserZonds returns page of zonds of user created in range of query
+func ListUserZonds(userUUID string, query ListQuery) ZondListResponse {
+	page := readList(listZonds, userUUID, query, nil)
+
+	results := []Zond{}
+	for _, js := range page.Entries {
+		var t Zond
+		if err := json.Unmarshal([]byte(js), &t); err != nil {
+			log.Println(err.Error())
+		}
+		results = append(results, t)
+	}
+
+	return ZondListResponse{Status: "ok", Results: results, Count: page.Count, Pages: page.Pages, Page: page.Page, HasPrev: page.HasPrev, HasNext: page.HasNext, NextCursor: page.NextCursor}
 }
 
+// Deprecated: use ApiV1ZondListHandler
 func ApiShowMyZonds(w http.ResponseWriter, r *http.Request) {
-	var perPage int = 20
-	page, _ := strconv.ParseInt(r.FormValue("page"), 10, 0)
-	if page <= 0 {
-		page = 1
+	query, apiErr := ListQueryFromRequest(r)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	w.Header().Set("X-CSRF-Token", csrf.Token(r))
+	WriteJSON(w, http.StatusOK, ListUserZonds(GetUserUUID(r), query))
+}
+
+// GetUserZond returns zond, zonds of other users are reported as not found
+func GetUserZond(userUUID string, zondUUID string) (Zond, *ApiError) {
+	var zond Zond
+
+	if !Client.SIsMember("user/zonds/"+userUUID, zondUUID).Val() {
+		return zond, NewApiError(http.StatusNotFound, api.ErrCodeNotFound, "zond not found")
 	}
-	userUuid, _ := Client.Get("user/uuid/" + r.Header.Get("X-Forwarded-User")).Result()
-	if userUuid == "" {
-		u, _ := uuid.NewV4()
-		userUuid = u.String()
-		Client.Set(fmt.Sprintf("user/uuid/%s", r.Header.Get("X-Forwarded-User")), userUuid, 0)
+
+	js, _ := Client.Get("zonds/" + zondUUID).Result()
+	if err := json.Unmarshal([]byte(js), &zond); err != nil {
+		log.Println(err.Error())
+		return zond, NewApiError(http.StatusInternalServerError, api.ErrCodeInternal, "zond is broken")
 	}
 
-	count, _ := Client.SCard("user/zonds/" + userUuid).Result()
-	currentPage, pages, hasPrev, hasNext := GetPaginator(int(page), int(count), perPage)
+	return zond, nil
+}
 
-	var results []Zond
-	if count > 0 {
-		// log.Println(count)
-		var cursor = uint64(int64(perPage) * int64(currentPage-1))
-		// var cursorNew uint64
-		var keys []string
-		var err error
-		keys, _, err = Client.SScan("user/zonds/"+userUuid, cursor, "", int64(perPage)).Result()
+func saveZond(zond Zond) {
+	js, _ := json.Marshal(zond)
+	Client.Set("zonds/"+zond.UUID, string(js), 0)
+}
 
-		if err != nil {
-			log.Println(err)
+// UpdateZond renames zond, replaces its labels and disables or enables it.
+// Disabled zond is kept out of zonds set, so it can't authorize or subscribe
+func UpdateZond(userUUID string, zondUUID string, req UpdateRequest) (Zond, *ApiError) {
+	zond, apiErr := GetUserZond(userUUID, zondUUID)
+	if apiErr != nil {
+		return zond, apiErr
+	}
+
+	if req.Labels != nil {
+		if apiErr := ValidateLabels(*req.Labels); apiErr != nil {
+			return zond, apiErr
+		}
+		zond.Labels = *req.Labels
+	}
+
+	if req.Name != nil {
+		if len(*req.Name) == 0 {
+			return zond, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "name can't be empty")
+		}
+		zond.Name = *req.Name
+	}
+
+	if req.Disabled != nil && *req.Disabled != zond.Disabled {
+		zond.Disabled = *req.Disabled
+		if zond.Disabled {
+			Client.SRem("zonds", zond.UUID)
+			DisconnectZond(zond.UUID)
+			log.Println("Zond disabled", zond.UUID)
 		} else {
-			// log.Println(keys)
-			for i, val := range keys {
-				keys[i] = "zonds/" + val
-			}
-
-			items, _ := Client.MGet(keys...).Result()
-			for _, val := range items {
-				if val != nil {
-					var t Zond
-					err := json.Unmarshal([]byte(val.(string)), &t)
-					if err != nil {
-						log.Println(err.Error())
-					}
-					results = append(results, t)
-				}
-			}
-			// log.Println(len(results), count, results)
+			Client.SAdd("zonds", zond.UUID)
+			log.Println("Zond enabled", zond.UUID)
 		}
-		// log.Println(len(results), count, currentPage, cursor, cursorNew, perPage)
 	}
 
-	varmap := map[string]interface{}{
-		"results":  results,
-		"count":    count,
-		"pages":    pages,
-		"page":     page,
-		"has_prev": hasPrev,
-		"has_next": hasNext,
+	zond.Updated = time.Now().Unix()
+	saveZond(zond)
+
+	if req.Labels != nil {
+		GetActiveDestinations()
 	}
 
-	js, _ := json.Marshal(varmap)
+	return zond, nil
+}
 
-	w.Header().Set("X-CSRF-Token", csrf.Token(r))
-	fmt.Fprintf(w, `%s`, js)
+// DeleteZond removes zond, its claimed tasks are returned to queue
+func DeleteZond(userUUID string, zondUUID string) *ApiError {
+	zond, apiErr := GetUserZond(userUUID, zondUUID)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	Client.SRem("zonds", zond.UUID)
+	IndexRemove(listZonds, userUUID, zond.UUID)
+	Client.Del("zonds/"+zond.UUID, agentSecretKey(zond.UUID))
+	RevokeZondCerts(zond.UUID)
+	DisconnectZond(zond.UUID)
+
+	log.Println("Zond deleted", zond.UUID)
+
+	return nil
+}
+
+// RotateZond replaces uuid and secret of zond, old credentials and
+// certificates stop working at once, zond has to enroll again
+func RotateZond(userUUID string, zondUUID string) (Zond, string, *ApiError) {
+	zond, apiErr := GetUserZond(userUUID, zondUUID)
+	if apiErr != nil {
+		return zond, "", apiErr
+	}
+
+	u, _ := uuid.NewV4()
+	zond.UUID = u.String()
+	secret := NewAgentSecret()
+	Client.Set(agentSecretKey(zond.UUID), secret, 0)
+	zond.Updated = time.Now().Unix()
+	saveZond(zond)
+
+	IndexAdd(listZonds, userUUID, zond.UUID, zond.Created)
+	if !zond.Disabled {
+		Client.SAdd("zonds", zond.UUID)
+	}
+
+	Client.SRem("zonds", zondUUID)
+	IndexRemove(listZonds, userUUID, zondUUID)
+	Client.Del("zonds/"+zondUUID, agentSecretKey(zondUUID))
+	RevokeZondCerts(zondUUID)
+	DisconnectZond(zondUUID)
+
+	log.Println("Zond", zondUUID, "rotated to", zond.UUID)
+
+	return zond, secret, nil
+}
+
+// DisconnectZond drops zond from online set, geo and network hashes, closes its
+// subscription and returns its claimed tasks to queue
+func DisconnectZond(zondUUID string) {
+	Client.SRem("Zond-online", zondUUID)
+	Client.HDel("zond:city", zondUUID)
+	Client.HDel("zond:country", zondUUID)
+	Client.HDel("zond:asn", zondUUID)
+	Client.HDel(zondNetworkHash, zondUUID)
+	Client.Del(zondUUID + "/alive")
+
+	RequeueAgentTasks(zondUUID)
+
+	go Delete(*nchanaddr + "/pub/zond:" + zondUUID)
+	GetActiveDestinations()
 }
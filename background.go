@@ -8,10 +8,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ad/gocc/api"
 	uuid "github.com/nu7hatch/gouuid"
 )
 
 var HistoryHours = flag.Int64("historyHours", 6, "How many hours check from history of repeatable tasks in case of downtime")
+var taskTTL = flag.Int64("taskttl", 86400, "Seconds queued task waits for zond before it expires when task has no queue_ttl, 0 keeps it forever")
 
 func ResetProcessing() {
 	tasks, _ := Client.SMembers("tasks-process").Result()
@@ -21,24 +23,55 @@ func ResetProcessing() {
 			ZondUuid, taskUuid := s[0], s[1]
 
 			tp, _ := Client.Get(task + "/processing").Result()
-			if tp != "1" {
+			if tp == "" {
 				log.Println("Removed outdated task", tp, ZondUuid, taskUuid)
-				Client.SRem("zond-busy", ZondUuid)
-				Client.SRem("tasks-process", task)
+				RequeueTask(ZondUuid, taskUuid)
+			}
+		}
+	}
+}
 
-				Client.SAdd("tasks-new", taskUuid)
+// RequeueTask returns task claimed by zond or manager to queue and publishes it again
+func RequeueTask(agentUUID string, taskUUID string) {
+	Client.SRem("zond-busy", agentUUID)
+	Client.SRem("tasks-process", agentUUID+"/"+taskUUID)
+	Client.Del(claimKey(agentUUID, taskUUID))
 
-				js, _ := Client.Get("task/" + taskUuid).Result()
-				var action Action
-				err := json.Unmarshal([]byte(js), &action)
-				if err != nil {
-					log.Println(err.Error())
-				} else {
-					go Post("http://127.0.0.1:80/pub/"+action.Target, string(js))
-					log.Println("Task resend to queue", action)
-				}
-			}
+	Client.SAdd("tasks-new", taskUUID)
+
+	js, _ := Client.Get("task/" + taskUUID).Result()
+	var action Action
+	err := json.Unmarshal([]byte(js), &action)
+	if err != nil {
+		log.Println(err.Error())
+	} else {
+		PublishTask(action, js)
+		log.Println("Task resend to queue", action)
+	}
+}
+
+// RequeueAgentTasks returns all tasks claimed by zond or manager to queue
+func RequeueAgentTasks(agentUUID string) {
+	tasks, _ := Client.SMembers("tasks-process").Result()
+	for _, task := range tasks {
+		if strings.HasPrefix(task, agentUUID+"/") {
+			RequeueTask(agentUUID, strings.TrimPrefix(task, agentUUID+"/"))
+		}
+	}
+}
+
+// PublishTask sends task to its destination, measurements are sent to
+// managers, task with label selector is sent to every matching online zond
+// reaching address family of task
+func PublishTask(action Action, js string) {
+	if action.Type != api.TypeTask {
+		go Post(*nchanaddr+"/pub/mngrtasks", js)
+	} else if strings.HasPrefix(action.Target, labelsTargetPrefix) {
+		for _, zondUUID := range EligibleZonds(action.Target, TaskFamily(action.Action, action.Param)) {
+			go Post(*nchanaddr+"/pub/zond:"+zondUUID, js)
 		}
+	} else {
+		go Post(*nchanaddr+"/pub/"+taskChannel(action), js)
 	}
 }
 
@@ -57,8 +90,10 @@ func ResendOffline() {
 			} else {
 				if action.Result != "" {
 					Client.SRem("tasks-new", action.UUID)
+				} else if TaskExpired(action) {
+					ExpireTask(action)
 				} else {
-					go Post("http://127.0.0.1:80/pub/"+action.Target, string(js))
+					PublishTask(action, js)
 					log.Println(action)
 				}
 			}
@@ -66,6 +101,65 @@ func ResendOffline() {
 	}
 }
 
+// TaskDeadline returns unix time after which queued task expires, 0 is never
+func TaskDeadline(action Action) int64 {
+	ttl := action.QueueTTL
+	if ttl == 0 {
+		ttl = *taskTTL
+	}
+	if ttl <= 0 {
+		return 0
+	}
+	return action.Created + ttl
+}
+
+// TaskExpired reports whether deadline of queued task is passed
+func TaskExpired(action Action) bool {
+	deadline := TaskDeadline(action)
+	return deadline != 0 && time.Now().Unix() > deadline
+}
+
+// ExpireTask moves queued task to expired state and notifies creator on
+// tasks/done channel, manager of child task is notified as with results.
+// False means task was claimed or finished meanwhile
+func ExpireTask(action Action) bool {
+	if Client.SRem("tasks-new", action.UUID).Val() != int64(1) {
+		return false
+	}
+
+	action.Status = api.TaskStatusExpired
+	action.Updated = time.Now().Unix()
+
+	js, _ := json.Marshal(action)
+	Client.Set("task/"+action.UUID, string(js), 0)
+
+	go Post(*nchanaddr+"/pub/tasks/done", string(js))
+	if action.ParentUUID != "" && action.MngrUUID != "" {
+		go Post(*nchanaddr+"/pub/"+api.ChannelMngr(action.MngrUUID), string(js))
+	}
+
+	log.Println("Task expired", action.UUID, "created at", action.Created)
+
+	return true
+}
+
+// ExpireTasks expires queued tasks whose deadline is passed
+func ExpireTasks() {
+	tasks, _ := Client.SMembers("tasks-new").Result()
+	for _, task := range tasks {
+		js, _ := Client.Get("task/" + task).Result()
+
+		var action Action
+		if err := json.Unmarshal([]byte(js), &action); err != nil {
+			log.Println(task, err.Error())
+			continue
+		}
+		if TaskExpired(action) {
+			ExpireTask(action)
+		}
+	}
+}
+
 func ResendRepeatable(fromPast bool) {
 	var historyHours int64 = *HistoryHours
 	if !fromPast {
@@ -103,32 +197,17 @@ func ResendRepeatable(fromPast bool) {
 					js, _ := json.Marshal(action)
 
 					Client.Set("task/"+UUID, string(js), 0)
-					Client.SAdd("user/tasks/"+action.Creator, UUID)
+					IndexAdd(listTasks, action.Creator, UUID, action.Created)
 
 					t := time.Now()
 
-					repeatTypes := map[string]int{
-						"5min":   300,
-						"10min":  600,
-						"30min":  1800,
-						"1hour":  3600,
-						"3hour":  10800,
-						"6hour":  21600,
-						"12hour": 43200,
-						"1day":   86400,
-						"1week":  604800,
-					}
 					tnew := t.Add(time.Duration(repeatTypes[action.Repeat]) * time.Second).Unix()
 					t300new := (tnew - (tnew % 300))
 					log.Println("next start will be at ", strconv.FormatInt(t300new, 10))
 
 					Client.SAdd("tasks-repeatable-"+strconv.FormatInt(t300new, 10), string(js))
 
-					if action.Type != "task" {
-						go Post("http://127.0.0.1:80/pub/mngrtasks", string(js))
-					} else {
-						go Post("http://127.0.0.1:80/pub/"+action.Target, string(js))
-					}
+					PublishTask(action, string(js))
 
 					Client.SRem("tasks-repeatable-"+t300, task)
 				}
@@ -148,10 +227,10 @@ func CheckAlive() {
 				u, _ := uuid.NewV4()
 				var UUID = u.String()
 				var msec = time.Now().Unix()
-				action := Action{Action: "alive", UUID: UUID, Created: msec}
+				action := Action{Action: api.ActionAlive, UUID: UUID, Created: msec}
 				js, _ := json.Marshal(action)
 				Client.Set(zond+"/alive", UUID, 90*time.Second)
-				go Post("http://127.0.0.1:80/pub/zond:"+zond, string(js))
+				go Post(*nchanaddr+"/pub/zond:"+zond, string(js))
 			} else {
 				log.Println(zond, "— removed")
 				Client.SRem("Zond-online", zond)
@@ -159,9 +238,36 @@ func CheckAlive() {
 				Client.HDel("zond:city", zond)
 				Client.HDel("zond:country", zond)
 				Client.HDel("zond:asn", zond)
-				go Delete("http://127.0.0.1:80/pub/zond:" + zond)
+				Client.HDel(zondNetworkHash, zond)
+				go Delete(*nchanaddr + "/pub/zond:" + zond)
 				GetActiveDestinations()
 			}
 		}
 	}
 }
+
+// CheckMngrAlive pings online managers, manager that did not answer previous
+// check is removed and its measurements are returned to queue at once
+func CheckMngrAlive() {
+	mngrs, _ := Client.SMembers("mngr-online").Result()
+	for _, mngr := range mngrs {
+		tp, _ := Client.Get(mngr + "/alive").Result()
+		if tp == "" {
+			u, _ := uuid.NewV4()
+			var UUID = u.String()
+			action := Action{Action: api.ActionAlive, UUID: UUID, Created: time.Now().Unix()}
+			js, _ := json.Marshal(action)
+			Client.Set(mngr+"/alive", UUID, 90*time.Second)
+			go Post(*nchanaddr+"/pub/"+api.ChannelMngr(mngr), string(js))
+		} else {
+			log.Println(mngr, "— removed")
+			Client.SRem("mngr-online", mngr)
+			Client.Del(mngr + "/alive")
+
+			RequeueAgentTasks(mngr)
+
+			go Delete(*nchanaddr + "/pub/" + api.ChannelMngr(mngr))
+			PublishMngrState(mngr, api.ActionOffline)
+		}
+	}
+}
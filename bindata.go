@@ -7,6 +7,7 @@
 // templates/password_recovery.html
 // templates/register.html
 // templates/repeatable.html
+// templates/task.html
 // templates/tasks.html
 // templates/zonds.html
 // DO NOT EDIT!
@@ -76,7 +77,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _dashboardHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5a\xdf\x6f\x23\xb7\xf1\x7f\xbf\xbf\x62\xc2\x7c\xbf\xd1\xba\x96\xb4\xb2\x2f\x97\xa2\xb2\x76\x81\xc4\xbe\xf4\x5c\x5c\x7c\x57\xdb\xd7\x04\x4d\xd3\x82\x5e\x8e\x24\xc2\x14\xb9\x21\x29\xff\x88\x61\x20\xc8\x43\x5f\x52\xf4\xa1\x7d\xe8\x43\x9f\xfa\x1f\x24\x40\x82\xb6\x28\x72\x7f\x83\xfc\x1f\x15\x24\x77\xa5\x95\xbc\x92\xe5\x0b\x92\x16\xc5\xc9\x80\xb5\x4b\xce\x7c\x38\x9c\x19\x0e\x67\x48\xf5\x86\x76\x24\xd2\x07\x0f\x7a\x43\xa4\x2c\x7d\x00\x00\xd0\xb3\xdc\x0a\x4c\x77\x95\xb4\x5a\x09\xc8\x50\x5a\xd4\xbd\x38\xb4\x06\x0a\x93\x69\x9e\xdb\xf0\xe2\x3e\x67\x54\x83\x51\xd9\x29\x5a\x48\x40\xe2\x39\x7c\x88\x27\x47\xfe\x3d\x22\xe7\xa6\x1b\xc7\x04\x36\x41\xa8\x8c\x5a\xae\x64\x7b\xa8\x8c\x85\x4d\x20\xb1\x19\x9f\xc4\x96\x9a\x53\x13\x33\x25\xb1\x79\x75\xd5\x7e\xff\x97\x7b\x07\xd7\xd7\x64\x63\xe7\xc1\x14\x3b\xe0\xb6\x95\x1c\xa1\x31\x74\x80\x90\x40\x7f\x2c\x33\x87\x04\x51\xd1\xb6\x01\x57\x53\xfa\x52\x1e\x3c\x43\xe9\xc4\xf9\xc5\xd1\xb3\x83\x76\x4e\xb5\xc1\x92\xba\xcd\xa8\xa5\x1b\x3b\x73\x1c\x99\x92\x46\x09\x6c\x0b\x35\x88\x3c\x67\x55\x04\xf7\xe1\x7d\x08\x1d\x6d\x1a\xc6\x4e\x12\x20\x0c\x8d\xe5\xd2\xcf\xca\x90\x45\x21\xdc\x87\x32\xf6\x2c\xf7\xdd\x11\xf9\x4c\x49\x66\x48\x13\xfc\x43\x77\x3c\xe6\xac\x4b\x9a\x41\xcc\xb6\xef\xdb\x58\xc9\x4e\x8d\x9c\x71\x53\x23\x67\xcc\xae\x67\x35\x6f\xa6\xc6\xd2\x6a\x8e\x33\x80\xd0\x72\x39\x03\x99\x92\xdc\x81\xc4\xed\x1c\x0c\xb7\x55\x0c\xdf\x39\x0f\x70\x0d\x28\x0c\xd6\xab\x6f\x9c\x33\x6a\x91\xd5\x6a\x6e\xc6\x70\x86\xda\x38\x8e\x37\x12\x20\x57\x57\xed\x5f\x85\xd7\xeb\x6b\x02\x6f\xbd\x05\xf3\x24\xe9\x02\x45\x1d\x70\xe9\x1f\x25\x4f\x02\x4c\x65\xe3\x91\x83\x19\xa0\x7d\x2c\xd0\x3d\xbe\x77\xb9\xcf\x22\x52\x90\x90\x05\x5f\x99\xa2\x84\xee\xb6\xb1\x97\x02\xdb\x99\x12\x4a\x43\x02\x44\x23\x23\x2b\xe9\xb9\x94\xa8\x8f\xf1\xc2\x79\x27\xd9\xdd\x85\x42\x0d\x4d\xd0\x28\x14\x65\x90\xd3\x01\xde\x46\xb8\xae\x53\xeb\xed\xe9\xb9\xa9\x59\x7a\x22\x70\xd5\xc4\x32\x35\x1a\x51\xe7\x8d\x8b\x5e\x5e\x22\x68\x75\x0e\x49\xc0\x69\x73\x69\x50\xdb\x43\x75\x1e\x6d\x2d\x23\xcf\x50\x88\x2d\x48\x1c\x5b\x41\xbe\x8b\x42\x44\x9d\x1a\xc5\x95\xe4\xdb\xb7\xc9\xb7\x56\x90\x3f\xbc\x4d\xbe\xbd\x82\xfc\xed\xdb\xe4\x0f\x97\x09\xcf\xca\xa0\xb5\x47\x2d\x16\x3e\x57\x98\x04\x7e\x02\x5b\x9d\x4e\x67\xa3\x6d\xd5\x53\x95\x51\x81\x47\x56\x73\x39\x88\x6e\xaf\x11\xaf\x81\x60\xd9\x27\xc7\x1f\x3c\x75\xca\xb7\xb7\xc5\xf3\x13\x9f\xa3\x6a\xf4\x4c\x4e\x25\x64\x82\x1a\x93\xf8\x78\x42\xd2\x06\x6c\x96\x0b\x4a\x23\xb5\x4a\xc3\x26\x34\xe2\x59\xab\x5b\x79\xd3\x97\x11\x95\x74\x80\x8e\x84\xf4\x62\x87\x95\x92\xfa\x71\x1f\xae\x18\x37\xac\xc9\xea\xc8\xc5\x2a\xdd\x84\x46\x81\x0a\x73\x0c\x39\xd5\x74\x54\xa5\xf7\x0d\x4b\xc9\x35\xe6\x48\xe7\x66\x16\x5a\x2a\x0c\x8d\x7a\xa9\xdf\x9e\x93\x9a\xf4\x72\x8d\xa9\xdb\x4c\xa4\xd8\x3e\xd1\x51\x89\x65\xc6\xc2\x6e\x04\x15\x78\x82\x1a\x4b\xc7\xb1\x77\x08\xca\xd8\x63\xc7\xf4\x94\x1b\x8b\x12\x75\x44\x32\xc1\xb3\x53\xd2\xac\x6c\x2a\x21\xfe\xd7\xac\xad\x38\x0e\x72\x39\xab\xe0\x31\x35\xa7\x91\x1d\x72\xd3\xfe\x74\x8c\xfa\xf2\x08\x05\x66\x56\xe9\xa8\xd1\x76\x56\x6c\x6c\xcc\x04\x6f\x42\x2d\x59\x50\xf1\x1a\x84\x5e\xb7\x6b\xd0\x05\x9d\x56\x09\x6b\x56\x48\x1c\xc3\x75\x13\xfa\x54\x18\xac\x5b\x10\xfa\xdc\x4c\x17\xfe\x2c\x6a\x98\xf7\x2e\x8f\xe9\xe0\x80\x8e\x30\x6a\x1c\x1f\x36\x6a\x60\x5d\xb8\xd6\xe7\xa6\x2d\x50\x0e\xec\x10\x52\x78\xb4\xb5\x2c\xf8\x06\x70\x86\x02\x2d\xba\xa8\x52\x61\x6b\x41\x5d\x0c\x58\x88\x7c\xd3\xb7\xeb\x8a\xfc\x53\xeb\x55\x36\x2b\x67\x87\xfd\xbd\x26\xe4\x1a\xfb\xfc\xa2\x09\xdc\xe2\xc8\x2c\x4a\xb5\x2c\x46\x06\xe6\x8d\xf9\x45\xb3\xe0\xa4\x7d\xa5\x21\xe2\x90\x40\x67\x07\x38\xf4\xc2\x00\xc5\x5c\x76\x60\x73\x93\xd7\xa9\x40\xe5\xb6\x1a\x9a\x83\x33\x15\x23\x47\x8d\x67\xcf\x8f\xf7\x9f\x1d\xd4\x69\x58\xe5\xb6\x6d\xf1\xc2\xba\xbc\x2c\x64\x36\x7e\xb8\x8f\xf9\x27\xf5\xb4\x67\x54\x8c\xdd\x26\x10\x66\x0f\x9b\x2b\xc8\xef\xd2\x01\xcd\x73\x94\x6c\x77\xc8\x05\x8b\x54\x6e\x17\x84\xab\x18\xa4\xc6\x1e\x95\xc5\xe2\xe0\x9a\xe0\x12\xbe\xe3\xcb\x1c\xc3\xd3\x7e\xee\xb6\x3d\xe7\xb7\xa1\x6d\x44\xb9\xb4\xd3\x5e\x9f\x94\xd4\x25\x77\x17\x43\x5d\x04\xed\x8f\x3e\x78\xfa\xc4\xda\xfc\x10\x3f\x1d\xa3\xb1\xd1\xa2\x4f\x5f\x0c\x75\x5b\xe5\x28\xa3\xc6\xf3\x67\x47\xc7\x8d\x26\x34\x62\x9a\x73\x9f\x75\xc6\x41\xb4\x45\x5d\x3b\x0e\x83\xb6\x00\x7c\x82\x94\xa1\x8e\x1a\x85\xd6\x5b\x4e\x4a\x87\x42\xf3\x5c\xf0\x90\xcf\xc6\x17\xad\xf3\xf3\xf3\x56\x5f\xe9\x51\x6b\xac\x05\xca\x4c\x31\x64\xeb\xc1\x7e\xd4\x2a\x1a\x90\xb5\x3e\xe4\x76\xe8\xa0\x2f\x46\x62\x68\x6d\xae\x43\x47\x1d\xce\x39\xb7\xc3\x5d\x8d\x0c\xa5\xe5\x54\xf8\x05\xab\xc7\xb8\xde\x78\xbb\x47\x87\xef\xb7\x8e\xd5\x29\xca\x46\x73\x66\xf8\x85\x50\xc2\xf8\xd9\x9b\x4e\x45\xbf\x0b\x2a\x02\x2e\xf3\xb1\xfd\x58\xd2\x11\x26\xd6\xb1\x7e\xd2\xd8\x08\xfe\x55\x23\x9c\x92\x3e\x89\xa9\x66\xe9\xcb\xf2\x3b\x2f\xa3\xa5\x76\x6c\xe0\x8d\x24\x81\xed\x4e\x67\x59\xcc\xa0\x02\xb5\x8d\x1a\xc5\x6c\xa0\x4f\xb9\x40\xd6\x06\x38\x44\x3b\xd6\x12\x19\x14\x30\xaa\x0f\x6e\x8f\x99\x01\xdf\x1d\x4e\xea\xd4\x26\x59\x14\xcc\xf8\xe2\x70\x3f\x6a\x38\xbf\x4d\x1c\xac\x7b\x70\x5b\xd6\x5b\xce\x43\x7d\x4b\xe9\xcc\xbe\x95\xe7\xd3\xb6\xfd\xdc\xb7\x04\xcf\xf6\xad\x33\x27\xf7\x3d\xa5\x9f\xfb\xbe\xf2\x25\x60\x97\x7e\x3f\x05\x0b\xab\x60\xd1\xb3\xb5\x9f\x7a\x88\xe4\x3b\x6b\x2c\xc1\x5f\x2b\xc9\x22\x97\x3a\xb8\x30\xfe\x43\xac\x29\x87\xfd\x7a\x4d\xad\x5c\x53\x4e\x45\xff\x9b\x6b\x6a\x59\x39\xe2\x3e\xae\xdc\x9e\x2f\xc4\x1d\x96\x46\x93\x2b\x69\xd0\x15\x42\x4b\x0a\x2c\x37\x1f\xc7\x5c\x4e\xc8\xd5\x8c\xea\x74\x69\x55\x07\xd5\xad\x6c\x41\xfb\x5e\xf5\x2f\xc6\x9c\x95\xe9\x51\x51\x7f\x79\xf8\x17\x2f\xf6\xf7\xea\x25\x58\x39\xaf\x57\x1e\xaf\x38\x87\x58\x32\xe4\xf7\x0f\x58\xde\xa9\x9c\xcd\xa6\xcb\xfd\xfb\x06\x8f\x0f\xe4\x40\x47\x23\x39\xd0\x3f\x54\xf0\x70\xd8\xaf\x83\xc7\xca\xe0\xe1\x54\xf4\x3a\x78\xfc\x47\x82\x87\x53\xfd\x8f\x19\x3c\x56\x8d\xf7\xa3\x05\x8f\xe9\x72\x7f\xf5\xe0\x11\xaa\x74\x63\xf5\xa2\xd6\x9d\x71\x5c\xf3\xed\xfa\x33\x60\x1b\xeb\x6c\x9c\x0b\x9a\x61\x14\x47\x1f\xff\x36\xfd\x64\xe3\x37\x32\x1e\x34\xa1\xf1\x7f\x5b\xbd\x13\x1d\xa7\xd5\x55\x18\xa6\xd8\x8b\xab\xa7\xd2\x3d\x7f\x28\x37\x3b\xa1\x3e\x51\xec\x72\x41\x86\xbe\x92\xb6\xd5\xa7\x23\x2e\x2e\xbb\xd0\x78\x96\xa3\x84\x23\x2a\x4d\xa3\x09\x86\x4a\xd3\x32\xa8\x79\xbf\x76\x7a\xe1\x78\x6d\x1e\xec\x44\x69\x86\xba\x95\x29\x21\x68\x6e\xb0\x0b\xe5\xd3\xbc\xc2\xcf\x39\xb3\xc3\x2e\x6c\x75\x3a\xff\xbf\x1c\xba\x39\x7b\x1d\x56\x9e\x59\xed\x90\x5d\xe8\xd4\x23\xdd\xcd\xda\x3a\x51\xd6\xaa\x51\x17\xb6\xf2\x0b\x30\x4a\x70\x06\x6f\x32\xc6\xe6\x25\x76\x25\x67\x8b\x0a\x3e\x90\x5d\x10\xd8\x5f\x38\xd4\x3a\x43\x6d\x79\x46\x45\x49\x61\x55\x3e\x4f\x90\x53\xc6\xb8\x1c\x74\x61\xeb\x51\x7e\xb1\x2e\x72\x75\x1a\xba\x2b\xed\xb0\x95\xf9\xda\x13\xcf\x50\x2e\x7a\xd2\x09\xcd\x4e\x07\x5a\x8d\x25\x6b\xf9\x13\xd8\x2e\xbc\xd9\xdf\x76\x7f\x4b\xb4\xb2\xc0\x3e\x44\x3e\x18\xda\x2e\x3c\xea\x54\xc5\x9b\x7a\x54\x70\xa2\x5e\x1c\x6e\x47\x1e\xf4\x9c\x1b\x15\x0e\xc6\xf8\x19\xf8\xfe\x84\xf4\x85\xa2\xb6\x98\x04\x01\xce\x12\x52\x29\x9e\xc8\xcc\x09\x7b\x6e\x4b\x82\x11\xda\xa1\x62\x09\x71\xfb\x1f\x81\x70\x0a\x94\x90\x6a\x49\x4a\x40\xc9\xa3\xf1\xc9\x88\xdb\x84\x14\x0b\xa2\x5a\x46\x2f\xa9\xd6\x1b\x95\xdb\x88\x72\x3b\x68\x2e\xad\xed\x1b\xae\xe0\x58\x83\x8c\xe7\x6b\x10\x4d\x4f\x9e\xee\x22\x2c\x2b\x9d\x75\xe4\x2b\x2b\x9f\xe9\xd6\x56\xd1\xa4\xfb\x5c\x5d\x41\x3b\x33\xba\xff\x3e\x47\xc1\xe0\x7a\x3e\xd0\xf5\x8c\x8f\xa6\xe0\xa3\x59\xf5\x9e\x26\xd8\xa7\xda\x90\xde\x0a\x42\x3d\x95\x5b\xe7\x53\x39\x08\x7a\x82\x22\x21\x93\x3f\xdd\x7c\x39\xf9\x7a\xf2\xed\xcd\xe7\x93\x7f\xdc\x7c\x31\xf9\x16\x6e\x7e\x3f\xf9\x76\xf2\xaf\x9b\x3f\x04\xb4\x1a\x88\x12\x86\x2b\x99\x4e\xfe\x7a\xf3\xc7\xc9\xd7\x93\x97\x93\x7f\xc2\xe4\xef\x93\x97\x93\xef\x26\xdf\xf4\xe2\xa2\xef\xf6\xd8\x71\x39\xf8\x3a\x72\xfd\xed\xe6\x8b\x9b\xcf\x27\x5f\x4d\xbe\xbb\xf9\x32\x88\x32\xbb\xe9\x49\xef\x87\xf4\xe7\xc9\xcb\x9b\xcf\x27\x2f\x27\xdf\x4c\xbe\x2a\x90\xc2\x4d\xcf\xfd\x60\xde\x3d\x3a\x08\xdc\xfe\xb6\xea\x9e\x22\xfc\x25\xe8\xa6\x9c\x4a\xb8\x2f\x5b\x8a\xd1\x8b\x83\x89\xd3\x15\x76\x77\xae\x56\x2c\x48\xf7\x54\x2f\x84\xdb\xa8\xbc\x83\x25\x24\xe7\x72\x40\xd2\xe7\xfb\x07\x3f\x5f\x61\xa0\x79\x16\x17\x19\x48\xfa\xe4\xf1\xbb\x7b\x6b\xb3\x30\xa7\x9a\xbd\x83\x23\x88\xfc\x4d\xa8\xd2\xe0\xbe\x5b\x1a\x3f\x53\xe2\x0c\xf5\xc6\xda\x40\x56\xd3\x0c\xb5\x1a\xbb\x20\x73\x3c\x7d\xae\x67\x5f\x47\x5d\xc5\x31\xbd\x57\x58\x79\x64\x7f\x97\x0c\x86\xcb\x81\x40\x92\x32\x25\x1b\xb6\x38\xe5\x58\x7b\x02\x8f\x46\x5c\x92\xd4\xfd\x5f\x9b\x65\xab\xe3\x79\xfc\xd7\xda\x4c\x0f\x03\xd3\xc3\x7b\x31\x6d\x0d\xd5\x58\x93\xd4\x7f\xad\x3f\x52\x60\x7a\x78\x2f\xa6\x77\x02\xd3\x3b\xf7\x62\xda\xda\x2e\xe4\xdb\xbe\x1f\x1b\xa3\x97\x24\x75\xff\xd7\x67\x39\x47\x3c\x25\xa9\xff\x7a\x75\xe7\x2a\x43\x7f\x70\xaf\xe9\xdb\xdd\x4e\x4e\xcd\x29\x49\xdd\xff\xb5\x05\x1e\x21\x35\x63\xed\xf7\x12\x92\x56\x5e\xee\x25\xbc\x2f\xb1\xc0\x9f\xd1\x11\x97\xaa\x90\x32\xa6\x94\xfb\xd2\x6c\xa7\x2f\x5e\x4b\x75\x11\xf0\x89\xeb\x50\x09\x86\x3a\x21\xbb\xae\xd7\x15\x42\x15\x49\x0c\x59\x73\x34\x9e\x87\x61\xdc\xf7\xd4\xf0\x3f\x6d\x77\xda\x9d\xf6\xe2\x38\xfb\xcf\x57\x81\x1a\x9f\x4f\x4c\x41\xf6\x14\x70\xfb\x46\x35\x37\x89\x5d\x72\x52\xe4\x36\x31\xe3\x67\x4b\xd3\x1c\xed\x32\xa6\x9d\x59\x8c\xbe\x67\x9e\xb3\x70\x54\xb8\x34\xd7\xf1\xe7\x95\x4b\xb3\x03\xa7\x9d\x57\x4b\x0c\x96\x69\xda\xfd\x0f\x93\x0a\x4f\x85\xa2\x16\x94\xec\xa4\xf2\x0c\xf7\xd0\xf5\xbb\x8c\x81\xe3\x5b\x64\xf1\x37\xa4\xa5\x16\x5d\xb9\x47\xd2\xab\xab\xb6\xa3\x74\x35\xe5\xf5\x75\x71\x33\xfa\x3d\x4d\x54\x39\x36\xb8\x8f\x89\x2a\x07\x32\x4b\x4d\xe4\x4f\x85\xfe\xeb\x4c\xe4\xa4\x7a\x15\x13\x39\xbe\xa5\x26\x2a\x2b\x72\x6f\x22\x47\xb9\xb6\x89\xc2\xf3\x50\x97\x26\xca\x04\x52\xdd\x85\x13\x65\x87\x3b\xa4\xec\x0e\x25\x65\x28\xcb\x12\xd2\x29\x73\xb9\xe2\x57\x1a\x15\x7c\xab\x17\x24\xb4\xc3\x74\x8f\xba\x4d\xdf\x0e\x6f\xf7\xec\x86\xdf\x0f\xc4\x8f\x2f\x30\x1b\x5b\xa5\x97\x50\x85\x71\xea\x3b\x0f\xfd\xe5\xba\x99\xef\xec\xc5\xa5\x1c\xbd\xd8\x8b\x7e\xdb\x13\x73\x65\xb8\x73\xa7\x2e\xf4\xf9\x05\xb2\x1d\x28\x8b\xcd\xce\x4e\x70\x4f\xff\x34\x2d\x0f\x5d\x75\xe8\x2b\xf2\x2e\xfc\xcc\x55\xa3\xb3\x0a\xbc\x3a\x7b\x0a\x43\x8d\xfd\x84\xc4\xe5\x2f\x73\xbc\x9e\xca\x97\xb4\xfa\xdb\x9f\x5e\x4c\xe7\xcc\xd0\x8b\x43\xed\xe6\x8a\x39\x3b\x12\xe9\xbf\x03\x00\x00\xff\xff\xfd\xf7\xd0\x45\xf9\x26\x00\x00")
+var _dashboardHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x3b\x4d\x6f\xe3\x48\x76\x77\xff\x8a\x37\x1c\x64\x49\xa5\x65\xd2\xee\xde\x9e\x20\xb2\xa4\xd9\x5e\x77\x4f\xba\x83\x9e\x6e\xa7\xed\xc9\x2e\xd2\x69\x18\x65\xb2\x24\xd5\x9a\xaa\xe2\x56\x95\x6c\x2b\x6e\x01\x83\x39\xe4\xb2\x41\x0e\xc9\x21\x87\xe4\x92\x7f\xb0\x0b\xec\x20\x9b\x04\x3b\xbf\x41\xfe\x47\xc1\xab\x2a\x52\x24\x45\xea\x63\x66\x12\x04\x12\x6c\xb2\xea\x7d\xd5\xfb\xaa\x57\x8f\x54\x7f\xa2\xa7\xe9\xf0\xe0\xa0\x3f\xa1\x24\x19\x1e\x00\x00\xf4\x35\xd3\x29\x1d\x9e\x0a\xae\xa5\x48\x21\xa6\x5c\x53\xd9\x8f\xec\xa8\x85\x50\xb1\x64\x99\xb6\xe0\xf8\xb9\x21\x12\x94\x88\xaf\xa9\x86\x01\x70\x7a\x0b\xbf\xa0\x57\xe7\xe6\x3e\xf0\x6e\x55\x2f\x8a\x3c\x78\x04\xa9\x88\x89\x66\x82\x87\x13\xa1\x34\x3c\x02\x2f\x52\xb3\xab\x48\x13\x75\xad\xa2\x44\x70\xda\xbd\xbf\x0f\xbf\xf8\xab\xe7\x6f\x16\x0b\xaf\x73\x72\x50\xd0\xb6\x74\x43\xc1\xa7\x54\x29\x32\xa6\x30\x80\xd1\x8c\xc7\x48\x09\x02\x37\xd6\x81\xfb\x02\x3e\x97\x87\xde\x50\x8e\xe2\xfc\xe5\xf9\xdb\x37\x61\x46\xa4\xa2\x39\x74\x98\x10\x4d\x3a\x27\x15\x8c\x58\x70\x25\x52\x1a\xa6\x62\x1c\x18\xcc\xb2\x08\xf8\x61\x23\xb0\x13\x21\xb1\xbc\x07\x03\xf0\x12\xaa\x34\xe3\x66\x55\xca\xab\x0b\x81\x1f\x92\x24\x6f\x33\x33\x1d\x78\x7f\x27\x78\xa2\xbc\x2e\x98\x8b\xde\x6c\xc6\x92\x9e\xd7\x05\x4b\x13\x87\x54\x67\x23\x3a\x51\x7c\x85\x4d\x14\x5f\x21\xe3\xcc\x66\xdc\x58\xcc\xb8\x96\x8c\xae\x08\xd8\x91\xf9\x8a\x48\x01\xb2\x85\x12\xd3\x15\x32\x4c\x97\x69\x30\xdd\x46\xe0\x35\xb9\xa2\x69\x4e\xc5\x72\x4c\x71\xa8\x01\x5a\x4d\xc4\xed\x97\x7c\x2c\x73\xb8\x29\x5e\x57\xc1\x16\x40\x53\x45\x9b\x6d\x22\x78\xca\x38\xf5\xe0\xe3\x47\x58\x9f\x1b\x8d\xcc\x64\x93\xa9\xd0\x67\x90\x15\x0c\x20\x11\xf1\x6c\x8a\xac\xc7\x54\xbf\x48\x29\x5e\xfe\x7c\xfe\x2a\x09\x3c\x9c\x3f\x44\x5f\x76\x92\x11\x4e\xc6\x54\xd6\x5c\x29\xf7\x16\x04\x6e\x62\x84\x1f\x9c\x0b\xe3\x94\x28\xf5\x86\x4c\xd1\xa5\xcb\xa2\x9e\xb4\xa3\x98\x20\xac\x81\x63\x28\x81\x62\x3c\xa6\x80\xa2\x61\xf8\x3d\x27\x9a\x3a\xcd\xcc\xb2\x84\x68\x9a\xc0\x9f\xc2\xf1\xd1\xd1\x51\x27\xd4\xe2\xb5\x88\x49\x4a\xcf\xb5\x64\x7c\x1c\x34\xc8\xbe\xd8\x59\xd5\x8e\x76\xa3\xe7\xaf\x10\x6e\xa8\x54\x28\xe6\x27\x03\xf0\xee\xef\xc3\xbf\xb6\xb7\x8b\x85\x07\x3f\xf9\x09\x54\x41\x86\x35\x88\x36\xf5\xa1\xad\x72\x9c\x0d\xe6\x72\x20\x5e\xc3\x22\xf1\xe3\xa6\x43\xa5\xe7\x29\x0d\x63\x91\x0a\x09\x03\xf0\x24\x4d\xbc\x8d\xf0\x8c\x73\x2a\x2f\xe8\x1d\x66\x17\xef\xf4\x14\x9c\x1a\xba\x20\x69\x2a\x48\x02\x19\x19\x53\x6f\x37\xb5\xae\x2f\x0f\x97\xa6\xc9\x55\x4a\x37\x2d\x2c\x16\xd3\x29\xc1\x6c\x52\xcf\x52\x39\x05\x29\x6e\x61\x60\xe9\x84\x8c\x2b\x2a\xf5\x3b\x71\x1b\x1c\xb7\x81\xc7\x34\x4d\x8f\x61\x00\x52\xdc\x3a\xf0\x53\x9a\xa6\xc1\x51\xe7\xa4\x15\xfc\xf1\x3a\xf8\xf1\x06\xf0\x27\xeb\xe0\x8f\x37\x80\xff\x74\x1d\xfc\x49\x9b\xf0\x49\xbe\xe9\xec\xe1\xf5\x6b\x84\xa2\x08\x6e\x48\x3a\xa3\x0a\x62\x31\xa5\x30\x92\x62\x0a\x26\x29\x03\xe1\x09\x08\x3d\xa1\x12\x66\x8a\x4a\xd5\x05\x3d\xa1\x73\x20\x92\x82\xa2\x1a\x04\x4f\xe7\x40\x14\x68\x7a\xa7\xd7\x88\xe2\xc2\x8f\x43\x9c\xc2\xcd\xd4\x6e\x47\x89\x5e\x5f\x35\xc9\x32\xca\x13\xf4\xa8\x00\x51\x1e\x77\xc1\x3f\x3f\x7b\xf6\xc6\xef\x82\x8f\x3b\x8c\x5f\xe4\x57\x49\x89\x16\x12\x1e\x81\x1f\xf9\x45\x16\x42\x31\x77\x48\x49\x35\x26\x4f\x4a\x4c\x6c\x48\x17\x6c\xec\x6d\x03\x09\x14\xee\x49\x68\x09\x9d\x4e\x58\x9a\x04\x85\x87\x1a\xd1\x28\x52\x7f\x23\x12\x1a\xf8\xe0\x77\xf6\x93\x21\x23\x92\x4c\x0b\x11\xcc\xdd\xff\xb1\x04\x92\x66\x94\xac\x94\x6d\x6f\x3b\x27\x1b\x32\x9b\xd2\x44\xcf\x54\x5b\x92\xaa\xb1\xfa\x69\x89\x95\x45\x2c\x58\x39\x3a\xeb\xac\x5a\xb3\x44\x0b\xfd\xb3\x77\x2f\x50\x97\x7e\x17\x46\x42\x4e\x89\x7e\x47\xd5\x2c\xd5\xae\xa0\x69\x58\xca\x62\x3d\xa2\xa2\xc8\x04\x1e\x49\x92\x17\x88\xf5\x9a\x29\x4d\x39\x95\x81\x17\xa7\x2c\xbe\xf6\xba\xa5\xe2\xcb\x92\x6d\x90\x2e\x8a\xcc\x3f\x67\x11\xa2\xae\x03\x3d\x61\x2a\xfc\xf5\x8c\xca\xf9\x39\x4d\x69\xac\x85\x0c\xfc\xd0\xf8\x76\xc7\xa6\xd3\x97\x17\x5f\xbe\xee\x42\x23\x98\xf3\xce\xed\x80\xc6\x69\x76\x80\x73\x86\x2e\x01\x36\xa8\x26\x8a\x60\xd1\x85\x11\x49\x15\x6d\x4a\x3c\xf2\x56\x15\x09\x76\x95\x9d\xd5\xcf\xe7\x17\x64\x8c\x5b\x7a\xe0\x5f\xbc\xf3\x1b\xc8\xe2\xb6\x28\x6f\x55\x98\x52\x3e\xd6\x13\x18\xc2\xd3\xe3\x36\xff\xb1\xd9\x3b\xa1\x29\xd5\x14\xb3\x77\x09\xed\x10\x8e\xb7\x6f\xdc\xc5\xdd\xa2\x24\x7f\x14\x55\x5c\xc3\x14\x5b\x0a\x94\x96\xb3\x58\xcf\x24\x4d\x40\x9a\x71\x05\x62\x94\xe7\xbf\x5a\x72\x2b\xec\xdf\xe0\x62\xb5\x95\x60\x76\xb6\xf4\xaa\xc2\x6a\x39\x6f\x58\xb3\x85\xac\x56\xec\x79\x28\xe2\x4c\x6d\xc5\x0b\x88\x89\x8e\x27\x10\xd0\x26\x05\x4a\xaa\x67\x92\x17\xa1\xbc\x2e\xc3\xe2\xa0\x39\xa6\x4b\xe5\x4d\xd2\x52\xd4\xe3\xb2\x08\x57\xb7\x54\xa2\x0f\x04\x56\xba\x30\x1f\xf9\xf8\x11\xde\x7f\xe8\x84\x53\x92\x05\xab\x50\x21\x1d\xb8\xcf\x65\x22\xa1\x9e\x67\x14\x93\x38\x60\x12\x27\xa1\xd9\x78\x4e\x60\xd1\x39\x69\x5b\x86\x63\xe1\x0e\x4e\x16\x2f\xe7\x6b\x88\x7d\xfc\x08\xbe\xdf\x31\x73\x3f\xc3\x49\x37\x27\xa9\x12\xe9\x0d\x35\x3b\x06\x04\xa5\x09\x2d\x09\x57\x99\x90\x78\x0e\xf3\x3b\x3d\x24\xb8\xc6\x1b\xbf\x39\x13\x2a\xa5\x90\x58\x52\xbb\x7b\x19\x8b\x84\x96\x84\x48\xb8\x52\x34\x86\xcf\xc1\xef\x82\xbb\x2e\x71\x73\x23\x3d\x2b\x63\x23\x23\xfc\x06\x4e\x85\xb9\x97\x7f\x0e\xfe\xdf\x72\x24\x93\x8f\xff\x4a\x30\x1e\xe0\x58\xc7\xd2\xaa\x7b\xc4\x76\x93\xea\x38\x6b\x34\x69\x55\xcf\xb1\xe0\x9c\xc6\x58\x35\x7f\xbe\x06\x89\x5f\x7f\x05\xa0\x45\x79\xa1\x24\x49\x24\x55\x0a\x95\x0a\x8c\x57\xcc\xe4\x50\x2e\xa7\xc6\x43\x4c\x49\xf2\x05\xbb\xa3\x49\x70\x6c\xcd\x36\x55\x3e\xf4\x9a\xb9\x71\xa1\xa1\x8d\x63\xee\x12\xbd\xd2\x58\x6e\xd7\x5e\x19\xd0\x58\x70\x7f\x7d\xa5\xcd\x21\x80\xc0\x65\xc2\x4d\x30\x25\xbd\xfa\x3a\x55\x3f\x96\xd4\xeb\x92\xe7\x31\x99\x52\x32\x2a\x05\x64\x3c\x21\x8c\xbb\x70\x7c\x7f\xf4\x01\xaf\xee\x17\x27\x07\x2d\x22\x3a\xa4\x4c\x0a\x2d\x62\x91\x16\x61\x96\x13\x63\xd9\x84\xca\x4b\x35\x63\x9a\xb6\xb8\xb0\xdf\xad\x18\xfc\x86\x4a\x36\x62\xe8\x43\xe0\xe7\xd7\x3e\xfa\x2d\x9a\x33\x1f\xa8\x2c\xd6\x0c\xce\x2f\x9d\x42\x5b\x99\x30\xa5\x66\x54\x1a\x44\x5c\x71\xe8\xee\x1f\x61\xec\xd1\xbb\x8c\x49\xaa\x72\xe7\x73\x84\x13\x32\x57\x97\x29\x1d\x99\x68\x07\xbc\x6b\x8b\x77\xbf\x0b\xe7\xcf\xde\x58\x5b\x05\x86\x7a\xc2\xd5\x25\x27\x53\x5a\x24\xb6\x58\xf0\x98\x68\x3b\xc9\xb2\x4b\xe7\xf2\xab\x79\x1b\xa4\xd0\x1a\xe9\x7e\x17\xde\x9e\x9e\x9f\x55\x74\x25\x62\x95\x5d\x2a\x4d\xb2\xd4\xea\x2b\xbf\x6c\x82\xd1\x33\xc3\x0a\x53\x89\x53\xa6\x83\xf6\x3b\x7b\xbb\xf7\x44\xeb\xe6\x7c\x80\xee\x34\x22\x0c\x65\x28\x65\x78\x85\x07\x29\xec\x83\xe4\x6b\x1d\xb1\x54\x53\xd9\x92\xe7\x3f\x21\x61\x46\x94\xa2\x09\xa6\xf6\x86\xfd\x60\x53\xc0\x90\x30\x9e\xd0\xf8\xba\xf0\x42\x82\x49\x6c\x46\xac\x5b\x06\xf4\x2e\xb3\xb9\x00\xf5\x43\xc2\xe2\x16\x73\xb9\xbf\xee\xe1\x4d\x1b\x0b\x2e\x50\xb3\x29\xe3\x63\x18\x14\x9b\x82\xbd\x6f\x89\x13\x83\x61\x0f\xbd\x0e\x7e\x4a\xf5\x44\x24\xf5\x48\x99\xc9\x74\x15\xc6\x6d\xfb\x87\xb5\xe3\x25\xee\x22\x1b\xfc\x24\xe1\xce\x13\xad\xa4\xc6\x17\xdb\xf3\x67\x37\x4f\x92\x15\x9c\x8d\x79\xb7\x99\xb1\xa1\x95\x67\xac\x9c\x8e\x4e\x37\xf3\x1e\x31\xa9\x34\x5c\xcd\x35\xad\xa0\x99\xe1\x4b\x1c\xde\x5f\x02\xa1\x49\x5a\x95\x01\x47\x36\x48\xd1\x60\x7b\xe7\x4f\x58\xc1\x95\xcc\x61\xfd\x12\x23\xad\x0b\xf6\xda\x64\xa6\xae\xf3\x79\xc3\xd3\x5e\x96\xf6\x62\x9b\xab\xdc\xb0\x8d\xf2\x13\xd8\x69\x2b\xde\x58\x8c\x95\xce\x20\x45\x7c\xac\x5a\x80\x4c\xd3\xe9\xda\x09\x2b\x6f\xd5\xa9\x4d\x3d\x12\x03\x50\x6f\xfd\x98\xc1\x55\xdd\x0f\x03\xf0\x6b\x4a\x33\x0c\x61\xe0\xfe\x9b\x38\xaf\x02\x8c\x84\x84\x80\xc1\x00\x8e\x4e\x80\x41\xdf\x02\x3a\x35\x9d\xc0\xa3\x47\x6c\xd7\xe6\xa2\x3d\x1e\x39\x99\x03\x7b\x36\xac\x89\x5b\xf4\xfd\x18\xe6\xa1\x55\xfb\xd1\xf0\x7c\xcf\x3e\x9c\x1c\x6c\x6d\x2c\xe6\xfd\xd0\x16\xd0\x6a\x73\x22\xa7\x6b\x5a\x8a\x2d\x28\xaa\x72\xfc\x46\x22\xad\xb6\x6f\x32\x6d\xa9\x99\x8c\xe7\xbf\x57\xcf\xbb\x90\x49\x3a\x62\x77\x5d\x68\xb4\x75\x9b\x7d\x2d\x72\x67\xa3\x2d\xbf\x97\xa9\x44\xa6\x37\x58\xe9\xed\xd9\xc5\xab\xb7\x8d\x76\x12\x99\x6e\xd1\x66\x33\xac\xa9\xfa\x61\xe0\x56\xbf\xd1\xa8\xdb\x74\x50\x36\x88\xc8\xd6\x0e\x4a\xdb\xec\x51\xe9\xcd\xbb\xae\x7c\x4d\x31\xe8\xbf\x79\x64\x34\x06\x04\x02\x5c\xd3\x39\x16\x1e\x96\x02\x66\xca\xfb\x45\x93\x82\x0b\xf8\x92\x65\x2c\xce\xfb\x6b\x3a\xff\xb0\xcd\x3e\x45\x90\x86\xd9\x4c\x4d\x82\x12\xe6\x7b\xf6\x01\x3e\x37\x52\x3c\x02\x6f\x80\x71\x52\x9b\xec\xe1\x64\x83\xe9\x16\x07\xed\x77\xe5\x87\x1f\x96\x1c\x3e\x43\xf1\x9c\xb7\x86\x4a\x48\x1d\x94\xb3\x5f\xa3\x8e\x33\x76\x26\x94\x0e\x66\x32\xed\x82\x16\xd7\x94\x63\xda\x9d\x63\xb3\xb8\x0b\x82\x9f\xcf\xe2\x98\x2a\x85\x97\x2f\x9a\x8a\x69\xd4\xed\xdd\x44\xba\x2e\xe7\x2f\xbf\x7c\xfd\x52\xeb\xec\x1d\xfd\xf5\x8c\x2a\x1d\xd4\x9b\x13\x77\x13\x19\x8a\x8c\xf2\xc0\x3f\x7b\x7b\x7e\xe1\x77\x61\x26\xd3\xda\x92\x11\x44\x51\xed\x28\xbc\xa4\x24\xa1\x32\xf0\x9d\xdf\x1e\x5e\xcc\x33\x8a\xa5\x2c\xc9\xb2\x94\xd9\x27\x76\xd1\xaf\x14\x36\x60\x76\xa1\xf2\xcb\x43\x37\x40\x93\xc3\x5f\x30\x3d\x41\x4a\x77\xd3\x14\x0b\x2d\x69\x27\x9a\xe8\xdc\x32\x3d\x39\x95\x34\xa1\x5c\x33\x92\xa2\x93\x69\x39\xa3\xbb\xf1\x3b\x3d\x7f\xf7\xc5\xe1\x05\x2a\xd5\x77\xca\x6d\x60\x20\x38\x2a\xbb\xf2\xe8\xb0\xc9\xb7\x50\xd3\xf8\x70\x10\x06\x8d\x25\x50\x73\xdf\x02\x3f\x0e\xa9\xd4\xb9\x40\x71\x25\x55\x99\xe0\xca\xb4\x45\x6b\x42\x6d\xef\x60\xe0\x87\xa4\x54\xea\xc0\x77\x6b\xce\xf7\x5e\x80\x77\x66\x43\xa5\x09\xb8\x7a\x58\x8c\xcc\xde\x8c\x3c\x5b\x7b\x89\xab\x8d\xb8\x41\x92\xb5\x11\x2c\x97\x71\x51\x8e\x9e\xa9\x96\xc5\x75\x63\xad\x8c\xdf\xc2\x89\x83\x86\x67\xab\xa5\x4e\x66\x41\x76\xe3\xa9\xd1\xc5\x41\x19\xb2\x95\xe2\xff\xbe\xe2\x6a\xd9\xa1\x0a\x60\x54\x4e\x79\x12\x18\xd3\x2b\xf3\x78\x81\x8d\xe6\x81\x0b\xef\x6d\x99\xa1\xd4\x22\xc5\x64\xde\x05\x7c\x1c\x8e\x11\x68\xaf\x5e\x65\xf8\x50\x09\xbb\x95\x76\x6c\x4a\x18\xd7\xc5\xac\x79\x64\x6b\x2f\xcf\xb0\xf5\xd9\x98\xb5\x71\x16\x06\xb5\x09\xfc\x22\xbf\x9e\xf9\xdb\x5d\x9b\xb3\x47\xa4\xde\x4a\x9a\x35\x08\xd3\x6b\xed\xe5\x42\xae\x4d\x5b\xa1\x7b\x65\xe1\xd7\x60\x70\x21\xbd\xd5\x92\xd6\xe6\xcd\xf2\x7a\x60\x1a\x81\xaf\xb8\x0e\x4a\x4b\x3e\x3e\xea\xe0\xfe\x72\x5c\xc1\xa9\x05\x2c\xba\x5a\xbb\x6a\x36\x87\x33\xe2\x61\x1c\x93\xa9\xaa\x46\x75\x89\xe0\x0f\x88\x67\x4b\xd9\xd6\xd3\x34\xcc\xdf\x61\xd8\x14\xb2\xb6\x11\xdd\xc0\xf2\xa0\x5d\xf4\x86\x82\xa8\xe4\x82\xf8\xcd\xf7\x25\x3f\x22\x19\x8b\x6e\x8e\xed\xcb\x18\x7e\x77\x55\x70\xd4\x5a\xe7\x09\xbb\xf9\x14\x61\x2e\xad\xdf\x02\xe3\xd9\x4c\xbf\xc7\xf6\xc0\xc0\x64\xde\x0f\x7e\xc7\x76\x33\xad\x57\x96\x1f\x16\x60\x2c\x77\xe0\x7e\x51\x1e\x6b\x4d\x02\x56\x4f\x66\xba\x45\x3d\x8b\xfa\xbe\xd7\xac\xa7\xf6\x98\xfb\x1b\xc1\x93\x00\x3b\xdc\x58\x27\xd7\x65\x58\x53\x0c\x02\x6e\x53\x0c\xc2\xec\xa0\x98\xf5\xd5\x22\x58\x0f\x72\x59\x2a\xd3\x8b\x06\x15\xae\xe1\xb7\x09\xf5\x29\x92\xfc\x6a\xc6\x12\xbf\x53\x79\x66\x8d\xa6\x08\xf1\xfd\x13\x3c\x36\x76\x41\xd1\x58\x52\x0d\x01\x1e\xba\x38\x08\x1e\x53\xdb\x10\xb6\x70\x6e\xb6\xf9\xa0\xea\x32\x34\x97\x22\x4d\x2f\x8d\x07\xd8\x33\xa5\x1d\x41\x99\xec\x8e\x5c\xa2\x57\x01\x36\x47\xc7\x93\xf6\x15\xb7\x3a\xc8\xbe\x4b\xae\xb8\xd2\x8f\xec\x49\x78\x50\x35\xe7\xa0\x9d\x3c\x09\x01\xb7\x79\x12\xc2\xfc\x00\x4f\xca\x65\x69\xd7\xeb\xde\x9e\x84\x24\x7f\x1c\x4f\x3a\x69\x97\x6a\x7f\x6b\xb7\x88\xf5\xa3\x5a\x3b\x8a\x4a\x4f\x5a\xf1\xb8\xa4\x80\xda\xd3\x20\x60\xd9\x6a\x1e\x8e\x81\x16\xb8\x4f\x51\xae\xbb\xf6\x9e\x29\xe0\x14\x1f\xbc\x98\xdd\x2b\xc1\x87\x68\xd8\x6f\x28\x88\x16\x8b\x2e\x3d\xc4\x2d\x08\x90\x71\x17\x8a\x23\xbc\x25\x58\xd7\x0a\x16\xaa\x34\x6d\x3f\xa7\x6a\x32\xae\x25\x4c\xdc\x0e\x0b\xa2\x4d\x4a\xa6\x69\xa5\x6f\x50\x5c\x9f\x1c\xb4\x17\x43\x34\xad\x1d\x79\xf1\x0e\x6b\x45\x3e\x4b\x53\x4c\x05\xd8\x56\x72\xef\x5c\xe8\xf5\x22\xd8\xae\xb8\x72\x7c\xa5\xf5\xc3\x8a\xb3\x0f\x4d\x57\xc3\x56\x86\x7e\x54\x7e\x0b\xb1\x6f\x5e\xe2\x59\xbd\x91\x78\x25\x92\xfa\xe6\x3e\x12\x5c\x1f\x8e\xc8\x94\xa5\xf3\x1e\xf8\x6f\x33\xca\xe1\x9c\x70\x8c\x45\x45\xb8\x3a\x54\xd8\x7d\x2f\x33\x29\x2e\x8d\x9b\x29\x08\x6d\x27\xa5\x46\xd5\xbc\x36\xd4\x83\xb1\xa4\x94\x6f\xc6\x1e\x8d\x36\xa1\x93\xf9\x0a\x1b\x3f\xa8\xae\xc3\x84\xc6\x42\x9a\x23\x58\x0f\x10\xf9\x50\x4f\xa4\x98\x8d\x27\x8d\x8c\xcc\xf3\xe2\x1a\xf9\x2b\x21\x13\x2a\x0f\x63\x91\xa6\x24\x53\xb4\x07\xf9\x55\x95\xd9\x2d\x4b\xf4\xa4\x87\xef\xca\xfc\x49\x3b\xe9\x55\x85\xa6\x27\xa5\xeb\xa4\x91\x65\x0f\x8e\x9a\x29\x6d\x47\x3d\xbc\x12\x5a\x8b\x69\x0f\x8e\xb3\x3b\x50\x22\x65\x09\x7c\x9a\x24\x49\x83\x7a\x48\xca\xc6\xbc\x07\xf8\x64\xa3\x3a\x7b\x83\x7d\xfa\x98\xa4\x39\x84\x16\x59\x15\x20\x23\x49\xc2\xf8\xb8\x07\xc7\x4f\xb3\xbb\x5d\x29\x97\x97\x21\x7b\x5c\x4f\x0e\x63\xeb\xb3\x37\x94\xd7\x23\xea\x8a\xc4\xd7\x63\x29\x66\x3c\x39\x74\x26\xfe\x74\xf4\x18\x3f\xcd\xe4\x26\x35\xf4\x09\x65\xe3\x89\xee\xc1\xd3\xa3\xec\xae\xc1\xf1\xad\xaf\xf7\x23\xfb\xd2\xee\x41\x1f\xbd\xdd\xc5\x41\xc2\x6e\xc0\xc4\xc2\xc0\x1b\xa5\x02\x6b\x70\xb3\x08\x0f\x58\x32\xf0\x4a\xb5\x9b\xb7\x8a\x95\x3e\x3e\xc9\x07\xdb\xcd\x1f\x78\xd8\x36\xf0\xdc\x09\x60\xe0\x99\x92\x30\x72\x28\xa6\x57\x71\x35\x65\x7a\xe0\xb9\xb0\x2c\x9f\x5f\x5a\x9a\x54\xbe\x72\xf9\x3a\xdf\xb8\xb0\x72\x6f\x05\x2e\xbd\x51\xbb\xda\xe8\x5a\xa1\xf1\x08\xb1\x03\x18\xcb\x76\x00\x2a\xde\x0a\xd9\x06\x98\x9f\x58\x76\x00\x2d\x8e\x2c\x3b\xc0\x9a\x9a\x5d\xe5\x80\x9d\x92\x7d\xd0\xe1\xee\xef\x21\x8c\x95\x1c\x7d\xc1\x68\x9a\xc0\xa2\x9a\x86\xfb\x56\xc5\x66\xfb\x1f\x94\x5f\x4a\xb6\x56\x2f\x0f\x54\xa9\xe2\xa7\x2f\x32\x8d\x9e\x9a\x81\x69\x6f\x0d\xbc\xe5\x3f\x3d\xfc\x66\xf9\xbb\xe5\xb7\x0f\x5f\x2f\xff\xf0\xf0\xcd\xf2\x5b\x78\xf8\xfb\xe5\xb7\xcb\xff\x7e\xf8\x07\x4b\xad\x81\x44\x4e\x06\x77\x71\xa3\x3d\x84\x5a\xfe\xeb\xc3\x3f\x2e\x7f\xb7\xfc\x6e\xf9\x9f\xb0\xfc\x8f\xe5\x77\xcb\x3f\x2e\x7f\xdf\x8f\x2c\x50\x83\x10\x51\x2e\xc5\x2e\x02\xfe\xfb\xc3\x37\x0f\x5f\x2f\x7f\xbb\xfc\xe3\xc3\x6f\xac\x4c\xab\xf7\x9b\x87\xfb\x51\xfa\xe7\xe5\x77\x0f\x5f\x2f\xbf\x5b\xfe\x7e\xf9\x5b\x47\x89\xe9\xfd\xc9\x3c\x3b\x7f\x63\xb1\xf1\x4d\xec\x7d\x45\xf8\xb7\xe5\xb7\x0f\xdf\x2c\xff\x6b\xf9\x07\x4b\xc2\xf5\x18\xf7\x24\xf2\x2f\x56\xc1\xb9\x3e\xb0\xf0\xdf\x40\xa3\x1f\x59\x87\xa9\x8d\x9a\x52\x13\xd0\xb5\x07\x1e\x66\x41\xcf\x39\x54\x1e\xc0\x96\xf6\xea\x2e\x37\x35\x64\x29\x89\xe9\x44\xa4\x09\x95\xb9\x2d\xe6\x83\xe7\x2f\xba\x9c\xea\xc1\x54\x5c\xb1\x94\x76\x3f\x61\xd9\xcd\x67\xde\x70\x83\xdb\x22\x5f\x97\xa5\xf0\xaa\x79\xd5\x25\x0f\xcb\x18\x1f\x7b\xc3\xb3\x57\x6f\xfe\x62\x83\x5b\x55\x51\x30\x5d\x7a\xc3\x97\x2f\x9e\x3d\xdf\x19\x05\xdf\x01\x1a\x3e\x7f\x73\x0e\x01\xbe\xb3\xd0\x05\xfc\xfb\xb3\xe2\xb5\x1a\x21\xed\x00\xfe\xb4\x21\xed\xec\x4c\x54\x4b\x12\x53\x29\x66\x9a\x7a\xc3\x8b\xe2\x7a\x77\xf4\x38\xf3\x86\x17\xa7\x67\x56\xa6\x1e\xbe\x38\xb1\x07\x6f\x74\xae\x8b\xd7\x6e\x41\xef\x0d\xf6\x87\xdd\xd1\xb1\x91\xeb\x0d\x5f\x5e\x5c\x9c\x81\x6d\x67\xbb\x2e\x09\x36\xf5\xed\x0e\xd2\x05\x54\xb3\x79\x53\x76\xf5\xc0\xbc\x85\x41\x8b\x23\x56\xfc\xc2\xe6\x65\xeb\x19\xee\x7a\xab\x94\x8a\xf1\x71\x4a\xbd\x61\x22\xb8\xaf\x5d\x13\x6a\xe7\x25\x3e\x9d\x32\xee\x0d\xf1\xef\xce\x28\xc7\x47\x06\xe7\xf8\x68\x1f\xa4\x27\x16\xe9\xc9\x5e\x48\xc7\x13\x31\x93\xde\xd0\xfc\xdb\x9d\x13\x42\x7b\xc3\x27\x7b\x21\x7d\x66\x91\x3e\xdb\x0b\xe9\xf8\xb1\x93\xef\xf1\x7e\x68\x09\x99\x7b\xc3\xe3\x84\xcc\x77\x47\xb9\xa5\xf4\xda\x1b\x9a\x7f\xdf\xdf\xb9\xf2\xbd\xdc\xba\x57\x71\xb7\x95\x3b\x6e\xec\xde\x10\xff\xee\x2c\xf0\x94\x12\x35\x93\x66\xc3\xf7\x86\xa5\x9b\xbd\x84\x6f\x4b\xd1\x45\xa1\xb1\xaa\xf3\xdc\xad\xe3\x7e\x5c\xcb\xd2\xa7\x38\x8b\x2f\x79\x96\x24\x51\xde\x8e\xdc\x58\x66\xd9\xb0\x6c\x45\xff\xf1\x9f\x85\x47\xe1\x51\x58\xe7\xf3\xea\xec\xe6\xa7\x5d\x78\x75\x76\xf3\x59\x9e\x2c\x91\xc4\xae\x8c\x6c\x72\xb1\xcc\xf2\x6b\xc7\xb0\xca\xc7\xb7\xb3\x5d\xa0\xe1\x38\x84\x7b\x0f\x13\xa3\xd7\x03\x8f\xde\x91\x69\x66\x7e\xdc\x31\xc5\xc7\x76\x56\x27\x3d\x78\xba\xf0\x37\x48\xa0\x4c\x8d\x5b\x70\x7a\x2e\x80\xe9\x4f\x4a\x22\xf7\x23\x2c\x98\x5d\xbd\x1d\x25\xec\xa6\xb5\xf4\x96\x58\xc5\x9f\xac\xf6\xe4\x3d\x6b\x6f\x92\xb1\x08\xd1\xb6\xd5\xdf\xa6\x97\xd9\x5a\x58\xa2\x2a\xbf\x5f\x59\xd9\x66\x16\xfc\x6b\x17\x65\xaf\x1a\x4d\xe2\xa1\x54\xb0\xcd\xda\x35\x5d\x3f\x4b\x12\x40\xbc\x3a\x8a\xca\x08\x2f\xb4\x88\x1d\x1f\x6f\x78\x7f\x1f\x22\xe4\x57\x5f\xbd\x7a\xbe\x58\xf4\x23\x84\xf8\xa1\x26\x2a\xf5\xdd\xf6\x31\x11\xa2\x6d\x33\x91\x69\x12\xfe\xbf\x33\x11\x4a\xf5\x7d\x4c\x84\x78\xad\x26\xca\x9b\x72\xc6\x44\x08\xb9\xb3\x89\x0c\xc1\xfe\x44\xe6\x26\x8a\x53\x4a\x64\x0f\xae\x84\x9e\x9c\x78\xf9\x34\x9a\x30\x67\xa3\x2e\xdd\xdb\x2d\xc3\x2f\xed\xef\x5e\x54\xaf\x26\x88\xa9\x82\x71\x64\x58\xe1\x61\x7b\x25\xb6\xdf\x30\xf0\x8e\xf2\x53\x84\xfb\x55\x55\x49\x48\x2d\x57\x37\xf8\xe9\xeb\xc9\x10\x7f\x67\xd4\x8f\xf4\x64\x7d\xe6\x14\x9d\x40\xc8\xe8\xc5\x1d\x8d\x67\x5a\xc8\x16\x28\xfb\xeb\xad\xe6\x49\xfb\x3e\xbd\xaa\x4e\xf6\xa3\x5c\x8e\x7e\x64\x44\x5f\x77\xe7\x4c\x28\x86\x69\xa3\x07\x23\x7c\x35\xed\x04\xf2\x2e\xca\xd1\x89\xf5\x71\xec\xc8\xac\xfa\x1e\xd8\xf6\x30\x1d\xb1\x1e\xfc\x79\x76\x57\xee\x80\x95\xec\xda\x27\x30\x91\x74\x34\xf0\x22\xf7\xd3\x37\xab\xa7\xfc\x66\x58\xfe\xad\x5e\x3f\x22\xb9\x84\x46\xcf\xfd\xc8\x36\x25\x0e\xfa\xd1\x44\x4f\xd3\xe1\xff\x0c\x00\x87\xab\xa1\x11\x69\x3c\x00\x00")
 
 func dashboardHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -136,7 +137,7 @@ func loginHtml() (*asset, error) {
 	return a, nil
 }
 
-var _mngrsHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x58\x4f\x6f\xeb\xb8\x11\xbf\xe7\x53\xb0\x5a\x14\x49\x80\xe8\x8f\x1d\x38\xe9\x2a\xb2\xd0\xd7\xbe\x6d\x9b\xc3\xcb\x3e\x6c\x5e\x7a\x2d\x68\x71\x6c\x11\xa1\x48\x81\xa4\xfd\xec\x04\x06\x1e\xf6\xd0\xcb\x16\x3d\xb4\x87\x1e\x7a\xea\x37\xd8\x05\xf6\xa1\x2d\x8a\xcd\x67\x90\xbf\x51\x41\xfd\x71\x6c\x59\xb2\xe5\x55\x00\x89\x32\x67\x86\xbf\xf9\x71\x66\x38\x51\x10\xeb\x84\x85\x27\x27\x41\x0c\x98\x84\x27\x08\x21\x14\x68\xaa\x19\x84\xef\x16\xe8\x1d\x9f\x48\x15\xb8\xc5\x7b\x31\xa7\xf4\xa2\x1a\x9b\x6b\x24\xc8\x02\x3d\xaf\x5f\xcd\x35\x16\x5c\xdb\x63\x9c\x50\xb6\xf0\xd1\xe9\xd7\x29\x70\x74\x8f\xb9\x3a\xbd\x40\x0a\x73\x65\x2b\x90\x74\x7c\xb3\xd6\x58\x9e\xac\x87\x1a\x8f\x18\xd4\x8c\x8d\x84\x24\x20\xed\x48\x30\x86\x53\x05\x3e\xaa\x46\x37\x5b\x62\x1f\x29\xd1\xb1\x8f\x7a\x9e\xf7\xcb\x76\xd3\x17\xaf\xaf\xf1\xc6\x98\x34\x2e\xe9\x23\xaf\xd9\xd2\x61\x55\x7b\x24\xb4\x16\x89\x8f\x7a\xe9\x1c\x29\xc1\x28\x41\x5f\x10\x42\xb6\x11\x6b\x98\x6b\x1b\x33\x3a\xe1\x3e\x62\x30\xd6\xdb\xb3\x33\x90\x9a\x46\x98\x55\x12\x5a\xa4\xdb\x02\x29\x26\x84\xf2\x89\x8f\x7a\x83\x74\xde\xd5\xf2\xa6\x1b\xd2\xe7\x3a\xb6\xa3\x98\x32\x72\x06\x33\xe0\xe7\x75\x57\x70\xf4\x38\x91\x62\xca\x89\x21\x5f\x48\x1f\x7d\x31\xee\x9b\xbf\x16\x56\x6a\xea\x31\xd0\x49\xac\x7d\x34\xf0\x36\xe1\x6d\x68\x38\x29\x9e\x50\x8e\x35\x15\xbc\xa6\x4a\xa8\x4a\x19\x5e\xf8\xc8\x4e\x94\x3d\x66\x30\x1f\x89\x9a\x87\x6b\x09\x33\xdb\xc8\x8b\x6d\x1c\xdf\xda\x42\x73\x31\xaa\xb4\x9d\x07\xb0\x8f\xb8\xe0\xb5\x18\x2a\x37\x4f\x62\x42\xa7\xca\x47\x9e\xd3\x1f\x48\x48\x0e\x81\xb7\x55\x82\xf0\x45\xdb\x8c\x4a\x71\xdd\xbd\xf5\xce\x95\x0b\x20\xcf\xd9\x5e\x07\x55\x39\xa4\xe8\x13\x18\xb1\x5f\x5d\xef\x0a\x30\xca\xc1\xae\x48\xee\x39\x83\x86\x10\x20\x10\x09\x99\x23\xa9\x7b\xdb\xee\x09\xa3\xfe\x98\x4a\xa5\x8b\xc0\xd8\xe3\x58\x4d\xb0\xc1\xcf\x92\x4e\x2d\xd2\x7c\x33\x36\x79\xdd\xf1\x66\x2b\x6f\xf6\x8b\xef\xc5\xce\x70\x37\xe8\x1b\x72\xfb\x91\x4b\x43\xf0\x11\xd0\xf7\xcb\xb7\x84\x7f\x33\xd4\xc6\xd8\x11\x8a\x16\x1b\x2a\x81\x61\x4d\x67\xd0\x92\x18\x23\x26\xa2\xc7\x96\x8a\x51\xc4\x1b\xf2\x9c\x86\xb0\x4a\xb0\x9c\x50\x5e\x66\x8f\xdd\xab\x97\x96\x5a\xd4\xf5\x6b\x61\x57\x15\x0a\xcf\xbb\x1e\x8d\xc7\x37\x07\xeb\xc9\x8e\x4c\x59\x79\x37\xeb\x26\x40\x1f\xae\x0e\x53\xe8\xc7\x62\x06\xb2\x9d\xc8\x62\xbe\x46\xe7\x93\x4d\x39\x81\xb9\x8f\xfa\x6d\x7e\x0c\xae\x46\x97\x47\xa5\x56\x8b\xab\xf0\x25\x44\xd0\xe8\xed\x5a\xa4\xb3\xa7\x63\x11\x4d\xd5\x1e\x4f\xf3\xf9\x8e\x9e\x8a\xa9\x36\x7b\xba\x53\x29\x47\x62\x6e\xab\x18\x13\xf1\xd1\x47\x5e\xf1\x97\xc7\x32\x92\x93\x11\x3e\xf3\x2e\x50\xaf\x7f\x79\x81\xfa\x83\xc1\x45\x5e\xc6\xce\x3b\xc0\xe6\x42\x9f\xf9\x84\x2a\x73\x12\x93\xf3\xfc\xd5\x59\xbf\xd6\xe0\x46\x53\xa9\x0c\x29\xa9\xa0\x5c\x83\x3c\x68\xbd\x53\xd9\xea\x50\xb3\xb6\xc2\x7f\x87\x92\xd6\x7a\xb6\x9b\x47\xfb\x0b\x5a\x97\x73\xa5\x5e\xce\x8e\xa8\x51\x87\xe0\x1c\x50\x68\xc5\xe3\xe0\xc8\x94\x9c\x26\xe2\xd6\xf1\xd5\x6b\xce\xa4\xdd\x54\xdf\xcd\x91\xc6\xaa\xb1\x9d\x23\x75\x91\x76\xa8\x55\x64\x35\x81\xad\xac\x5d\x45\xd7\x83\xeb\x5a\x5b\x56\x46\x9c\x6d\x5a\x22\xad\x9a\xb2\xbb\x8a\x4d\x3c\xd5\xe2\xe7\x96\xb8\x3d\x49\x6f\xee\x81\x5b\x76\xd9\x81\x5b\x74\xe5\x27\x81\xe9\xb3\xcb\x0e\x9c\xd0\x19\xca\xe7\x87\xd6\x98\x09\xac\xcb\x2e\xcf\x42\x94\x0c\x2d\x8d\xd5\xe3\x9f\x22\x09\x58\x83\xf5\xda\xa5\x07\x63\x21\x13\x94\x80\x8e\x05\x19\x5a\xef\xbf\xbe\xff\x60\x21\xb3\x99\x82\x0f\x2d\xd7\xa8\xb8\xa5\x0a\x12\xfc\x7e\x3a\x4a\xa8\x1e\x5a\x12\xf4\x54\x72\x54\x4c\x7c\xc0\xea\xf1\x8c\x88\x68\x9a\x00\xd7\xce\x04\xf4\x57\x0c\xcc\xf0\x37\x8b\x5b\x72\x76\x4a\x40\xe9\x92\xfb\xd3\x73\x67\x86\xd9\x14\x2e\x50\xab\xb4\x5e\xa4\xd0\x41\x8c\xa6\x1d\x84\x24\xa4\x80\x75\x25\x78\xbe\xe1\xb2\xb9\x9e\x9f\x91\x13\x29\x39\xfe\x1d\x05\x46\xd0\x72\xb9\x35\x19\x28\x60\x10\x69\xc4\x71\x02\x43\x6b\xc3\x85\x82\xc8\xcd\x1f\xb6\xad\xe6\xca\x22\xd5\x66\xab\x53\xc4\xf0\x08\xd8\xd0\xca\xfe\xb6\xfa\x2e\xfb\x21\xfb\xbc\xfa\x94\xfd\x67\xf5\x6d\xf6\x19\xad\xfe\x9c\x7d\xce\xfe\xb7\xfa\x4b\x61\xad\xc1\x44\x65\x86\x0a\x1e\x66\xff\x5c\xfd\x35\xfb\x21\x7b\xc9\xfe\x8b\xb2\x7f\x67\x2f\xd9\x4f\xd9\x8f\x81\x5b\xce\xed\xae\xed\x56\x8b\x77\xc1\xf5\xaf\xd5\xb7\xab\x4f\xd9\xf7\xd9\x4f\xab\xef\x0a\x28\x91\x98\x72\x2d\x29\x28\x2b\x3c\xce\xd2\xdf\xb3\x97\xd5\xa7\xec\x25\xfb\x31\xfb\xbe\xb4\x44\xf5\xf1\x66\xde\xdc\xdf\x15\xda\x58\xf1\xa3\x21\xfc\xa3\xe0\xa6\x72\xe5\x49\x70\xb2\xc7\x46\xe0\x16\x5b\x1c\xee\xd9\x77\x13\x8c\x65\xe6\x98\x51\x33\x08\x53\x53\xf2\x00\x1b\x5a\x29\xe5\x13\x2b\x7c\x7f\x7b\xf7\xfb\x3d\x1b\xb4\xad\x62\x52\xd8\x0a\xff\xf0\xd5\x9b\xb7\x9d\x55\x88\xa1\xe6\xed\xdd\x3d\x3a\x8b\x85\xd2\x48\x48\x64\x9e\xb6\x84\x27\xc1\x66\x20\xcf\x3b\x1b\xd2\x12\x47\x20\xc5\xd4\x54\x83\x0f\xeb\x71\xb3\x7a\x17\xba\x8a\x7c\x2b\x08\x2b\xc7\x07\x31\x28\xca\x27\x0c\xac\x90\x08\x7e\xaa\x51\xa1\xd5\xd9\x81\x41\x42\xb9\x15\x9a\x7b\x67\x95\x9e\x97\xeb\xe4\x8f\xce\x4a\x97\x85\xd2\xe5\x51\x4a\xbd\x58\x4c\xa5\x15\xe6\x8f\xee\x2b\x15\x4a\x97\x47\x29\x5d\x15\x4a\x57\x47\x29\xf5\xfa\x25\xbe\xfe\x71\x6a\x04\x2f\xac\xd0\xdc\xbb\xab\x7c\x04\x78\xb4\xc2\xfc\x71\x54\x70\x51\x9e\x4e\x35\x32\xa9\x37\xb4\x4c\x67\x6d\x95\x71\x46\xd3\x22\xc6\xcc\x73\xed\xcd\xb5\xe3\x39\x9e\xd3\xb3\x50\xca\x70\x04\xb1\x60\x04\xe4\xd0\xba\x7d\x6f\xed\x31\xaa\xf2\xd3\x6c\x6d\xe4\xad\x40\x54\xff\x62\xf3\x64\x74\xcd\xd1\x58\x9e\xac\x2e\xa1\xb3\xd6\x43\x36\xef\x9a\xca\x53\x36\xe1\x13\x79\xe4\x29\x8b\x53\xea\x1a\xb5\x43\x27\xed\x3b\x3e\x91\xed\x27\xad\x61\xe7\xe7\x9d\x76\x6d\x4c\x9b\x7b\xe1\x54\x31\x2a\x89\xaa\x91\x6c\x90\xe7\x0a\x47\x70\xfd\x86\x10\x64\xf4\xf6\xb3\x5d\x8c\x63\x59\xb1\x1d\x31\xc0\xd2\x47\x23\xa1\xe3\x1b\x2b\xcc\x9d\x4a\xf1\x04\xa4\xf3\x0d\x70\x02\xb2\xf2\x2b\x28\x3e\x16\x16\xed\xd4\xd0\xf2\xaa\xb3\x2d\x49\x70\x7e\x26\xbc\x2e\xa9\x65\x0d\xb2\x8e\xc3\xdf\xe6\x54\x13\xe4\xa2\x87\x94\x98\x51\xe0\xea\x78\x57\xec\xe1\xe1\xf6\x6d\xf3\xcc\x1d\x4e\x60\x7b\x26\x70\x37\x17\x7a\x7e\x96\x98\x4f\x00\x39\xdf\x80\x9a\x32\xad\x36\x76\xa3\x01\x10\x09\x8d\x9b\x15\xa8\xe5\x12\xb9\xb9\xdf\x25\x36\xb4\x5c\x06\xae\x26\xcd\x4a\x06\xe2\x5e\x01\x83\xd4\x98\xdc\x96\xa8\xa3\x05\xa6\x60\xb9\x44\x77\x42\xa3\xb1\x69\x60\xcd\x4f\x9c\x54\x5c\xbb\x39\xd9\xbb\x99\xf1\xfa\x39\x62\x4c\xe7\x40\x6e\x50\xf5\xe1\xd3\xbb\x29\xd2\x25\x1f\xad\x3f\x3c\x0c\xd2\xf9\x4d\xfe\x65\xcb\x47\x5f\x9a\xff\xf0\x5f\xbf\x06\x5b\x9b\x58\x9c\x07\x05\x72\xb9\x74\xcb\x91\xf1\x70\xb9\xfc\xf5\xf3\xb3\xf3\x47\x90\x8a\x0a\xbe\x46\x95\xc7\x4f\xe0\x16\xad\xb1\xe9\x95\x75\xc2\xc2\xff\x07\x00\x00\xff\xff\xe7\x89\xe5\xd5\xd0\x16\x00\x00")
+var _mngrsHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x5a\x5b\x8f\xdb\xc6\x15\x7e\xf7\xaf\x38\x65\x50\x48\x42\x24\x4a\x5a\x63\xed\x46\xb7\xd4\xb5\x9d\xd8\x45\xb2\x76\xbd\xeb\x36\x40\x5b\x04\x23\xce\xa1\x38\x5d\x6a\x86\x9e\x19\xee\x4a\x56\x04\x04\x79\xe8\x4b\x8a\x3e\xb4\x0f\x7d\xe8\x53\xff\x41\x02\x24\x68\x8b\x22\xfe\x0d\xda\x7f\x54\x1c\x5e\x74\xa1\xa8\x5b\xd0\x2e\x85\x15\xc9\x39\x97\xef\x5c\x67\x38\x54\x2f\xb0\xe3\x70\x70\xef\x5e\x2f\x40\xc6\x07\xf7\x00\x00\x7a\x56\xd8\x10\x07\x9f\x4e\xe1\x53\x39\xd2\xa6\xd7\x4c\xaf\xd3\x31\x63\xa7\xf9\x39\x1d\x43\xc5\xa7\x30\x5b\x5e\xd2\xc7\x57\xd2\x36\x7c\x36\x16\xe1\xb4\x03\x95\x17\x11\x4a\xb8\x64\xd2\x54\xea\x60\x98\x34\x0d\x83\x5a\xf8\xdd\x25\xc7\xfc\xde\xf2\xd4\xb2\x61\x88\x05\x61\x43\xa5\x39\xea\x86\xa7\xc2\x90\x45\x06\x3b\x90\x9f\xad\x24\xd0\x71\x2b\xb8\x0d\x3a\xd0\x6e\xb5\x7e\xba\x5b\x74\x7d\x75\x19\xac\x9d\xf3\x52\x95\x1d\x68\x95\x4b\x3a\xcc\xda\x18\x2a\x6b\xd5\xb8\x03\xed\x68\x02\x46\x85\x82\xc3\x7b\x9c\xf3\x95\x38\x3a\x2c\x4e\x6c\x83\x85\x62\x24\x3b\x10\xa2\x6f\x37\x47\x6f\x50\x5b\xe1\xb1\x30\xa7\xb0\x2a\xda\x24\x88\x18\xe7\x42\x8e\x3a\xd0\x3e\x8f\x26\xc7\x4a\x5e\x37\x43\x77\xa4\x0d\x1a\x5e\x20\x42\x5e\xc5\x1b\x94\xb5\xa2\x29\xcc\xbb\x1e\x69\x15\x4b\x4e\xce\x57\xba\x03\xef\xf9\x67\x74\x94\x8b\x0b\x0a\xec\x01\x8a\x51\x60\x3b\x70\xde\x8a\x26\xa5\x1c\x6e\xc4\x46\x42\x32\x2b\x94\x2c\xb0\x72\x61\xa2\x90\x4d\x3b\xd0\x18\x9b\x86\x1f\xe2\x64\xa8\x26\xdd\x72\x0a\x1a\x2d\xf5\x4b\x83\x0c\xdf\x08\x21\x7d\x42\x61\x6c\x23\x49\xe0\x0e\x48\x25\xb1\x5b\x16\x3c\xcd\xb8\x88\x4d\x07\x5a\xee\xd9\xb9\xc6\xf1\x21\xf0\x0d\x33\x06\x56\xdf\x35\x62\x22\x56\x34\x6f\x19\xb9\x4c\x01\xb4\xdc\x4d\x3d\xcb\x1a\x32\xe2\x2d\x12\x8e\x9f\x3d\xdc\x26\x08\x85\xc4\x46\xee\xe4\xb6\x7b\xbe\x39\x9a\xa4\x00\x47\x4f\xe9\x04\x49\xd1\xda\xdd\x96\x84\xa2\xe3\x0b\x6d\x6c\x9a\x18\x7b\x0c\x2b\x10\x96\xd8\x99\xb9\xd3\xaa\x28\x09\xc6\xba\x5f\xb7\xac\xc9\x68\xd3\xba\xd9\x4f\xbe\x17\x7b\xc8\x8e\x83\x1e\xb2\x23\x91\x6b\x72\xf0\x09\xd0\xf7\xd3\x97\x63\xdf\x01\xb5\x0c\x59\xa4\x8c\x20\x96\x0e\x68\x0c\x99\x15\x37\xb8\xa3\x30\x86\xa1\xf2\xae\xbb\xbb\xf2\x2e\x4b\xbb\x92\xb4\x1a\x33\x3d\x12\x32\xab\x9e\x46\x3b\x9a\xec\xcd\xba\xb3\x42\xda\xe5\x8d\xa2\xd5\x7a\x38\xf4\xfd\xee\xc1\x7e\xb2\x45\x93\x75\xde\xf5\xbe\x89\x78\x86\x0f\x0e\xbb\xb0\x13\xa8\x1b\xd4\xbb\x1d\x99\x8e\x17\xdc\xf9\xb6\x21\x24\xc7\x49\x07\xce\x76\xd9\x71\xfe\x60\x78\xff\xa4\xd2\xda\x61\x2a\x7e\x80\x1e\x96\x5a\xbb\x24\x39\xda\x52\x5f\x79\xb1\xd9\x63\x69\x32\x7e\xa4\xa5\x2a\xb6\x14\xd3\xad\x4e\x39\x54\x93\x86\x09\x18\x57\xb7\x1d\x68\xa5\x47\x92\xfb\xa0\x47\x43\x56\x6d\xd5\xa1\x7d\x76\xbf\x0e\x67\xe7\xe7\x75\x1a\x38\xaf\x1d\x01\x5b\x2a\x5b\xed\x70\x61\x68\x26\xe6\xb5\xe4\xd2\x5d\x5e\x16\xe0\x7a\xb1\x36\xe4\x94\x48\x09\x69\x51\x1f\x94\x7e\x54\xdb\x2a\x36\xb7\x92\xfa\xda\x48\xff\x56\x77\x57\x57\x28\x36\xa8\xed\x3a\xda\xec\x0a\xfb\xe9\x77\xdb\xb4\xde\xce\x60\x56\xa6\xa0\xb4\x47\x9d\x9f\xda\xa4\x8e\xc4\xe3\x32\x8f\x5a\x4e\x99\xe3\x96\xf9\xd5\xde\x54\x9c\x27\xb7\x7f\x44\x3b\x28\xed\x1a\x9b\x35\x52\x24\xd9\x0d\x35\xcf\xac\x32\xb0\xb9\xb4\x07\xde\xc3\xf3\x87\x85\x65\x59\x96\x71\x0d\x5a\x12\x59\x53\x56\xdd\x79\x6e\xb2\xd8\xaa\x83\x36\xf9\x07\x0c\xda\x2e\x7a\xfa\xdf\x6b\xae\xad\xb2\x7b\xc6\xd3\x22\xb2\xab\x25\xb7\x1f\x4b\x2f\x31\x93\x45\xe2\x15\xbe\x89\xd1\xd8\xea\x18\x6d\xa0\x78\x1d\x62\x1d\xd6\x21\x62\xd3\x50\x31\x5e\x07\x25\x2f\x63\xcf\x43\x63\x8a\x8b\xbb\x1b\xa6\x61\x12\x68\xe8\x83\xc4\x5b\xf8\xec\xd3\x4f\x9e\x59\x1b\xe5\xb2\x6a\xdd\x95\x57\xe9\x98\x04\xda\x55\x11\xca\x75\x25\xb5\xee\x16\x89\x41\x9b\x49\x78\x86\x8c\xa3\xae\x56\x1e\x2b\x69\x51\xda\xc6\xd5\x34\xc2\x4a\x1d\x2a\x2c\x8a\x42\xe1\x25\xb5\xd8\xfc\x83\x51\xb2\x72\x94\x94\xcf\x1a\xd9\x0d\xe4\x8d\xdf\x08\x1b\x90\xa4\xc9\x38\x0c\xac\x8d\x74\x3a\x50\x26\xe7\x56\xd8\xe0\xb1\x46\x8e\xd2\x0a\x16\x1a\xe8\x83\xd5\x31\x1e\xa7\xef\xf1\xe5\xab\x8f\x1a\x57\xea\x1a\x65\xa5\x0e\x5c\x79\xf1\x18\xa5\x75\xdf\xc4\xa8\xa7\x97\x18\xa2\x67\x95\xae\x56\x84\x8c\x62\xfb\x5b\xc9\xc6\xd8\xb7\x44\xfa\xfb\x4a\xcd\xbd\x61\x61\x8c\x25\x60\x94\xa4\x78\x40\x7f\x15\xba\x6a\x31\x22\x79\x54\x38\xb3\x0c\xfa\x30\x9b\x6f\x4a\xa1\xc3\xea\xe2\x93\x56\xfe\x97\x31\xfd\xf2\xf2\xc5\x85\x1b\x31\x6d\xb0\x4a\x2e\xd0\x68\x22\x25\x0d\x5e\xe1\xc4\x16\x40\xd1\x67\x0e\x1e\xb3\x5e\x00\x55\x2c\xc3\x42\x07\x0b\x51\xdb\x6a\x25\xf3\x0f\xf8\x4c\x84\xc8\x5d\x80\x57\x68\x63\x2d\xa9\xb6\x2c\xb3\xb1\x01\xe5\x43\x05\xde\x4f\x2c\x4d\xef\x94\x68\xa3\x8f\x4e\xf8\x4a\x90\x6c\xdd\x11\x3e\x54\xc9\x28\x37\xd3\xd0\xef\x83\xa3\xae\x9d\x5d\x40\x97\x79\x9e\x30\x95\xda\x8a\xa1\xc1\x95\x58\xd4\x5a\xe9\xfd\x66\xaf\xe8\xdc\x31\x1a\xc3\x46\xb8\x5b\xee\xff\xdf\x7d\xf3\x8d\x3b\x85\xe4\x48\x1c\x8f\x92\x57\xb3\xc2\x87\x0f\xd3\x54\x30\x56\x0b\x39\x12\xfe\x34\x1f\xa8\x41\x07\x64\x1c\x86\xb5\xd2\x0e\xba\x4c\x4e\x8d\x44\xbc\x95\xa2\xa1\x4a\x6b\xd7\xcd\xc7\x0f\x49\xa1\xe2\x78\x34\x42\x69\xab\xd7\x42\x52\xdf\x88\x05\xaf\x03\xdd\x2d\x8a\xa6\x7b\xd0\x87\x48\xab\x71\x64\xab\xce\x05\xde\x26\x64\x4e\x46\xdd\xbd\x57\x4c\x8e\x32\x21\x74\xac\xb5\xc4\xca\xcb\x47\x57\x8f\x9f\x51\xb7\x68\xb2\x48\x34\x6f\xda\x4d\x4a\x52\x42\x02\xef\x43\x25\xb9\x48\x01\xcd\x12\x25\x9d\xe4\x3f\xcc\xeb\xb4\xb4\x56\x8c\xaf\x59\xb7\x19\x80\x32\x5b\xb3\xd9\x66\xdb\xd8\x7c\x1a\x2a\x62\xfd\x71\x38\x73\x69\x9d\xe5\x59\x29\xde\xd2\x68\x28\xcb\xec\x56\x34\x8a\xb0\xc8\xb5\x9e\x92\xbe\xd0\xe3\xaa\xf3\x38\xd6\x1a\xa5\x85\xd7\xaf\x9f\x3f\x01\x26\x39\x18\xf4\x34\x5a\xb8\x15\x61\x08\xc6\xaa\x08\x6e\x95\xbe\x16\x72\x04\xcc\x82\x92\x1e\xd6\x81\x91\x7c\x18\xc7\xc6\xc2\x10\x41\xa3\xb1\x4c\x5b\xe4\x40\xdd\x18\x6c\x80\xc9\x74\xa3\x24\x1a\x17\x68\x72\x10\x32\xc6\x0f\x9d\xda\xc1\x40\xbe\xb8\xbc\x3a\xe8\x9f\xe4\x22\xb5\xb2\x52\x4f\xb2\xbc\xbe\xb2\x3e\xed\x0b\x25\x6a\xe8\xb3\x9e\x75\x64\x6b\x07\x1c\x78\x3f\x6b\x14\x6f\x95\xe4\xf0\xc5\x17\x90\x5c\x8c\x99\x64\x23\xd4\x35\x37\xd3\xe7\xfc\x4e\x5e\x26\x2e\xa9\x83\xb0\x20\x0c\x98\x40\xdd\x4a\x50\x32\x9c\x26\xfe\xe8\x38\xf5\x94\x31\x75\x5c\xad\x5b\xaa\x7e\xbb\x9a\xf2\xbf\xf9\x69\x39\x88\x21\x9e\x18\xe1\x27\x09\x0b\x38\x2b\x17\x3a\x1f\xc2\x73\x6b\xc0\x32\x73\x6d\xd2\x40\x27\x71\xcc\x1a\x96\x55\xf0\x26\xc6\x18\xdd\xc3\x31\x7b\xf2\xf4\x93\xa7\x57\x4f\x8f\xc8\xea\x34\x52\x07\x6b\x2e\x5b\x16\x65\x4b\xa1\x5e\x33\xdd\xad\xbc\xd7\xa3\xfd\xc7\x6c\x9d\xc4\xc5\x0d\x24\xeb\xa6\xbe\xe3\x87\x8a\xd9\x6c\xf7\xcb\x01\xc1\xfb\x0e\x59\xf4\xb9\xa7\x91\x59\x74\x56\x4b\xa9\x9e\xaf\xf4\x18\xd2\x75\x4d\xdf\x79\xf9\xe2\xf2\xca\x01\x5a\xe4\x2a\xd9\x77\x9a\xc4\xd2\xcc\x58\x92\xa5\xd4\x70\x2c\x6c\xdf\x49\x67\x31\x48\x07\xae\x98\xb9\xae\x2e\x97\x08\x23\xb4\x4f\x43\xa4\xd3\x5f\x4c\x9f\xf3\x6a\x85\xa3\xb1\xd9\x9a\x34\x5f\x1d\xd4\x61\x27\xb5\xa5\x65\xd2\x61\x32\x11\x1d\x41\xa4\x31\x42\x66\x73\xc2\xda\x9a\xc9\xb4\xf9\x37\x9b\x81\xeb\x19\xed\x7f\x24\x30\xe4\x30\x5f\xf9\x99\x8e\x9e\x49\xd6\x38\x49\x3b\xec\x3b\x6b\x26\xa4\x8e\x5c\xbf\xb1\x29\x95\x8e\x9e\x8a\x2c\x2d\x81\x23\x08\xd9\x10\xc3\xbe\xb3\xf8\xcb\xdd\xd7\x8b\x6f\x17\xdf\xdf\x7d\xb9\xf8\xd7\xdd\x57\x8b\xef\xe1\xee\x8f\x8b\xef\x17\xff\xb9\xfb\x53\x2a\xad\x44\x44\x2e\x46\x28\x39\x58\xfc\xfd\xee\xcf\x8b\x6f\x17\xef\x16\xff\x86\xc5\x3f\x17\xef\x16\x3f\x2c\xbe\xeb\x35\xb3\xb1\x6d\xdd\xcd\x5c\xf9\x31\xb8\xfe\x71\xf7\xd5\xdd\x97\x8b\x6f\x16\x3f\xdc\x7d\x9d\x42\xf1\x54\x2c\xad\x16\x68\x9c\xc1\x69\x92\xfe\xba\x78\x77\xf7\xe5\xe2\xdd\xe2\xbb\xc5\x37\x99\x24\x61\x4f\x17\xf3\xe8\xf2\x22\xe5\x66\x46\x9e\x0c\xe1\x6f\xa9\x6f\x72\x53\xa8\x6b\xed\x91\xd1\x6b\xa6\x21\x1e\xec\x89\x3b\x25\x63\x56\x39\x74\x56\x0e\x82\x5a\x6b\x92\xae\x7d\x27\x12\x72\xe4\x0c\x5e\x3e\xbf\xf8\x78\x4f\x80\x36\x59\xa8\x84\x9d\xc1\xb3\xa7\x8f\x9e\x1c\xcd\xc2\xc9\x35\x4f\x2e\x2e\xa1\x1a\x28\x63\x41\x69\xa0\xef\x86\xc6\xb7\x2a\xbc\x41\x5d\x3b\x5a\x90\xd5\xcc\x43\xad\x62\xea\x06\x57\xcb\xf3\x72\xf6\x63\xdc\x95\xd6\x5b\xea\xb0\xec\xfc\x20\x06\x23\xe4\x28\x44\x67\xc0\x95\xac\x58\x48\xb9\x8e\x36\xe0\x7c\x2c\xa4\x33\xa0\xff\x47\xb3\xb4\x5b\x09\x4f\xbb\x75\x0a\xd3\xfd\x94\xe9\xfe\x49\x4c\xed\x40\xc5\xda\x19\x24\x5f\xc7\x6b\x22\x6a\x67\x70\xff\x24\xa6\x07\x29\xd3\x83\x93\x98\xda\x67\x19\xbe\xb3\xd3\xd8\x38\x9b\x3a\x83\x36\x67\xd3\xe3\x59\x6e\x11\xaf\x9d\x41\xf2\x75\x52\x72\x25\x4f\x97\x40\x45\xd8\x77\x68\xc7\xd1\xc9\xf2\x4c\x44\x69\x8e\xd1\x77\xae\xe3\xec\xa1\xdb\x72\x5b\x6e\xdb\x81\x28\x64\x1e\x06\x2a\xe4\xa8\xfb\xce\xf3\x97\x85\xaa\xdd\x10\x6a\x92\xd9\x6c\x29\xe4\x89\x02\x61\x7f\xb2\xc6\xd0\x6b\xd2\xd4\x98\xcd\xac\x4d\x2e\x6e\x76\x4e\xb2\xc9\x6e\x52\x37\x85\x35\x96\x23\x7d\xe2\x2c\xcb\x22\xd1\x24\xb6\x43\x33\x2d\xbd\x8a\xdc\x3d\xd3\x92\x77\x7e\xdc\x6c\xb7\xcb\xd3\xf4\x3f\x35\x2a\x3d\xcb\x1c\x55\x70\x32\x21\x4f\x42\xe3\x0c\x76\x8b\x2d\xf8\xfa\x11\xe7\x40\x7c\xfb\xbd\x9d\x9e\x07\x3a\xf7\xb6\x17\x22\xd3\x1d\x18\x2a\x1b\x74\x33\xce\x24\x18\x84\x90\xde\x6b\x7d\xee\x8b\xd0\xa2\xde\xe9\xf6\x8f\x9f\xae\x7b\x9d\xf4\x37\xc7\x53\x67\x5f\x4f\x33\x4a\x1f\xd1\xc5\x1a\x69\x78\xb8\x33\x90\x78\x9b\x6c\x13\xd0\x46\xec\xd1\x15\x92\x73\xcf\x66\xf4\x74\x8e\x6f\xa0\xea\xfe\x2a\x46\x3d\x75\x3f\x46\x0b\x29\x84\x1a\x2c\xa9\x60\x3e\x87\x14\x23\xf2\xd9\x0c\x50\x52\x3c\x07\x14\x8b\xfd\x8a\x4f\xac\x32\x5f\xab\xf1\x32\x5c\x94\x3e\x6b\x90\xd2\xb1\xf9\xbc\x90\x08\x8f\x53\x84\x90\x0c\x1f\xa9\xc6\xaa\x5d\x4a\xac\xda\xa3\xc2\xaa\x7d\x0a\x0a\xc9\xf6\xd1\x56\x56\x94\x17\x36\x59\x19\xd1\xd3\x8d\xfb\x0a\x25\x47\x9d\xd7\x49\x2f\x7d\x29\x9f\x6e\x20\xf7\x9d\x56\xbe\x56\x1a\x8f\x59\xb2\xc6\x58\xc9\xb5\xba\x80\xca\x06\x83\x1c\x72\x13\x5e\x47\x9c\xc0\xf7\x9a\x36\xd8\x26\xa3\xa7\xae\xf2\x91\x0b\x36\xc6\xf2\x91\x4b\x7a\xd2\x2b\x1f\xda\xbc\xdb\x6b\xae\x23\x9b\xcd\x34\x93\x23\x04\xf7\x15\x9a\x38\xb4\x66\x3e\xdf\x67\x01\x1f\x90\x5f\x72\x2b\xe6\x73\x68\x02\xdd\xc8\x8c\x81\xf9\xbc\xd7\xb4\xbc\x9c\x89\x6c\xda\x4b\x40\xa6\x51\x3e\xef\xa4\x10\x3e\xb8\x4f\x96\xcf\xf9\xf3\xfc\x91\x9f\xf2\x9e\x36\xb3\xe6\x73\x94\xcb\x1b\x92\xef\xd4\xb5\x71\x83\x3e\xbd\x61\x6c\xad\xa2\xe7\x54\x2f\x14\xde\x75\xdf\x59\xdf\xa9\xa9\x50\x67\xa0\xdf\x6a\x54\xd6\x8c\xc8\x2f\x33\xc8\x95\x9a\x33\x48\x79\x7a\xcd\x54\xd6\xa6\xd6\x2c\x9f\x0a\xf8\x0f\xe3\xc8\x2c\xdc\x0f\xc4\x67\xa1\xc1\x9a\x33\x48\x8d\xdf\x0b\x20\x73\xd3\xff\x48\x31\xed\x1f\xd7\x9c\x41\x46\xbb\x5f\xb1\x3c\xce\xe0\xf5\x4d\x99\x72\xb5\xb5\xa4\xad\x82\xb7\xda\xc9\xde\xad\x78\xdb\xac\xb5\x1d\x81\x9d\xe2\x53\xa2\x72\xa9\x9b\x09\x55\x2c\x24\x72\xef\x7c\x0e\x17\xca\x82\x4f\x2f\x3d\x60\x36\x43\xc9\x33\xbb\x7b\xcd\xa4\x71\x6c\xaf\x1a\x56\xaf\xb0\x7d\x31\x41\xde\x85\xfc\xc7\x32\xad\x6e\xba\x94\xa0\x77\x91\xab\x97\xd5\xf4\xeb\x96\xe4\xd7\x10\x1d\xf8\x20\x9a\xac\xff\x82\x68\xad\xf7\xcc\x66\xee\x6b\x83\x7a\x3e\x6f\x66\x67\x64\xdf\x7c\xfe\xf3\xd9\xcc\xfd\x35\x6a\x23\x94\x5c\xa2\x4a\x1a\x5e\xaf\x99\x6e\x1b\xdc\xeb\x35\x03\x3b\x0e\x07\xff\x1d\x00\x62\x5a\x72\x60\x04\x25\x00\x00")
 
 func mngrsHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -216,7 +217,27 @@ func repeatableHtml() (*asset, error) {
 	return a, nil
 }
 
-var _tasksHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x58\xc1\x8e\xe3\xb8\x11\xbd\xf7\x57\x30\x5a\x04\xdd\x0d\xb4\x24\xdb\x0d\x77\x67\xd5\xb6\x90\xc9\xce\x26\x99\x43\x66\x07\xd3\x33\x39\xe4\x12\xd0\x62\xd9\x22\x9a\x22\x05\x92\xee\xb1\xc7\x30\x30\xd8\x43\x2e\x1b\xe4\x90\x1c\x72\xc8\x29\x7f\xb0\x0b\xec\x20\x09\x82\x9d\x6f\x90\xff\x68\x41\x51\x72\xcb\xb2\x64\xcb\x23\x03\x12\x65\xd6\x2b\x56\x3d\x56\x15\x0b\x1a\xc5\x3a\x61\xe1\xd9\xd9\x28\x06\x4c\xc2\x33\x84\x10\x1a\x69\xaa\x19\x84\x7f\x58\x22\x8d\xd5\x83\x1a\xf9\xf6\xdd\xce\x29\xbd\x2c\xc7\xe6\x9a\x08\xb2\x44\xab\xed\xab\xb9\xa6\x82\x6b\x77\x8a\x13\xca\x96\x01\x3a\xff\x26\x05\x8e\xee\x31\x57\xe7\x57\x48\x61\xae\x5c\x05\x92\x4e\xef\xb6\x88\xf5\xd9\x76\xa8\xf1\x84\x41\x4d\xd9\x44\x48\x02\xd2\x8d\x04\x63\x38\x55\x10\xa0\x72\x74\xb7\x23\xf6\x8e\x12\x1d\x07\xa8\xdf\xeb\xfd\xb2\x5d\xf5\xd5\xd3\x6b\x5c\x19\x93\xc6\x25\x03\xd4\x6b\xd6\x74\x1c\xea\x4e\x84\xd6\x22\x09\x50\x3f\x5d\x20\x25\x18\x25\xe8\x0b\x42\xc8\xae\xc5\x1a\x16\xda\xc5\x8c\xce\x78\x80\x18\x4c\xf5\xee\xec\x23\x48\x4d\x23\xcc\x4a\x09\x2d\xd2\x5d\x81\x14\x13\x42\xf9\x2c\x40\xfd\x61\xba\xe8\xaa\xb9\xea\x86\x0c\xb8\x8e\xdd\x28\xa6\x8c\x5c\xc0\x23\xf0\xcb\xba\x2b\x38\x7a\x98\x49\x31\xe7\xc4\x90\x2f\x64\x80\xbe\x98\x0e\xcc\xaf\x85\x95\x1a\x3c\x06\x3a\x8b\x75\x80\x86\xbd\xaa\x79\x15\x84\x97\xe2\x19\xe5\x58\x53\xc1\x6b\x50\x42\x55\xca\xf0\x32\x40\x6e\xa2\xdc\x29\x83\xc5\x44\xd4\x3c\xdc\x4a\x98\xd9\x46\x5e\x5c\xe3\xf8\xce\x16\x9a\x8b\x51\xa5\xdd\x3c\x80\x03\xc4\x05\xaf\xc5\x50\xb1\x79\x12\x13\x3a\x57\x01\xea\x79\x83\xa1\x84\xe4\x98\xf1\xae\x4a\x10\xbe\x6a\x9b\x51\x29\xae\xbb\xb7\xdd\xb9\x62\x01\xd4\xf3\x76\xd7\x41\x65\x0e\x29\xfa\x1e\x8c\xd8\xaf\x6e\xf7\x05\x18\xe5\xe0\x96\x24\xf7\xbd\x61\x43\x08\x10\x88\x84\xcc\x2d\xa9\x7b\xdb\xee\x09\xa3\xc1\x94\x4a\xa5\x6d\x60\x1c\x70\xac\x26\xd8\xe0\x67\x41\xa7\x16\x69\xbe\x19\x55\x5e\xf7\xbc\xd9\xc9\x9b\xc3\xe2\x07\x6d\x67\xb8\x9b\xe9\x15\xb9\xc3\x96\x4b\x43\xf0\x09\xa6\x1f\x96\x6f\x09\xff\x66\x53\x1b\x63\x47\x28\x6a\x37\x54\x02\xc3\x9a\x3e\x42\x4b\x62\x4c\x98\x88\x1e\x5a\x2a\x86\x8d\x37\xd4\xf3\x1a\xc2\x2a\xc1\x72\x46\x79\x91\x3d\x6e\xbf\x5e\x5a\x6a\x51\x37\xa8\x85\x5d\x59\x28\x7a\xbd\xdb\xc9\x74\x7a\x77\xb4\x9e\xec\xc9\x14\x95\xb7\x5a\x37\x01\x06\x70\x73\x9c\xc2\x20\x16\x8f\x20\xdb\x89\xb4\xf3\x35\x3a\xdf\xbb\x94\x13\x58\x04\x68\xd0\xe6\xc7\xf0\x66\x72\x7d\x52\x6a\xb5\xb8\x0a\x5f\x42\x04\x8d\xde\x6e\x45\x3a\x7b\x3a\x15\xd1\x5c\x1d\xf0\x34\x9f\xef\xe8\xa9\x98\x6b\xb3\xa7\x7b\x95\x72\x22\x16\xae\x8a\x31\x11\xef\x02\xd4\xb3\xbf\x3c\x96\x91\x9c\x4d\xf0\x45\xef\x0a\xf5\x07\xd7\x57\x68\x30\x1c\x5e\xe5\x65\xec\xb2\x83\xd9\x5c\xe8\x8b\x80\x50\x65\x4e\x62\x72\x99\xbf\x7a\xdb\xd7\x9a\xb9\xd1\x5c\x2a\x43\x4a\x2a\x28\xd7\x20\x8f\x6a\xef\x54\xb6\x3a\xd4\xac\x9d\xf0\xdf\xa3\xa4\xb5\x9e\xed\xe7\xd1\xe1\x82\xd6\xe5\x5c\xa9\x97\xb3\x13\x6a\xd4\x31\x73\x8e\x00\x5a\xed\xf1\x70\x64\x4a\x4e\x13\x71\xdb\xf8\xea\x37\x67\xd2\x7e\xaa\xef\xe7\x48\x63\xd5\xd8\xcd\x91\xba\x48\xbb\xa9\x65\x64\x35\x19\x5b\x6a\xbb\x89\x6e\x87\xb7\xb5\xb6\xac\x88\x38\xd7\xb4\x44\x5a\x35\x65\x77\x19\x9b\x78\xae\xc5\xe7\x96\xb8\x03\x49\x6f\xee\x23\xbf\xe8\xb2\x47\xbe\xed\xca\xcf\x46\xa6\xcf\x2e\x3a\x70\x42\x1f\x51\x3e\x3f\x76\xa6\x4c\x60\x5d\x74\x79\x0e\xa2\x64\xec\x98\x8e\xfd\xcf\x91\x04\xac\xc1\x79\xea\xd2\x47\x53\x21\x13\x94\x80\x8e\x05\x19\x3b\xaf\xbe\xb9\x7f\xe3\x20\xb3\x99\x82\x8f\x1d\xdf\x40\xfc\x02\x82\x04\xbf\x9f\x4f\x12\xaa\xc7\x8e\x04\x3d\x97\x1c\xd9\x89\x37\x58\x3d\x5c\x10\x11\xcd\x13\xe0\xda\x9b\x81\xfe\x9a\x81\x19\xfe\x66\xf9\x82\x5c\x9c\x13\x50\xba\xe0\xfe\xfc\xd2\x7b\xc4\x6c\x0e\x57\xa8\x55\x5a\x2f\x53\xe8\x20\x46\xd3\x0e\x42\x12\x52\xc0\xba\x14\xbc\xac\xb8\x6c\xae\xd5\x0a\x79\x91\x92\xd3\xdf\x52\x60\x04\xad\xd7\x3b\x93\x23\x05\x0c\x22\x8d\x38\x4e\x60\xec\x54\x5c\xb0\x44\x56\xff\xd8\xd5\x9a\x83\x45\xaa\xcd\x56\xa7\x88\xe1\x09\xb0\xb1\x93\xfd\x7d\xf3\x5d\xf6\x43\xf6\x71\xf3\x21\xfb\xef\xe6\xdb\xec\x23\xda\xfc\x25\xfb\x98\xfd\x7f\xf3\x57\xab\xad\x41\x45\xa9\x86\x0a\x1e\x66\xff\xda\xfc\x2d\xfb\x21\xfb\x94\xfd\x0f\x65\xff\xc9\x3e\x65\x3f\x65\x3f\x8e\xfc\x62\x6e\x7f\x6d\xbf\x5c\xbc\x8b\x5d\xff\xde\x7c\xbb\xf9\x90\x7d\x9f\xfd\xb4\xf9\xce\x9a\x12\x89\x39\xd7\x92\x82\x72\xc2\xd3\x34\xfd\x23\xfb\xb4\xf9\x90\x7d\xca\x7e\xcc\xbe\x2f\x34\x51\x7d\xba\x9a\x67\xf7\x2f\x2d\x1a\x2b\x7e\xb2\x09\xff\xb4\xdc\x94\xae\xbc\x17\x9c\x1c\xd0\x31\xf2\xed\x16\x87\x07\xf6\xdd\x04\x63\x91\x39\x66\xd4\x6c\x84\xa9\x29\x79\x80\x8d\x9d\x94\xf2\x99\x13\xbe\x7a\xf1\xf2\x77\x07\x36\x68\x17\x62\x52\xd8\x09\x7f\xff\xf5\xb3\xe7\x9d\x21\xc4\x50\xf3\xfc\xe5\x3d\xba\x88\x85\xd2\x48\x48\x64\x9e\xae\x84\xf7\x82\x3d\x82\xbc\xec\xac\x48\x4b\x1c\x81\x14\x73\x53\x0d\xde\x6c\xc7\xcd\xf0\x2e\x74\xd9\x7c\xb3\x84\x15\xe3\xa3\x36\x28\xca\x67\x0c\x9c\x90\x08\x7e\xae\x91\x45\x75\x76\x60\x98\x50\xee\x84\xe6\xde\x19\xd2\xef\xe5\x98\xfc\xd1\x19\x74\x6d\x41\xd7\x27\x81\xfa\xb1\x98\x4b\x27\xcc\x1f\xdd\x57\xb2\xa0\xeb\x93\x40\x37\x16\x74\x73\x12\xa8\x3f\x28\xec\x1b\x9c\x06\x23\x78\xe9\x84\xe6\xde\x1d\xf2\x0e\xe0\xc1\x09\xf3\xc7\x49\xc1\x45\x79\x3a\xd7\xc8\xa4\xde\xd8\x31\x9d\xb5\x53\xc4\x19\x4d\x6d\x8c\x99\xe7\xd6\x9b\x5b\xaf\xe7\xf5\xbc\xbe\x83\x52\x86\x23\x88\x05\x23\x20\xc7\xce\x8b\x57\xce\x01\xa5\x2a\x3f\xcd\xb6\x4a\x9e\x0b\x44\xf5\x2f\xaa\x27\xa3\x6f\x8e\xc6\xe2\x64\xf5\x09\x7d\x6c\x3d\x64\xf3\xae\xe9\xee\xa9\xf0\x9c\x78\xca\x1a\xc8\xb1\x53\xf6\x4f\x82\x93\xf6\x53\xd6\x30\xf3\x79\x27\x5d\x1b\xcb\xe6\x6e\x1d\xb2\xa3\x82\xa4\x1a\xc1\xc6\xaa\x1c\x70\x02\xcf\xcf\x08\x41\x06\x77\x98\x69\x3b\x8e\x65\xc9\x74\xc4\x00\xcb\x00\x4d\x84\x8e\xef\x9c\x30\x77\x2a\xc5\x33\x90\xde\x6b\xe0\x04\x64\xe9\xd7\xc8\x7e\x28\xb4\xad\xd4\xd8\xe9\x95\xe7\x5a\x92\xe0\xfc\x3c\x78\x5a\x52\xcb\x9a\xc9\x3a\x0e\xbf\xca\xa9\x26\xc8\x47\x6f\x53\x62\x46\x23\x5f\xc7\xfb\x62\x6f\xdf\xbe\x78\xde\x3c\xf3\x95\x5d\xa8\x79\xf2\x35\xa8\x39\xd3\x6a\x77\x72\xe4\x57\x0d\x59\xad\x24\xe6\x33\x40\x5e\x21\x5b\xd9\xad\x06\x83\x49\x68\x68\x28\x8d\x5e\xaf\x91\x9f\xf3\x52\xd8\x8e\xd6\xeb\x91\xaf\x49\x13\xc8\x33\xfc\x1b\x2f\x0e\x88\x3c\xcb\xa3\x73\xbd\x46\xab\x95\xf7\x0a\x4b\x9c\xd8\xe1\xeb\xbc\x46\xb7\xe1\xf6\x8b\x41\x2a\x21\xcc\x61\xc6\x1f\x03\x33\x7f\xd4\xd2\xbf\x0a\xac\xf3\x01\x4c\xc1\x7a\x8d\x5e\x0a\x8d\xa6\xa6\x7d\x36\x7f\x71\x52\xee\xb6\x9f\x6f\xf7\x7e\x5e\x3e\x7d\x0c\x99\xd2\x05\x90\x3b\x54\x7e\x76\xed\xdd\xd9\x64\xcd\x47\xdb\xcf\x1e\xc3\x74\x71\x97\x7f\x57\x0b\xd0\x97\xe9\xa2\xfa\x2d\xda\xa9\xda\xe2\xbd\x55\x20\xd7\x6b\xbf\x18\x59\x02\x7f\xbd\x5a\x79\x7f\x04\xa9\x72\xb2\xaa\x11\x3c\xf2\x6d\x63\x6e\x3a\x75\x9d\xb0\xf0\xe7\x00\x00\x00\xff\xff\x99\x8d\x5c\x18\x4e\x17\x00\x00")
+var _taskHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x58\x5f\x6f\xdb\x36\x10\x7f\xf7\xa7\x38\xa8\x1d\xba\x01\x89\xe4\x0e\xe9\xc3\x14\x59\x58\xe0\xb4\xc3\x80\x25\x2d\x92\x66\x0f\x7b\xa3\xcd\xb3\xc9\x45\x22\x05\x92\x4e\x63\x08\xfa\xee\x03\x25\xea\x9f\x2d\x59\x49\xde\x06\x09\x30\x79\x3c\x1e\xef\x7e\xf7\x87\x27\x47\xcc\xa4\x49\x3c\x9b\x45\x0c\x09\x8d\x67\x00\x00\x91\xe1\x26\xc1\xf8\x3b\xd1\x8f\x90\xe7\xe0\x5f\xa3\x21\x3c\xf1\xed\xdc\x7f\x78\xf8\xf3\x1a\x8a\x22\x0a\x2a\x9e\x8a\x5f\x9b\x7d\x3d\xb6\xcf\x4a\xd2\x3d\xe4\xcd\xd4\xbe\x1b\x29\xcc\xf9\x86\xa4\x3c\xd9\x87\xf0\xe1\x6b\x86\x02\xee\x89\xd0\x1f\xce\x40\x13\xa1\xcf\x35\x2a\xbe\xb9\x6c\x76\x14\xb3\x66\x68\xc8\x2a\xc1\x03\x61\x2b\xa9\x28\xaa\xf3\xb5\x4c\x12\x92\x69\x0c\xa1\x1e\xb5\x12\xec\xf3\x83\x53\xc3\x42\xf8\x38\x9f\xff\x34\x2e\xfa\xac\x9d\xb2\xce\x98\x0e\x1e\x19\xc2\x7c\x58\xd2\xf4\xd6\xf3\x95\x34\x46\xa6\x21\x7c\xcc\x9e\x41\xcb\x84\x53\x78\x47\x29\x6d\xc5\xd9\xc7\xe0\xb3\x39\x27\x09\xdf\x8a\x10\x12\xdc\x98\xfe\xea\x13\x2a\xc3\xd7\x24\xa9\x39\x8c\xcc\xfa\x0c\x19\xa1\x94\x8b\x6d\x08\x1f\x3f\x65\xcf\x2f\x95\xdc\x35\x43\x85\xc2\xb0\xf3\x35\xe3\x09\xfd\x19\x9f\x50\xfc\x72\x68\x0a\x59\x3f\x6e\x95\xdc\x09\x6a\xc1\x97\x2a\x84\x77\x9b\x5f\xed\x33\x2c\x8e\x1d\x6c\x67\xc8\xb7\xcc\x84\xf0\x69\x9e\x3d\x0f\xef\x50\x3e\x17\x6b\x29\x34\xd7\x06\x85\x39\xc6\xb2\x3e\x75\x35\xef\x39\xc2\xfe\x44\x81\x8b\xc2\x28\xa8\x22\x79\x16\xd9\x38\x74\x11\x4a\x80\x29\xdc\x2c\xbc\xc0\x10\xfd\x18\xa4\x7b\x2f\xbe\xd9\x83\x1d\xeb\x28\x20\x8e\x87\x29\x28\x45\x2c\xbc\x75\x82\x44\x85\xb0\x92\x86\x5d\x7a\xd5\x6a\x9e\xc3\x0f\x6e\x58\x9d\x0c\x50\xb8\x53\xab\xf8\xac\x7c\xbc\xf0\xe6\x1e\x70\xba\xf0\xac\x60\xb7\xcf\xbe\x91\x51\xed\xc4\x3e\x91\x61\xb1\x4d\xa4\x28\x30\xec\x70\x85\xc6\x36\xe5\x0e\x72\xcd\x25\xa6\x7d\xa3\xc0\xa8\x09\xd1\x4b\x99\xa6\x44\xd0\x09\xe9\x57\x6b\xc3\xa5\x80\xa2\x80\x86\xf4\x8d\x28\x92\xf6\x28\x77\x98\x21\x31\x6f\x51\xe2\xde\x10\xb3\xd3\xa7\x75\xa8\x78\xde\x22\xfd\x1a\xb5\xe1\x82\x58\x0b\x26\xcc\xfc\x4e\xd4\x16\xdf\x64\xc1\x52\x21\x31\x48\x21\x80\x6b\x24\x34\xe1\x02\x21\x80\x65\x42\x78\x5a\x12\xbf\x70\xc1\x35\xc3\x13\x30\xd7\x02\x8a\x02\x02\x57\x48\x9d\x9c\x86\x52\x8b\x6b\x08\xb5\xd4\x09\x85\xf3\x1c\xf8\xc6\x59\xf8\x8d\x28\x14\xc6\x05\xcb\x69\x93\x2a\xd6\x61\x8d\x0f\x72\x24\xcf\x87\xc4\x7b\xf1\x30\xdd\x66\xd1\x84\xbe\x28\x68\x57\xbf\x26\xa1\xfe\x91\x82\x4e\x2a\x6e\x99\xc6\x81\xbe\x25\x29\xd6\x61\xeb\x14\x2a\xc1\x5c\x72\xb3\x6f\xc6\x72\x27\x8c\x6a\xa6\x57\xf7\xb7\x6e\xa8\x88\xd8\x22\xbc\x7f\xc4\xfd\x19\xbc\x7f\x22\xc9\x0e\x21\x5c\x80\xff\x17\x59\x61\xa2\xa1\x28\xf2\xbc\x5c\xac\x46\x7c\x53\xf3\x14\xc5\x22\xcf\xdb\x49\x63\x60\x6b\xea\xab\xf1\x68\x3c\x7a\x23\xb6\xca\xd9\x71\x1a\x96\x1b\x22\xc8\x16\xd5\x38\x32\x87\xe2\x5e\xa7\xd3\xe0\x99\x5f\x38\x26\x74\x3c\xb1\x47\xe0\xac\x76\xf5\xe0\x0c\xa1\x0b\x60\xb4\x52\xf1\x8b\xa0\x1b\x54\xea\x0e\xf5\x2e\x19\x09\xec\x1e\xc1\xbe\x51\xa6\xb0\x45\xa7\xda\x6a\x35\x08\x2c\xbd\xbf\x7d\x44\x8f\x28\x28\xeb\x7e\x3c\x73\x37\xc7\x45\x7c\x65\x0c\xa6\x99\xd1\x51\xc0\x2e\xe2\x13\x57\x03\x71\x7c\x53\xd7\x83\x93\x37\x64\x11\x8b\xaf\xb6\x23\x59\xcc\xe2\xb6\x3e\xd5\xc5\x66\x98\xef\xeb\xce\xac\x65\x7a\xb0\xd8\x07\xba\x71\xa6\x5f\x1b\x37\x11\x1b\x55\xc8\x39\xe6\x23\x2f\xf6\x78\xac\x01\x27\x39\x8e\x0a\x63\x6d\xce\xc9\x5d\xce\xaa\x23\x9e\x23\xc3\x30\xd1\x36\x6b\xe1\x56\x1a\x58\xbb\xa3\xfa\xf1\xdf\x77\xb1\xcb\xce\xa5\x6d\x8d\x14\x8a\x86\x89\x5d\xc4\x25\xad\xee\x25\x4e\x3b\x7f\xed\xb6\x7b\x43\x20\x1f\xca\x3e\x09\xf2\xd1\xd5\xf2\x90\x51\x47\x18\x46\x67\xa0\xba\xbb\x92\xe0\xc5\x9d\xc9\x71\x1d\xef\x9e\x6a\xab\x70\xc3\x38\xc6\xd4\xef\x2b\x7a\x2d\xc5\x8b\xee\xfb\x11\x37\xf4\x97\x7a\x3d\xd9\x7b\xff\xfa\xf6\xbe\xd9\xc0\x2e\x62\x3b\x25\x42\xff\x40\xa5\xc3\xc6\x75\x6d\x5b\x59\x14\x6d\x8f\xd9\xc6\x42\xb7\xf3\x6c\x0e\x3a\xb3\xdb\xfd\xab\x52\x56\x89\xae\x93\x8b\xb4\x5a\xb9\xe1\x5a\x73\xb1\xb5\x0b\x69\x35\x9c\x8a\x01\x2a\xa6\x73\xbf\x3c\x62\xb0\xc4\xb2\xd8\xfa\xe0\x60\x69\x2c\x6d\xff\x50\x72\x97\x1d\x26\x6d\x05\x87\x90\x06\xfc\x1b\xf2\xaf\x54\xee\x8a\x5c\x27\x44\xeb\x85\xd7\x05\xc1\x6b\x50\x38\x76\x74\x8f\xe0\x4e\xb5\xd7\xd7\x67\xa5\xa4\x82\xa2\x40\xfb\x5b\x62\xdf\x90\x5a\xa4\x2d\xf5\x6e\x29\xa9\x1d\xd7\xb5\xd8\xa9\x5c\x21\xed\xee\x09\xdf\xaa\xde\x28\x11\x05\x8e\xb5\x13\x1d\xf5\x33\x1a\x8d\x4e\x6c\x09\x9a\x95\x31\xdc\xe3\xd4\x59\xd0\x6b\x48\x9c\x45\xae\xb7\xe8\xb4\x19\x7d\x43\x5c\x3e\x34\x7a\xbd\xac\xf1\x68\xd8\x6d\xc2\x95\x6c\x77\xa8\x65\xf2\x84\xaa\xe6\xbb\xbe\xbd\xbf\xff\xbc\x7c\xcd\xc5\xf8\xaa\xc4\xb1\xce\xba\xdb\x89\x26\x3a\xec\x1d\xf6\xd5\x30\x54\xa0\x76\x62\xb2\x90\xa9\x5d\x2f\x8a\x5b\xa0\xbb\x22\xff\x8f\x05\x6c\xbc\x57\x78\x4b\x9b\xd0\x87\xfe\x84\x57\x06\x18\x29\x7f\xaa\x3f\x47\x33\xa9\xb9\xad\xa9\x21\x6c\xf8\x33\xd2\x4b\xa8\xff\x4b\x98\x5f\x82\xaa\x3e\xa9\xe7\x97\xed\xd7\xbf\xfd\xf8\x2f\xff\x70\x09\xe1\xb7\xec\xb9\xfb\x07\x4b\xcf\x65\xfe\x83\x46\x55\x14\x81\x1b\x59\xfc\x8a\xe2\xf7\x3c\xf7\xff\x46\xa5\xb9\x14\xb5\x22\x01\xe5\x4f\xf1\x2c\x0a\xaa\xaf\xe9\x59\x14\x30\x93\x26\xf1\x7f\x03\x00\x1e\x73\x8b\x13\x37\x12\x00\x00")
+
+func taskHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_taskHtml,
+		"task.html",
+	)
+}
+
+func taskHtml() (*asset, error) {
+	bytes, err := taskHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "task.html", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _tasksHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x59\x4f\x8f\xdb\xb8\x15\xbf\xcf\xa7\x60\xb5\x5b\x64\x06\x18\xcb\x7f\x02\x27\x5d\x59\x16\x9a\xee\x64\xd3\x1c\x9a\x4d\x33\x99\x1e\x7a\x29\x68\xf1\xc9\x22\x46\x26\x55\x92\x9e\xd8\x11\x04\x04\x7b\xe8\x65\x8b\x1e\xda\x43\x0f\x3d\xf5\x1b\xec\x02\x1b\xb4\x45\xb1\xf9\x0c\xf2\x37\x2a\x28\x4a\xb6\x25\x4b\xb6\x5c\x60\x21\xc3\xa6\xfd\xfe\xff\xf8\xde\xe3\xb3\xe4\x86\x6a\x11\x79\x17\x17\x6e\x08\x98\x78\x17\x08\x21\xe4\x2a\xaa\x22\xf0\x7e\xb3\x46\x0a\xcb\x7b\xe9\xf6\xcd\x77\x43\x93\x6a\x5d\xae\xf5\x35\xe3\x64\x8d\x92\xed\x57\xfd\x0a\x38\x53\xbd\x00\x2f\x68\xb4\x76\xd0\xa3\xaf\x63\x60\xe8\x16\x33\xf9\xe8\x1a\x49\xcc\x64\x4f\x82\xa0\xc1\x64\x2b\x91\x5e\x6c\x97\x0a\xcf\x22\xa8\x29\x9b\x71\x41\x40\xf4\x7c\x1e\x45\x38\x96\xe0\xa0\x72\xb5\xd3\xa0\xaf\x77\x94\xa8\xd0\x41\xc3\xc1\xe0\xe7\xed\xaa\xaf\x77\x5f\xc3\xbd\x35\x69\x34\xe9\xa0\x41\xb3\xa6\xd3\xa2\xbd\x19\x57\x8a\x2f\x1c\x34\x8c\x57\x48\xf2\x88\x12\xf4\x19\x21\x64\xa7\x4e\x5f\x0a\x56\xaa\x87\x23\x3a\x67\x0e\x8a\x20\x50\x55\xea\x03\x08\x45\x7d\x1c\x95\x1c\x8a\xc7\x55\x86\x18\x13\x42\xd9\xdc\x41\xc3\x71\xbc\xea\xaa\x79\x3f\x0c\xe1\x30\x15\xf6\xfc\x90\x46\xe4\x12\x1e\x80\x5d\xd5\x43\xc1\xfe\xfd\x5c\xf0\x25\x23\x1a\x7c\x2e\x1c\xf4\x59\x30\xd2\x57\xb3\xba\xb0\x26\x1e\x02\x9d\x87\xca\x41\xe3\x41\xbc\x6a\x94\xb0\x63\x3c\xa7\x0c\x2b\xca\x59\x4d\x94\x50\x19\x47\x78\xed\xa0\xde\x42\xf6\x82\x08\x56\x33\xbe\x9a\x34\x73\x68\x6a\x23\x2e\x3d\x1d\x78\x65\x0b\xf5\x2b\xa2\x52\xf5\xf2\x04\x76\x10\xe3\x0c\x26\x4d\x9b\x27\x30\xa1\x4b\xe9\xa0\x81\x3d\x1a\x0b\x58\x9c\x72\xbe\x27\x17\x08\x5f\xb7\x51\x64\x8c\xeb\xe1\x6d\x77\xae\x30\x80\x06\x76\xd5\xce\xb6\x86\x24\x7d\x0f\xda\x8f\x5f\x3c\x3d\x64\x88\x28\x83\x5e\x09\xf2\xd0\x1e\x57\xa9\x79\x0a\x10\xf0\xb9\xc8\x3d\xa9\x47\xdb\x1e\x49\x44\x9d\x80\x0a\xa9\x4c\x62\x1c\x09\xac\xc6\xd8\x10\x67\x01\xa7\xe2\x71\xbe\x19\xfb\xb8\x1e\x44\x53\xf0\x9a\xba\x39\xce\x7e\xd4\xf7\x08\x77\x73\x3d\xc2\x1d\x3d\x17\x1a\xe0\x33\x5c\x3f\xce\xdf\xec\x7b\x8b\xab\x4d\x9e\xc5\x5c\x52\x2d\xe2\x20\x01\x11\x56\xf4\x01\x5a\x0a\x63\x16\x71\xff\x7e\xd2\x96\x77\x45\xda\x35\xa4\xd5\x02\x8b\x39\x65\x45\xf5\xf4\x86\xf1\xea\x68\xd6\x8d\x6a\x69\x57\x36\x8a\xc1\xe0\xe9\x2c\x08\x26\x27\xfb\xc9\x01\x4f\xd1\x79\xf7\xfb\x26\xc0\x08\x9e\x9c\x86\xd0\x09\xf9\x03\x88\x76\x20\x0d\xbd\x06\xe7\xfb\x1e\x65\x04\x56\x0e\x1a\xb5\xc5\x31\x7e\x32\x7b\x7c\x56\x69\xb5\x84\x0a\x5f\x80\x0f\x8d\xd1\x6e\x59\x3a\x47\x1a\x70\x7f\x29\x8f\x44\x9a\xd3\x3b\x46\xca\x97\x4a\xef\xe9\x41\xa7\x9c\xf1\x55\x4f\x86\x98\xf0\x77\x0e\x1a\x98\x2b\xcf\x7d\x24\xe6\x33\x7c\x39\xb8\x46\xc3\xd1\xe3\x6b\x34\x1a\x8f\xaf\x35\x61\x7c\xd5\xc1\x6d\xc6\xd5\xa5\x43\xa8\xd4\x27\x31\xb9\xca\xbf\xda\xdb\xaf\x35\x77\xfd\xa5\x90\x1a\x94\x98\x53\xa6\x40\x9c\xd4\xde\xa9\x6d\xd5\x9b\x5b\x43\x7d\x55\xd2\x7f\x30\x69\xeb\x0a\xf5\x06\x75\x58\x47\xd5\xae\x70\x9c\xbf\x3d\xa6\xfd\x76\x86\x92\x26\x03\x8d\x3d\x6a\x7c\x6e\x93\xea\xe8\x8f\x8d\x7d\xdd\x72\x9a\x80\xdb\xe6\xd7\xb0\x6a\xb8\x4c\xee\xa0\x43\x3b\x68\xec\x1a\xd5\x1a\xa9\xb3\xb4\xbb\x5a\x66\x56\x93\xb3\xa5\xb6\x27\xfe\xd3\xf1\xd3\xda\x58\x56\x64\x5c\x4f\x8f\x44\x4a\x36\x55\x77\x99\x9b\x78\xa9\xf8\xc9\x98\x82\x13\x01\x1d\x16\xbd\x7e\x77\xfb\xc5\x94\xed\xf6\xcd\x54\x7e\xe1\xea\x39\xbb\x98\xc0\x09\x7d\x40\x39\x7d\x6a\x05\x11\xc7\xaa\x98\xf2\x2c\x44\xc9\xd4\xd2\x13\xfb\x1f\x7c\x01\x58\x81\xb5\x9b\xd2\xdd\x80\x8b\x05\x5a\x80\x0a\x39\x99\x5a\xaf\xbf\xbe\x7d\x6b\x21\xbd\x99\x9c\x4d\xad\xbe\x16\xe9\x17\x22\x88\xb3\xdb\xe5\x6c\x41\xd5\xd4\x12\xa0\x96\x82\x21\x43\x78\x8b\xe5\xfd\x25\xe1\xfe\x72\x01\x4c\xd9\x73\x50\xcf\x23\xd0\xcb\x5f\xad\x5f\x92\xcb\x47\x04\xa4\x2a\xb0\x7f\x74\x65\x3f\xe0\x68\x09\xd7\xa8\x95\x5b\xad\x63\xe8\xc0\x46\xe3\x0e\x4c\x02\x62\xc0\xaa\x64\xbc\xda\x0b\x59\x0f\xb9\x49\x82\x6c\x5f\x8a\xe0\x2b\x0a\x11\x41\x69\x5a\x21\xba\x12\x22\xf0\x15\x62\x78\x01\x53\x6b\x2f\x04\x03\xe4\xfe\x0f\x55\xad\xfa\x72\x79\xac\xf4\x56\xc7\x28\xc2\x33\x88\xa6\x56\xf6\xd7\xcd\xb7\xd9\xf7\xd9\xc7\xcd\x87\xec\xdf\x9b\x6f\xb2\x8f\x68\xf3\xa7\xec\x63\xf6\xdf\xcd\x9f\x8d\xb6\x06\x15\xa5\x1a\xca\x99\x97\xfd\x63\xf3\x97\xec\xfb\xec\x53\xf6\x1f\x94\xfd\x2b\xfb\x94\xfd\x98\xfd\xe0\xf6\x0b\xda\xa1\xed\x7e\x69\xbc\x8b\x5f\xff\xdc\x7c\xb3\xf9\x90\x7d\x97\xfd\xb8\xf9\xd6\xb8\xe2\xf3\x25\x53\x82\x82\xb4\xbc\xf3\x34\xfd\x2d\xfb\xb4\xf9\x90\x7d\xca\x7e\xc8\xbe\x2b\x34\x51\x75\xbe\x9a\x67\xb7\xaf\x8c\x34\x96\xec\x6c\x17\xfe\x6e\xb0\x29\x43\x79\xcf\x19\x39\xa2\xc3\xed\x9b\x2d\xf6\x8e\xec\xbb\x4e\xc6\xa2\x72\xf4\xaa\xd9\x09\x7d\x78\xe4\x59\x3d\xb5\x62\xca\xe6\x96\xf7\xfa\xe5\xab\x17\x47\x36\xa8\x2a\xa2\x4b\xd8\xf2\x7e\xfd\xfc\xd9\x4d\x67\x11\xa2\xa1\xb9\x79\x75\x8b\x2e\x43\x2e\x15\xe2\x02\xe9\xcf\x9e\x80\xf7\x3c\x7a\x00\x71\xd5\x59\x91\x12\xd8\x07\xc1\x97\x0a\x2c\xef\xed\x76\xdd\x2c\xde\x05\x2e\x53\x6f\x06\xb0\x62\x7d\xd2\x07\x49\xd9\x3c\x02\xcb\x23\x9c\x3d\x52\xc8\x48\x75\x0e\x60\xbc\xa0\xcc\xf2\xf4\x7b\x67\x91\xe1\x20\x97\x19\x0e\xce\x11\x7a\x6c\x84\x1e\x9f\x25\x34\x0c\xf9\x52\x58\x5e\xfe\xd1\xdd\x92\xe6\xb6\xbc\xc7\x67\x09\x3d\x31\x42\x4f\xce\x12\x1a\x8e\x0a\xff\x46\xe7\x89\x11\xbc\xb6\xbc\x21\xc1\xeb\xee\x22\xef\x00\xee\x2d\x2f\xff\x38\x2b\xb9\x28\x8b\x97\x0a\xe9\x22\x9c\x5a\x7a\xb2\xb6\x8a\x3c\xa3\xb1\xc9\x31\xfd\x59\xda\x18\x3d\xb5\x07\xf6\xc0\x1e\x5a\x28\x8e\xb0\x0f\x21\x8f\x08\x88\xa9\xf5\xf2\x75\xad\x6a\x2b\x4a\x65\x7e\x9a\x6d\x95\xdc\x70\x44\xd5\xcf\xf6\x04\xdc\xbe\x3e\x1a\x8b\x93\xb5\x4f\xe8\x43\xeb\x21\x9b\x4f\x4d\x93\x5d\xe3\x39\xf3\x94\xd5\x22\xa7\x4e\xd9\xdf\x73\x46\xda\x4f\x59\x8d\xcc\xff\x77\xd2\xb5\xa1\xac\xdf\x4d\x40\x66\x55\x80\x54\x03\x58\x7b\x95\x6f\x8b\xe5\xb5\xab\xad\xe1\xfc\x8c\x10\xa4\xe5\x8e\x23\x6d\xd6\xa1\x28\x91\xf6\x23\xc0\xc2\x41\x33\xae\xc2\x49\x21\x99\x6f\xc4\x76\xb0\x09\x68\xa4\x40\xb4\x42\xfe\xe2\xf9\xc1\x5c\xb3\x58\x5b\xc7\xfa\x99\xd9\x1e\xeb\x64\x82\x5b\x1e\x66\xeb\x42\x75\x7b\x55\x24\x09\xfa\xdc\xf0\x20\x67\x8a\xec\xdf\x2e\x41\xac\xed\x17\xa0\x50\x69\xa7\xbe\x33\x85\x90\xc0\x6c\x0e\xe8\xf3\x1c\xbb\x5c\xf2\x59\xce\x2e\x51\x9a\xd6\xfc\xd0\x16\xf2\x25\x4a\x53\x2b\x49\x10\x0d\x10\xfc\xb1\xfc\xa9\xb4\x9d\xa6\xc8\x04\x09\x24\x49\x10\x30\x9d\x10\xde\xbe\xe4\x36\x82\x2d\xb9\x4b\xad\x56\x80\x93\x0a\xab\xa5\xec\x0a\x9c\xe1\x3e\x0e\x9c\xe1\xa9\x03\x57\xd8\xe9\x0a\xdc\x6d\xce\x0e\x67\x23\x57\x18\xff\x89\x90\x6b\xab\x3f\x85\xc5\x1c\x76\x45\x93\x24\x95\xd0\x4b\x6a\x9a\xd6\x0a\xf2\x6d\x4e\x40\x3e\x67\x0a\x53\x26\x8f\x95\xe5\xbe\x35\x3d\xd1\xb6\xd9\x32\xb4\x03\x4b\x37\xad\x43\x70\xab\x15\xe9\x87\x40\x96\x11\xb4\x59\xda\xd1\x0f\xac\xdd\x96\xa4\xa3\x89\xc7\x45\x87\x99\xa3\x67\x1a\x2a\xb1\x3c\x06\xef\x40\x2a\x94\xff\xf1\x6f\x4f\xc0\x9a\x78\x29\xbd\xcd\x93\xcb\x4a\x08\xda\x85\x2b\xb4\xe5\x6a\x2e\x38\x1d\xd4\x71\xc3\x67\x66\x4b\x20\xf8\xa2\x0d\x55\x43\x3b\x40\xf4\x4b\xe3\x21\xca\xc9\x1d\xcd\x28\xde\x66\x44\xf1\x23\x26\x14\x3f\x66\xa0\x76\x3c\x7c\x75\xd0\xc7\x9b\x8f\x61\x9d\x3b\x31\x9e\x83\xb0\xdf\x00\x23\x20\xca\x7a\x73\xcd\xa3\x22\x73\x5b\x63\x6a\x0d\xca\x7f\x36\x8b\x05\xce\xff\x11\xec\xf4\x2a\x51\xf3\x4a\x85\x5e\xe9\x72\x1f\xdd\xc5\x44\x3b\xef\xf6\x55\x78\xc8\x76\x77\xf7\xf2\xa6\x99\xf2\xa5\x31\xd4\x4c\x7c\x03\x72\x19\x29\x59\x25\xba\xfd\x7d\x47\x92\xc4\x74\x7c\xbb\xe0\x4d\xd3\x63\x0e\x13\xdd\x81\xec\xd2\xe9\x34\x45\x7d\xa4\x7f\x28\x7c\xcf\x9b\x92\x22\x4d\x42\xb6\x3e\x81\x75\x14\x6d\x2c\x2e\x46\xa1\x80\xa0\x3c\x2b\x73\xad\x77\x2f\x6f\x74\x87\xd4\xe2\xe6\x14\x4a\x53\x94\x24\xf6\x6b\x2c\xf0\xc2\x2c\xdf\xe4\x13\xbc\xd6\x89\xbd\x66\xbd\x95\x1f\xf4\xcb\x8d\x05\x68\x8d\x26\x5e\x2d\xaa\x7f\xa8\xca\x55\x34\xd5\xf1\x82\x48\x42\x9a\xa2\x57\x5c\xa1\x40\xdf\x60\x41\x49\x02\x8c\x94\xd9\xd0\xcf\xd3\xe1\x70\x72\xdb\xdd\x2e\x0f\xe8\x0a\xc8\x04\x95\x0f\xe6\x06\x13\x33\xce\xe9\xfb\x9e\xbb\x1b\xe3\xfa\x49\x5a\xfe\xe4\xc5\x41\x5f\xc4\xab\xfd\xa7\x95\x7b\x19\x95\x24\xf6\x9d\x04\x91\xa6\xfd\x62\x65\x00\xfe\x65\x92\xd8\xbf\x03\x21\x29\x67\x5b\xaf\xf2\x69\xd2\xed\x9b\x5b\x37\x17\x6e\x3f\x54\x8b\xc8\xfb\xdf\x00\xf9\x99\xb3\x39\x70\x1d\x00\x00")
 
 func tasksHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -236,7 +257,7 @@ func tasksHtml() (*asset, error) {
 	return a, nil
 }
 
-var _zondsHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x58\xcd\x6e\xe3\xc8\x11\xbe\xfb\x29\x3a\x5c\x04\xb6\x01\xf3\x47\x32\x64\x67\x29\x8a\xc8\x24\xb3\x49\xe6\x10\xef\x60\x3d\x93\xc3\x5e\x82\x16\xbb\x24\x36\xdc\xec\x26\xba\x5b\x1a\xc9\x86\x80\xc1\x1e\x72\xd9\x20\x87\xe4\x90\x43\x4e\x79\x83\x5d\x60\x07\x49\x10\xac\x9f\x81\x7a\xa3\x45\xf3\x47\x96\x28\x52\xa2\x96\x06\xc8\xa6\xbb\xaa\xfa\xab\xaf\xab\xaa\x4b\x0c\x62\x9d\xb0\xf0\xec\x2c\x88\x01\x93\xf0\x0c\x21\x84\x02\x4d\x35\x83\xf0\x8f\x4b\xf4\xb5\xe0\x44\x05\x6e\xf1\x5e\xcc\x29\xbd\xac\xc6\xe6\x1a\x0b\xb2\x44\x4f\x9b\x57\x73\x4d\x04\xd7\xf6\x04\x27\x94\x2d\x7d\x74\xfe\x65\x0a\x1c\xdd\x63\xae\xce\xaf\x90\xc2\x5c\xd9\x0a\x24\x9d\x0c\x37\x1a\xab\xb3\xcd\x50\xe3\x31\x83\x9a\xb1\xb1\x90\x04\xa4\x1d\x09\xc6\x70\xaa\xc0\x47\xd5\x68\xb8\x23\xf6\x81\x12\x1d\xfb\xa8\xe7\x79\xbf\x6c\x37\x7d\xf5\xf2\x1a\x6f\x8d\x49\xe3\x92\x3e\xf2\x9a\x2d\x1d\x57\xb5\xc7\x42\x6b\x91\xf8\xa8\x97\x2e\x90\x12\x8c\x12\xf4\x19\x21\x64\x17\xb1\x86\x85\xb6\x31\xa3\x53\xee\x23\x06\x13\xbd\x3b\x3b\x07\xa9\x69\x84\x59\x25\xa1\x45\xba\x2b\x90\x62\x42\x28\x9f\xfa\xa8\x37\x48\x17\x5d\x2d\x6f\xbb\x21\x7d\xae\x63\x3b\x8a\x29\x23\x17\x30\x07\x7e\x59\x77\x05\x47\x0f\x53\x29\x66\x9c\x18\xf2\x85\xf4\xd1\x67\x93\xbe\xf9\x6b\x61\xa5\xa6\x1e\x03\x9d\xc6\xda\x47\x03\x6f\x1b\xde\x96\x86\x93\xe2\x29\xe5\x58\x53\xc1\x6b\xaa\x84\xaa\x94\xe1\xa5\x8f\xec\x44\xd9\x13\x06\x8b\xb1\xa8\x79\xb8\x91\x30\xb3\x8d\xbc\xd8\xc6\xf1\x9d\x2d\x34\x17\xa3\x4a\xdb\x79\x00\xfb\x88\x0b\x5e\x8b\xa1\x72\xf3\x24\x26\x74\xa6\x7c\xe4\x39\xfd\x81\x84\xe4\x18\x78\x5b\x25\x08\x5f\xb5\xcd\xa8\x14\xd7\xdd\xdb\xec\x5c\xb9\x00\xf2\x9c\xdd\x75\x50\x95\x43\x8a\x3e\x82\x11\xfb\xd5\xed\xbe\x00\xa3\x1c\xec\x8a\xe4\x9e\x33\x68\x08\x01\x02\x91\x90\x39\x92\xba\xb7\xed\x9e\x30\xea\x4f\xa8\x54\xba\x08\x8c\x03\x8e\xd5\x04\x1b\xfc\x2c\xe9\xd4\x22\xcd\x37\x63\x9b\xd7\x3d\x6f\x76\xf2\xe6\xb0\xf8\x41\xec\x0c\x77\x83\xbe\x25\x77\x18\xb9\x34\x04\x9f\x00\xfd\xb0\x7c\x4b\xf8\x37\x43\x6d\x8c\x1d\xa1\x68\xb1\xa1\x12\x18\xd6\x74\x0e\x2d\x89\x31\x66\x22\x7a\x68\xa9\x18\x45\xbc\x21\xcf\x69\x08\xab\x04\xcb\x29\xe5\x65\xf6\xd8\xbd\x7a\x69\xa9\x45\x5d\xbf\x16\x76\x55\xa1\xf0\xbc\xdb\xf1\x64\x32\x3c\x5a\x4f\xf6\x64\xca\xca\xbb\x5d\x37\x01\xfa\x70\x73\x9c\x42\x3f\x16\x73\x90\xed\x44\x16\xf3\x35\x3a\x1f\x6d\xca\x09\x2c\x7c\xd4\x6f\xf3\x63\x70\x33\xbe\x3e\x29\xb5\x5a\x5c\x85\xcf\x21\x82\x46\x6f\x37\x22\x9d\x3d\x9d\x88\x68\xa6\x0e\x78\x9a\xcf\x77\xf4\x54\xcc\xb4\xd9\xd3\xbd\x4a\x39\x16\x0b\x5b\xc5\x98\x88\x0f\x3e\xf2\x8a\xbf\x3c\x96\x91\x9c\x8e\xf1\x85\x77\x85\x7a\xfd\xeb\x2b\xd4\x1f\x0c\xae\xf2\x32\x76\xd9\x01\x36\x17\xfa\xc2\x27\x54\x99\x93\x98\x5c\xe6\xaf\xce\xe6\xb5\x06\x37\x9a\x49\x65\x48\x49\x05\xe5\x1a\xe4\x51\xeb\x9d\xca\x56\x87\x9a\xb5\x13\xfe\x7b\x94\xb4\xd6\xb3\xfd\x3c\x3a\x5c\xd0\xba\x9c\x2b\xf5\x72\x76\x42\x8d\x3a\x06\xe7\x88\x42\x2b\x1e\x07\x47\xa6\xe4\x34\x11\xb7\x89\xaf\x5e\x73\x26\xed\xa7\xfa\x7e\x8e\x34\x56\x8d\xdd\x1c\xa9\x8b\xb4\x43\xad\x22\xab\x09\x6c\x65\xed\x26\xba\x1d\xdc\xd6\xda\xb2\x32\xe2\x6c\xd3\x12\x69\xd5\x94\xdd\x55\x6c\xe2\x99\x16\x3f\xb7\xc4\x1d\x48\x7a\x73\x0f\xdc\xb2\xcb\x0e\xdc\xa2\x2b\x3f\x0b\x4c\x9f\x5d\x76\xe0\x84\xce\x51\x3e\x3f\xb2\x26\x4c\x60\x5d\x76\x79\x16\xa2\x64\x64\x69\xac\x1e\xfe\x1c\x49\xc0\x1a\xac\x97\x2e\x3d\x98\x08\x99\xa0\x04\x74\x2c\xc8\xc8\x7a\xfb\xe5\xfd\x3b\x0b\x99\xcd\x14\x7c\x64\xb9\x46\xc5\x2d\x55\x90\xe0\xf7\xb3\x71\x42\xf5\xc8\x92\xa0\x67\x92\xa3\x62\xe2\x1d\x56\x0f\x17\x44\x44\xb3\x04\xb8\x76\xa6\xa0\xbf\x60\x60\x86\xbf\x59\xbe\x21\x17\xe7\x04\x94\x2e\xb9\x3f\xbf\x74\xe6\x98\xcd\xe0\x0a\xb5\x4a\xeb\x65\x0a\x1d\xc4\x68\xda\x41\x48\x42\x0a\x58\x57\x82\x97\x5b\x2e\x9b\xeb\xe9\x09\x39\x91\x92\x93\xdf\x51\x60\x04\xad\x56\x3b\x93\x81\x02\x06\x91\x46\x1c\x27\x30\xb2\xb6\x5c\x28\x88\xdc\xfe\xc7\xae\xd5\x5c\x59\xa4\xda\x6c\x75\x8a\x18\x1e\x03\x1b\x59\xd9\xdf\xd7\xdf\x66\xdf\x67\x9f\xd6\x1f\xb3\xff\xae\xbf\xc9\x3e\xa1\xf5\x5f\xb2\x4f\xd9\xff\xd7\x7f\x2d\xac\x35\x98\xa8\xcc\x50\xc1\xc3\xec\x5f\xeb\xbf\x65\xdf\x67\xcf\xd9\xff\x50\xf6\x9f\xec\x39\xfb\x31\xfb\x21\x70\xcb\xb9\xfd\xb5\xdd\x6a\xf1\x2e\xb8\xfe\xbd\xfe\x66\xfd\x31\xfb\x2e\xfb\x71\xfd\x6d\x01\x25\x12\x33\xae\x25\x05\x65\x85\xa7\x59\xfa\x47\xf6\xbc\xfe\x98\x3d\x67\x3f\x64\xdf\x95\x96\xa8\x3e\xdd\xcc\xab\xfb\xbb\x42\x1b\x2b\x7e\x32\x84\x7f\x16\xdc\x54\xae\x3c\x9a\x9f\xa7\xed\x36\x02\xb7\xd8\xe2\xf0\xc0\xbe\x9b\x60\x2c\x33\xc7\x8c\x9a\x41\x98\x9a\x92\x07\xd8\xc8\x4a\x29\x9f\x5a\xe1\xdb\x37\x77\xbf\x3f\xb0\x41\xbb\x2a\x26\x85\xad\xf0\x0f\x5f\xbc\x7a\xdd\x59\x85\x18\x6a\x5e\xdf\xdd\xa3\x8b\x58\x28\x8d\x84\x44\xe6\x69\x4b\x78\x14\x6c\x0e\xf2\xb2\xb3\x21\x2d\x71\x04\x52\xcc\x4c\x35\x78\xb7\x19\x37\xab\x77\xa1\xab\xc8\xb7\x82\xb0\x72\x7c\x14\x83\xa2\x7c\xca\xc0\x0a\x89\xe0\xe7\x1a\x15\x5a\x9d\x1d\x18\x24\x94\x5b\xa1\xb9\x77\x56\xe9\x79\xb9\x4e\xfe\xe8\xac\x74\x5d\x28\x5d\x9f\xa4\xd4\x8b\xc5\x4c\x5a\x61\xfe\xe8\xbe\x52\xa1\x74\x7d\x92\xd2\x4d\xa1\x74\x73\x92\x52\xaf\x5f\xe2\xeb\x9f\xa6\x46\xf0\xd2\x0a\xcd\xbd\xbb\xca\x07\x80\x07\x2b\xcc\x1f\x27\x05\x17\xe5\xe9\x4c\x23\x93\x7a\x23\xcb\x74\xd6\x56\x19\x67\x34\x2d\x62\xcc\x3c\x37\xde\xdc\x3a\x9e\xe3\x39\x3d\x0b\xa5\x0c\x47\x10\x0b\x46\x40\x8e\xac\x37\x6f\xad\x03\x46\x55\x7e\x9a\x6d\x8c\xbc\x16\x88\xea\x5f\x6c\x9f\x8c\xae\x39\x1a\xcb\x93\xd5\x25\x74\xde\x7a\xc8\xe6\x5d\xd3\xf0\xa5\xf0\x9c\x78\xca\x1a\x95\x63\xa7\xec\xd7\x82\x93\xf6\x53\xd6\x30\xf3\xf3\x4e\xba\x36\x96\xcd\xbd\x70\xa8\x18\x95\x24\xd5\x08\x36\xa8\x72\x85\x13\x78\x7e\x45\x48\xfe\xf1\xf0\x30\xd3\xc5\x38\x96\x15\xd3\x11\x03\x2c\x7d\x34\x16\x3a\x1e\x5a\x61\xee\x54\x8a\xa7\x20\x9d\xaf\x80\x13\x90\x95\x5f\x41\xf1\xa1\xb0\x68\xa5\x46\x96\x57\x9d\x6b\x49\x82\xf3\xf3\xe0\x65\x49\x2d\x6b\x90\x75\x1c\xfe\x36\xa7\x9a\x20\x17\xbd\x4f\x89\x19\x05\xae\x8e\xf7\xc5\xde\xbf\x7f\xf3\xba\x79\xe6\x0e\x27\xb0\x3b\x13\xb8\xdb\x0b\x3d\x3d\x49\xcc\xa7\x80\x9c\xaf\x40\xcd\x98\x56\x5b\xbb\xd1\x00\x88\x84\xc6\xcd\x0a\xd4\x6a\x85\xdc\xdc\xef\x12\x1b\x5a\xad\x02\x57\x93\x66\x25\x03\xf1\xa0\x80\x41\x6a\x4c\xee\x4a\xd4\xd1\x02\x53\xb0\x5a\xa1\x3b\xa1\xd1\xc4\x34\xaf\xe6\x5f\x9c\x54\x5c\xbb\x39\xd9\xfb\x59\xf1\xf2\x29\x62\x42\x17\x40\x86\xa8\xfa\xe8\xe9\x0d\x8b\x54\xc9\x47\x9b\x8f\x0e\x83\x74\x31\xcc\xbf\x6a\xf9\xe8\x73\xf3\xeb\xfe\xe5\x4b\xb0\xb5\x8d\xc5\x79\xaf\x40\xae\x56\x6e\x39\x32\x1e\xae\x56\xbf\x7e\x7a\x72\xfe\x04\x52\x51\xc1\x37\xa8\xf2\xf8\x09\xdc\xa2\x2d\x36\x7d\xb2\x4e\x58\xf8\x53\x00\x00\x00\xff\xff\x3d\x1e\x5f\x0e\xcc\x16\x00\x00")
+var _zondsHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x5a\xdd\x8e\xdb\xc6\xf5\xbf\xf7\x53\x9c\x3f\x93\x3f\x24\x21\x12\x25\xad\xbb\x76\xa3\x95\xe4\xba\xb6\x13\xbb\x48\xec\xd4\xbb\x6e\x83\xa6\x41\x30\x4b\x1e\x8a\x93\xa5\x66\xe8\x99\xe1\xee\xca\x0a\x81\x20\x17\xbd\x49\xd1\x8b\xf6\xa2\x17\xed\x4d\xdf\x20\x01\x62\x34\x6d\x11\x3f\x83\xf6\x8d\x8a\xc3\x21\xf5\x41\x51\x5f\x41\xba\x24\x56\x24\xe7\x9c\x33\xe7\xfc\xce\xc7\x7c\x90\xfd\xd0\x8c\xa3\xe1\xad\x5b\xfd\x10\x99\x3f\xbc\x05\x00\xd0\x37\xdc\x44\x38\xfc\x70\x02\xbf\x93\xc2\xd7\xfd\xb6\xbd\xb7\x6d\xda\x4c\x8a\x6b\x3a\xce\xa5\x3f\x81\xe9\xfc\x96\xce\x40\x0a\xd3\x0a\xd8\x98\x47\x93\x1e\xd4\x9e\xc5\x28\xe0\x94\x09\x5d\x6b\x82\x66\x42\xb7\x34\x2a\x1e\x9c\xcc\x39\xd2\x5b\xf3\x4b\xc3\xce\x23\x2c\x09\x3b\x97\xca\x47\xd5\xf2\x64\x14\xb1\x58\x63\x0f\x8a\xab\x85\x04\x3a\xae\xb8\x6f\xc2\x1e\x74\x3b\x9d\xff\xdf\x2c\xba\xb9\xb8\x0d\x97\xae\xfd\xca\x2e\x7b\xd0\xa9\x96\xb4\x9b\xb5\x75\x2e\x8d\x91\xe3\x1e\x74\xe3\x6b\xd0\x32\xe2\x3e\xbc\xe5\xfb\xfe\x42\x1c\x1d\x06\xaf\x4d\x8b\x45\x7c\x24\x7a\x10\x61\x60\x56\x5b\x2f\x51\x19\xee\xb1\xa8\xa0\x30\x32\x5e\x25\x88\x99\xef\x73\x31\xea\x41\xf7\x38\xbe\xde\x57\xf2\xb2\x19\xaa\x27\x4c\xd8\xf2\x42\x1e\xf9\x75\xbc\x44\xd1\x28\x9b\xc2\xbc\x8b\x91\x92\x89\xf0\x09\x7c\xa9\x7a\xf0\x56\x70\x44\x47\xb5\xb8\xb0\xc4\x1e\x22\x1f\x85\xa6\x07\xc7\x9d\xf8\xba\x92\xc3\x8d\xd9\x88\x0b\x66\xb8\x14\x25\x56\x9f\xeb\x38\x62\x93\x1e\xb4\xc6\xba\x15\x44\x78\x7d\x2e\xaf\x4f\xaa\x29\xa8\xb5\x12\x97\x16\x19\xbe\xe2\x42\x3a\x23\xae\x4d\x2b\x0b\xe0\x1e\x08\x29\xf0\xa4\xca\x79\x8a\xf9\x3c\xd1\x3d\xe8\xb8\x47\xc7\x0a\xc7\xbb\x94\x6f\xe9\x31\xb0\xe6\xa6\x16\x1d\xb3\xb2\x79\x73\xcf\xe5\x1d\x40\xc7\x5d\xed\x67\x9e\x43\x9a\xbf\x42\xd2\xe3\xe7\x77\xd7\x09\x22\x2e\xb0\x55\x80\xdc\x75\x8f\x57\x5b\xb3\x10\xf0\xd1\x93\x2a\xd3\xa4\x6c\xed\x66\x4b\x22\xde\x0b\xb8\xd2\xc6\x06\xc6\x16\xc3\x4a\x84\x15\x76\xe6\x70\x1a\x19\x67\xce\x58\xc6\x75\xcd\x9a\x9c\xd6\xe6\xcd\x76\xf2\xad\xba\x47\x6c\x3f\xd5\x23\xb6\xa7\xe6\x8a\x00\x3e\x40\xf5\xed\xf4\xd5\xba\x6f\x50\xb5\x4a\xb3\x58\x6a\x4e\x2c\x3d\x50\x18\x31\xc3\x2f\x71\x43\x62\x9c\x47\xd2\xbb\x38\xd9\x14\x77\x79\xd8\x55\x84\xd5\x98\xa9\x11\x17\x79\xf6\xb4\xba\xf1\xf5\xd6\xa8\x3b\x2a\x85\x5d\x51\x28\x3a\x9d\xbb\xe7\x41\x70\xb2\xb3\x9e\xac\xd1\xe4\x95\x77\xb9\x6e\x22\x1e\xe1\x9d\xdd\x10\xf6\x42\x79\x89\x6a\x33\x90\xb6\xbd\x04\xe7\xab\x16\x17\x3e\x5e\xf7\xe0\x68\x93\x1d\xc7\x77\xce\x6f\x1f\x94\x5a\x1b\x4c\xc5\x77\xd1\xc3\x4a\x6b\xe7\x24\x7b\x5b\x1a\x48\x2f\xd1\x5b\x2c\xcd\xda\xf7\xb4\x54\x26\x86\x7c\xba\x56\x29\xcf\xe5\x75\x4b\x87\xcc\x97\x57\x3d\xe8\xd8\x23\x8b\x7d\x50\xa3\x73\x56\xef\x34\xa1\x7b\x74\xbb\x09\x47\xc7\xc7\x4d\x6a\x38\x6e\xec\xa1\xb6\x90\xa6\xde\xf3\xb9\xa6\x91\xd8\x6f\x64\xb7\xee\xfc\xb6\xa4\xae\x97\x28\x4d\xa0\xc4\x92\x0b\x83\x6a\xa7\xf4\xbd\xca\x56\xb9\xb8\x55\xe4\xd7\x4a\xf8\x77\x4e\x36\x55\x85\x72\x81\x5a\xcf\xa3\xd5\xaa\xb0\x9d\x7e\xb3\x4d\xcb\xe5\x0c\xa6\x55\x1d\x54\xd6\xa8\xe3\x43\x8b\xd4\x9e\xfa\xb8\xcc\xa3\x92\x53\x05\xdc\x3c\xbe\xba\xab\x1d\x17\xc1\x1d\xec\x51\x0e\x2a\xab\xc6\x6a\x8e\x94\x49\x36\xab\x5a\x44\x56\x95\xb2\x85\xb4\x3b\xde\xdd\xe3\xbb\xa5\x69\x59\x1e\x71\x2d\x9a\x12\x19\x5d\x95\xdd\x45\x6c\xb2\xc4\xc8\x9d\x36\x05\x3b\x0c\x5a\x4f\x7a\xfa\xdf\x6f\x2f\xcd\xb2\xfb\xda\x53\x3c\x36\x8b\x29\x77\x90\x08\x2f\x33\x93\xc5\xfc\x39\xbe\x4c\x50\x9b\xfa\x18\x4d\x28\xfd\x26\x24\x2a\x6a\x42\xcc\x26\x91\x64\x7e\x13\xa4\x38\x4d\x3c\x0f\xb5\x2e\x4f\xee\x2e\x99\x82\xeb\x50\xc1\x00\x04\x5e\xc1\xc7\x1f\x7e\xf0\xd8\x98\xb8\x90\xd5\x38\x59\xa0\x4a\xc7\x75\xa8\x5c\x19\xa3\x58\xee\xa4\x71\xb2\x46\xa2\xd1\xe4\x12\x1e\x23\xf3\x51\xd5\x6b\x0f\xa4\x30\x28\x4c\xeb\x6c\x12\x63\xad\x09\x35\x16\xc7\x11\xf7\xb2\x5c\x6c\x7f\xae\xa5\xa8\xed\x25\xe5\xe3\x56\xfe\x00\xfd\xd6\x6f\xb9\x09\x49\xd2\xf5\x38\x0a\x8d\x89\x95\x6d\xa8\x92\x73\xc5\x4d\xf8\x40\xa1\x8f\xc2\x70\x16\x69\x18\x80\x51\x09\xee\xd7\xdf\x83\xd3\xe7\xef\xb5\xce\xe4\x05\x8a\x5a\x13\x7c\xe9\x25\x63\x14\xc6\x7d\x99\xa0\x9a\x9c\x62\x84\x9e\x91\xaa\x5e\xe3\x22\x4e\xcc\x27\x82\x8d\x71\x60\x88\xf4\xd3\x5a\xc3\xbd\x64\x51\x82\x15\xca\x48\x41\xfe\x80\xc1\xc2\x75\xf5\xb2\x47\x0a\xaf\xf8\xcc\x30\x18\xc0\x34\x5d\x95\x42\x87\x51\xe5\x95\x56\xf1\x97\x33\xfd\xea\xf4\xd9\x53\x37\x66\x4a\x63\x9d\x20\x50\xa8\x63\x29\x34\x9e\xe1\xb5\x29\x29\x45\x67\x0a\x1e\x33\x5e\x08\x75\xac\xd2\x85\x0e\x16\xa1\x32\xf5\x5a\x8e\x0f\x04\x8c\x47\xe8\xbb\x00\xcf\xd1\x24\x4a\x50\x6e\x19\x66\x12\x0d\x32\x80\x1a\xbc\x93\x59\x6a\x9f\x54\xf4\x46\xa7\xca\xf8\x2a\x34\x59\x7b\xc2\x03\xa8\x93\x51\x6e\xde\xc3\x60\x00\x8e\xbc\x70\x36\x29\x3a\x8f\xf3\x8c\xa9\xd2\x56\x8c\x34\x2e\xc4\xa2\x52\x52\x6d\x37\x7b\x41\xe7\x8e\x51\x6b\x36\xc2\xcd\x72\xff\xf7\xf0\xa5\x2b\x4f\x4a\xc1\x91\x01\x8f\xc2\xaf\xe7\x89\x0f\xf7\x6c\x28\x68\xa3\xb8\x18\xf1\x60\x52\x34\x34\xa0\x07\x22\x89\xa2\x46\x65\x05\x9d\x07\xa7\x42\x22\x5e\x0b\xd1\x48\xda\xdc\x75\x8b\xf6\x5d\x52\x28\x39\xee\x8f\x50\x98\xfa\x05\x17\x54\x37\x12\xee\x37\x81\x9e\x96\x45\xd3\x33\x18\x40\xac\xe4\x38\x36\x75\xe7\x29\x5e\x65\x64\x4e\x4e\x7d\x72\xab\x1c\x1c\x55\x42\xe8\x58\x2a\x89\xb5\x8f\xee\x9f\x3d\x78\x4c\xd5\xa2\xcd\x62\xde\xbe\xec\xb6\x29\x48\x49\x13\x78\x07\x6a\xd9\x8d\x55\x68\x9a\x75\xd2\xcb\xfe\x43\xda\xa4\xa9\xb5\x64\xfe\x92\x75\xab\x0e\xa8\xb2\x15\x7d\x6e\x3e\x60\xe7\x18\xe9\xba\x95\x19\x65\x37\x65\x0d\xed\xd3\x25\x43\x2d\x4f\x13\x3c\x39\x1e\x33\xd0\x18\x33\xc5\x0c\xfa\x70\x81\x93\x41\x56\x4c\x40\x2a\xba\x71\xe6\x12\xd7\xa1\x28\x84\x0e\x06\xd6\xb7\x15\xa8\x54\x25\xde\x6a\x44\x51\xe9\x29\xa2\x67\xbd\xfa\xd8\x2e\x5c\x1d\x47\xdc\xd4\x9d\xa6\xd3\x70\x03\xa9\x1e\x31\x2f\xac\xcf\x11\xa8\x67\x34\x55\x9d\x67\x0d\x30\xb0\x06\xb8\x46\xf1\xf1\x72\xe4\xac\x59\x52\x25\xa2\xd0\xf0\xe2\x72\x2e\x27\xd7\x65\xe0\x54\xc8\xa2\x33\x37\xe6\x93\x8b\xcb\x4f\x3a\x9f\xe6\xbd\x7e\x0a\x03\xb8\xb8\x74\x23\x14\x23\x13\xc2\x10\xba\x70\x8f\xee\x75\xc4\x3d\xac\x77\x1b\xee\xe7\x92\x8b\xba\x33\x70\x1a\x39\x3d\xf4\xc0\x71\xd6\xc5\xaf\x22\x97\x96\x14\xd8\x1a\x81\xaf\x68\x3f\x6d\x25\xf4\x32\x9b\x75\x6f\x0e\x7e\x55\xfc\x55\x45\x5c\x3e\xbf\x59\x4f\xaf\x62\xe2\x53\xc6\xf1\xc7\x65\x46\x21\xad\x37\xbf\xda\x5b\x43\x25\x0d\x33\x6b\xf9\x5f\x56\x8b\xfc\xee\x49\x11\x70\x35\xae\x3b\x0f\x12\xa5\x50\x18\x78\xf1\xe2\xc9\x43\x60\xc2\x07\x8d\x9e\x42\x03\x57\x3c\x8a\x40\x1b\x19\xc3\x95\x54\x17\x5c\x8c\x80\x19\x90\xc2\xc3\x26\x30\x92\x0f\xe3\x44\x1b\x38\x47\x50\xa8\x0d\x53\x94\x41\x34\xfe\x83\x09\x31\x9b\xe0\x48\x81\xda\x05\x9a\x8e\x70\x91\xe0\x3d\xa7\x51\xd6\x62\x0d\xa0\x67\xa7\x67\x3b\xf1\xc9\x6e\xac\x95\xb5\x66\x96\x7b\xcd\x85\xf5\x76\x24\xaa\xe8\x86\xce\xe5\x3a\x47\xb6\xf6\xc0\x81\x77\xf2\xa1\x89\x42\x04\xbe\xf8\x02\xb2\x9b\x31\x13\x6c\x84\xaa\xe1\xe6\xfd\x39\xbf\x17\xa7\x19\x24\x4d\xe0\x06\xb8\x06\x1d\xca\x2b\x01\x52\x44\x93\x0c\x8f\x9e\xd3\xb4\x8c\x16\xb8\x25\x0f\x2d\x1f\xeb\xf5\x7b\x53\x30\xa7\x5b\x3d\x8c\x42\xc9\x28\xb2\x1e\xae\xf2\xed\x36\x44\x57\x33\x21\x83\xd2\x8a\x3b\x00\xca\x02\xc6\x67\x02\x5b\x86\x8f\x31\x57\x88\x66\x6b\x90\xcd\xc9\x9a\x70\xc9\x68\x17\x21\x90\x0a\x8e\x7e\x06\xa1\x4c\x94\x9e\x43\x64\x89\x3f\xcb\x08\xcb\x66\x37\x4e\xb6\x1a\xee\x63\x84\x07\x86\xf6\xc3\x8c\x05\x9c\x85\xc1\xce\x3d\x78\x62\x34\x18\xa6\x2f\xb4\x8d\xf0\x2c\x80\xf3\xb9\x81\x91\xf0\x32\xc1\x04\xdd\xdd\xc1\xfa\xf0\xd1\x07\x8f\xce\x1e\xed\x0c\xd7\x02\xd7\xb5\xe4\x2d\x3b\x3a\x5f\x81\xe4\xab\x8e\x7e\xdb\xbe\x18\xb8\xd5\xa7\xad\xfe\x7c\x49\xe2\xf3\x4b\xc8\x96\x28\x03\x27\x88\x24\x33\xf9\x46\xb3\x03\xdc\x1f\x38\x64\xd1\x67\x9e\x42\x66\xd0\x59\xac\x5a\xfa\x81\x54\x63\xb0\x4b\x88\x81\x43\xe1\xe0\x00\xad\x27\xa5\x18\x38\x6d\x62\x69\xe7\x2c\xd9\xaa\xe5\x7c\xcc\xcd\xc0\xb1\xe3\x16\xd8\x86\x33\xa6\x2f\xea\xf3\xd9\xf8\x08\xcd\xa3\x08\xe9\xf2\x97\x93\x27\x7e\xbd\xe6\xa3\x36\xf9\xf2\xaf\x98\x88\x37\x61\x23\xb5\xa1\x15\xc9\x6e\x32\x1e\xef\x41\xa4\x30\x46\x66\x0a\xc2\xc6\x92\xc9\xb4\xcf\x3e\x9d\x82\xeb\x69\x15\xbc\xc7\x31\xf2\x21\x5d\xe0\x4c\x47\x5f\x67\xcb\x89\x6c\xe6\x31\x70\x96\x4c\xb0\x40\x2e\x3f\x58\x95\x4a\x47\x5f\xc6\x86\x56\x9b\xb1\x1d\x11\x07\xce\xec\xcf\x37\x5f\xcf\xbe\x9d\xbd\xbe\xf9\x72\xf6\xfd\xcd\x57\xb3\xd7\x70\xf3\x87\xd9\xeb\xd9\x7f\x6e\xfe\x68\xa5\x55\x88\x28\xc4\x50\x96\x65\x58\x10\xd5\xec\x6f\x37\x7f\x9a\x7d\x3b\x7b\x33\xfb\x17\xcc\xfe\x39\x7b\x33\xfb\x61\xf6\x5d\xbf\x6d\x89\x2a\x94\x68\x17\x5a\xec\xa3\xe0\x3f\x6e\xbe\xba\xf9\x72\xf6\xcd\xec\x87\x9b\xaf\xad\x4e\x9e\x4c\x84\x51\x1c\xb5\x33\x3c\x4c\xd2\x5f\x66\x6f\x6e\xbe\x9c\xbd\x99\x7d\x37\xfb\x26\x97\xc4\xcd\xe1\x62\xee\x9f\x3e\xb5\xdc\x4c\x8b\x83\x55\xf8\xfb\xec\xf5\xcd\x57\xb3\x7f\xcf\xbe\xb7\x22\x32\xcd\x0e\x16\xf2\x57\x0b\x70\x81\x47\x56\x15\x37\xcb\xe8\xb7\x6d\xc0\x0c\xb7\x44\x11\x85\x76\x9e\x87\x74\x55\xad\xc4\x92\xc3\x63\x2e\x46\xce\xf0\xa3\x27\x4f\xdf\xdf\xe2\xe5\x55\x16\x2a\x08\xce\xf0\xf1\xa3\xfb\x0f\xf7\x66\xf1\x09\xdf\x87\x4f\x4f\xa1\x1e\x4a\x6d\x68\x56\x4b\xbf\x2d\x85\xaf\x64\x74\x89\xaa\xb1\xb7\x20\xa3\x98\x87\x4a\x26\x54\x5b\xce\xe6\xd7\xd5\xec\xfb\xc0\x65\xb3\xd7\x02\x96\x5f\xef\xd4\x41\x73\x31\x8a\xd0\x19\xfa\x52\xd4\x0c\x58\xae\xbd\x0d\x38\x1e\x73\xe1\x0c\xe9\xff\xde\x2c\xdd\x4e\xc6\xd3\xed\x1c\xc2\x74\xdb\x32\xdd\x3e\x88\xa9\x4b\x43\xa4\x33\xcc\x7e\xf6\xef\x89\xa8\x9d\xe1\xed\x83\x98\xee\x58\xa6\x3b\x07\x31\x75\x8f\x72\xfd\x8e\x0e\x63\xf3\xd9\xc4\x19\x76\x7d\x36\xd9\x9f\xe5\x0a\xf1\xc2\x19\x66\x3f\x07\x05\x57\xb6\x2d\x04\x94\x84\x03\x87\x5e\x15\x38\x79\x9c\xf1\xd8\xc6\x18\xfd\x16\x7d\x1c\xdd\x75\x3b\x6e\xc7\xed\x3a\x10\x47\xcc\xc3\x50\x46\x3e\xaa\x81\xf3\xe4\xa3\x52\xd6\xae\x08\xd5\xd9\xd8\x38\x17\xf2\x50\x02\x37\xff\xb7\xc4\xd0\x6f\xd3\x40\x9b\x8f\xd3\x6d\x9f\x5f\x6e\x1c\xb2\xb3\x6d\xe0\x93\x45\xe1\x39\x70\xcc\x26\x96\x5d\x63\x36\x7d\x3f\xb0\x79\xcc\x26\x64\x7e\xdc\xb8\xb9\x09\x65\xfa\x6f\x0d\xb2\x57\x39\x48\x25\x80\x49\xab\xcc\x2d\xce\x70\xb3\xd8\x12\xce\xf7\x7d\x3f\xfb\x1a\x62\x3b\xd2\xf6\x3a\x54\x05\xd2\x5e\x84\x4c\xf5\xe0\x5c\x9a\xf0\x24\xe7\xcc\x1c\x41\x1a\xd2\xcb\xe8\xcf\x02\x1e\x19\x54\x1b\x21\x7f\xff\xd1\x1a\xe2\xe3\x89\xb3\xad\x9e\x69\xa9\xf6\xa8\x60\x2d\xeb\x1e\xdf\x19\x0a\xbc\xca\xf6\xf6\xe8\xed\xc9\xde\xd9\x51\x70\x4f\xa7\xb4\xa5\x86\x2f\xa1\xee\xfe\x3a\x41\x35\x71\xdf\x47\x03\x56\x85\x06\xcc\xa9\x20\x4d\xc1\xea\x88\xfe\x74\x0a\x28\xc8\x9f\x43\xf2\xc5\xf6\x8e\x0f\xcc\xb0\x40\xc9\xf1\xdc\x5d\x14\x3e\x4b\x2a\xd9\xb6\x34\x2d\x05\xc2\x03\xab\x21\x64\xcd\x7b\x76\x63\xe4\xa6\x4e\x8c\xdc\xd2\x85\x91\xdb\x3a\x28\x05\xdb\x7b\x6b\x51\x51\x9d\xd4\x64\x65\x4c\x0b\x44\xf7\x39\x0a\x1f\x55\x91\x27\x7d\xfb\x25\x8d\x7d\xeb\x33\x70\x3a\xc5\x64\x6b\x3c\x66\xd9\xfc\x62\x21\xd7\xa8\x92\x56\x26\x1c\x16\x2a\xb7\xe1\x45\xec\x93\xf2\xfd\xb6\x09\xd7\xc9\x68\xe1\x5a\xdd\xf2\x94\x8d\xb1\xba\xc5\xee\x77\x55\xb7\x9d\xd2\x42\xba\xba\x69\xf5\x69\xbf\xbd\xac\xf5\x74\xaa\x98\x18\x21\xb8\xcf\x51\x27\x91\xd1\x69\xba\xcd\x3a\x7f\x48\x98\x15\x16\xa6\x29\xb4\x81\x1e\xe4\x86\x42\x9a\xf6\xdb\xc6\xaf\x66\x22\x7b\xb7\x12\x90\xd9\x14\xeb\x1b\x29\xac\xa2\x6f\x5f\xe0\xa4\x09\x6f\x67\xae\x86\xde\x00\x5c\x0b\x0a\xa4\xe9\x74\x9a\x35\xda\x2b\x1e\x14\x34\x69\x3a\x98\x4e\x17\x37\xf3\x14\x82\xf9\xd5\xc6\x1e\x79\x00\xee\xc3\xf9\xc6\x4d\x5a\xec\xe1\x10\x23\xed\x87\xa7\x29\x8a\xf9\x83\x2d\x92\x56\x1e\xd0\xd9\x3f\x4f\x8c\x91\xb4\xf1\xe0\x45\xdc\xbb\x18\x38\xcb\x9b\xbd\x35\xaa\x53\xf4\xb9\x57\x6d\x09\xb6\xe2\x36\x07\xa9\xd6\x70\x86\x96\xa7\xdf\xb6\xb2\xf6\xe8\x64\x69\x97\xb5\x42\xf4\x4f\x8c\x6e\x73\x7e\x45\xba\x46\x79\xe0\x6e\xd2\x75\x1d\xeb\xdd\xe6\xe4\xde\xd8\x0e\x5a\xc0\x22\x8d\x0d\x67\x68\x1d\xb5\x55\x81\xdc\xa5\x3f\x51\xc7\xf4\xba\xac\xe1\x0c\x73\xda\xed\x1d\x8b\xfd\x0c\x5e\xde\x11\xac\xee\xb6\x91\x0d\x48\xe0\x2d\x5e\xdc\x6d\xee\x78\x4d\xfc\xf2\x76\x54\x59\xaa\x47\xdf\xd3\x05\xf4\xf2\xf1\x90\x80\x5b\xde\xe7\xd9\xa8\xb0\x25\xaa\x96\xba\x9a\x4e\xe5\xc2\x45\x0e\x4b\x53\x78\x2a\x0d\x04\xf4\xd6\x18\xa6\x53\x14\x7e\x8e\x64\xbf\x9d\x15\xf1\xf5\xd9\xdb\xe2\x1b\xa0\x80\x5f\xa3\x7f\x02\xc5\xd7\x86\x9d\x13\x3b\xa5\xa3\x8f\x39\x16\x5f\xfb\xd0\xe7\x81\xd9\xe7\x64\x3d\x78\x37\xbe\x5e\xfe\x04\x73\x69\x1c\x98\x4e\xdd\x17\x1a\x55\x9a\xb6\xf3\x2b\xb2\x2f\x4d\x7f\x31\x9d\xba\xbf\x41\xa5\xb9\x14\x73\xad\xb2\x19\x65\xbf\x6d\x37\x83\x6e\xf5\xdb\xa1\x19\x47\xc3\xff\x0e\x00\x13\xe6\xc6\x57\x45\x2a\x00\x00")
 
 func zondsHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -315,6 +336,7 @@ var _bindata = map[string]func() (*asset, error){
 	"password_recovery.html": password_recoveryHtml,
 	"register.html":          registerHtml,
 	"repeatable.html":        repeatableHtml,
+	"task.html":              taskHtml,
 	"tasks.html":             tasksHtml,
 	"zonds.html":             zondsHtml,
 }
@@ -367,6 +389,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"password_recovery.html": &bintree{password_recoveryHtml, map[string]*bintree{}},
 	"register.html":          &bintree{registerHtml, map[string]*bintree{}},
 	"repeatable.html":        &bintree{repeatableHtml, map[string]*bintree{}},
+	"task.html":              &bintree{taskHtml, map[string]*bintree{}},
 	"tasks.html":             &bintree{tasksHtml, map[string]*bintree{}},
 	"zonds.html":             &bintree{zondsHtml, map[string]*bintree{}},
 }}
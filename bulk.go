@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ad/gocc/api"
+	uuid "github.com/nu7hatch/gouuid"
+)
+
+// maxBulkTargets is count of targets accepted by one bulk request
+const maxBulkTargets = 1000
+
+// ParseTargetList reads targets from text, one per line. Blank lines and
+// lines starting with # are skipped
+func ParseTargetList(r io.Reader) ([]string, error) {
+	var targets []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		targets = append(targets, line)
+	}
+
+	return targets, scanner.Err()
+}
+
+// TaskBulkRequestFromForm reads bulk request from multipart form, targets are
+// taken from uploaded targets file or from targets field
+func TaskBulkRequestFromForm(w http.ResponseWriter, r *http.Request) (TaskBulkRequest, *ApiError) {
+	var req TaskBulkRequest
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBody)
+	if err := r.ParseMultipartForm(maxRequestBody); err != nil && err != http.ErrNotMultipart {
+		return req, NewApiError(http.StatusBadRequest, api.ErrCodeBadRequest, "Error reading request body")
+	}
+
+	req.Action = r.FormValue("action")
+	req.Type = r.FormValue("type")
+	req.Dest = r.FormValue("dest")
+	req.Repeat = r.FormValue("repeat")
+	if value := r.FormValue("count"); value != "" {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return req, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "count must be number")
+		}
+		req.Count = count
+	}
+	if value := r.FormValue("queue_ttl"); value != "" {
+		queueTTL, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return req, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "queue_ttl must be number")
+		}
+		req.QueueTTL = queueTTL
+	}
+
+	var list io.Reader = strings.NewReader(r.FormValue("targets"))
+	if file, _, err := r.FormFile("targets"); err == nil {
+		defer file.Close()
+		list = file
+	}
+
+	targets, err := ParseTargetList(list)
+	if err != nil {
+		return req, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "can't read targets: "+err.Error())
+	}
+	req.Targets = targets
+
+	return req, nil
+}
+
+// CreateTaskBatch validates every target of bulk request and creates task
+// for each valid one. Settings shared by targets are checked once and reject
+// the whole request
+func CreateTaskBatch(userUUID string, req TaskBulkRequest) (TaskBulkResponse, *ApiError) {
+	if !taskTypes[req.Action] {
+		return TaskBulkResponse{}, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong task type")
+	}
+	if len(req.Targets) == 0 && len(req.Params) == 0 {
+		return TaskBulkResponse{}, NewApiError(http.StatusBadRequest, api.ErrCodeMissingParam, "targets or params are required")
+	}
+	if len(req.Targets)+len(req.Params) > maxBulkTargets {
+		return TaskBulkResponse{}, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, fmt.Sprintf("no more than %d targets allowed", maxBulkTargets))
+	}
+
+	// targets may need different address families, zonds of any family are counted
+	settings, apiErr := resolveTaskSettings(TaskCreateRequest{Action: req.Action, Type: req.Type, Count: req.Count, Dest: req.Dest, Repeat: req.Repeat, QueueTTL: req.QueueTTL}, "")
+	if apiErr != nil {
+		return TaskBulkResponse{}, apiErr
+	}
+
+	u, _ := uuid.NewV4()
+	resp := TaskBulkResponse{Status: "ok", Batch: u.String(), Eligible: settings.eligible, Results: []TaskBulkResult{}}
+
+	seen := map[string]bool{}
+	add := func(result TaskBulkResult, ip string, params api.Params, apiErr *ApiError) {
+		if apiErr == nil && seen[string(params)] {
+			apiErr = NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "duplicate target")
+		}
+
+		if apiErr != nil {
+			result.Error = apiErr
+			resp.Failed++
+		} else {
+			seen[string(params)] = true
+			result.UUID = saveNewTask(userUUID, req.Action, ip, params, settings, resp.Batch).UUID
+			resp.Created++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	for _, target := range req.Targets {
+		result := TaskBulkResult{Target: strings.TrimSpace(target)}
+		ip, params, apiErr := ValidateTaskParams(req.Action, result.Target, nil)
+		add(result, ip, params, apiErr)
+	}
+	for _, item := range req.Params {
+		// empty item would fall back to legacy param, which params items don't have
+		if len(item) == 0 {
+			add(TaskBulkResult{}, "", nil, NewApiError(http.StatusBadRequest, api.ErrCodeMissingParam, "params item is empty"))
+			continue
+		}
+		ip, params, apiErr := ValidateTaskParams(req.Action, "", item)
+		add(TaskBulkResult{Target: ip}, ip, params, apiErr)
+	}
+
+	log.Println("batch", resp.Batch, "of", userUUID, "created", resp.Created, "tasks,", resp.Failed, "targets rejected")
+
+	return resp, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ad/gocc/api"
+)
+
+func TestCreateTaskBatchParams(t *testing.T) {
+	useTestRedis(t)
+
+	resp, apiErr := CreateTaskBatch("alice", TaskBulkRequest{
+		Action:  "tcp",
+		Targets: []string{"8.8.8.8:53"},
+		Params: []api.Params{
+			api.Params(`{"host": "1.1.1.1", "port": 443, "timeout": 3}`),
+			api.Params(`{"host": "1.1.1.1", "port": 443, "timeout": 3}`),
+			api.Params(`{"host": "1.1.1.1"}`),
+			api.Params(`{"host": "1.1.1.1", "port": 443, "unknown": true}`),
+			nil,
+		},
+		QueueTTL: 60,
+	})
+	if apiErr != nil {
+		t.Fatal(apiErr.Message)
+	}
+
+	if resp.Created != 2 || resp.Failed != 4 || len(resp.Results) != 6 {
+		t.Fatalf("created %d, failed %d of %d, want 2, 4 of 6", resp.Created, resp.Failed, len(resp.Results))
+	}
+
+	result := resp.Results[1]
+	if result.Error != nil || result.Target != "1.1.1.1:443" {
+		t.Fatalf("params item = %+v", result)
+	}
+	task, _ := GetUserTask("alice", result.UUID)
+	var params api.TCPParams
+	if err := json.Unmarshal(task.Params, &params); err != nil || params.Timeout != 3 {
+		t.Errorf("params of task = %s, %v", task.Params, err)
+	}
+
+	for i, result := range resp.Results[2:] {
+		if result.Error == nil {
+			t.Errorf("item %d is not rejected: %+v", i+1, result)
+		}
+	}
+}
+
+func TestCreateTaskBatchEmpty(t *testing.T) {
+	useTestRedis(t)
+
+	if _, apiErr := CreateTaskBatch("alice", TaskBulkRequest{Action: "tcp", QueueTTL: 60}); apiErr == nil || apiErr.Code != api.ErrCodeMissingParam {
+		t.Errorf("batch without targets and params: %+v, want missing param", apiErr)
+	}
+}
@@ -0,0 +1,434 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ad/gocc/api"
+)
+
+// HeaderClientCert is url-escaped PEM client certificate of zond verified
+// by nginx, $ssl_client_escaped_cert. nginx must always set it, so it can't
+// be passed by client
+const HeaderClientCert = "X-Client-Cert"
+
+// validity of certificates issued by CA
+const (
+	caValidity         = 10 * 365 * 24 * time.Hour
+	zondCertValidity   = 365 * 24 * time.Hour
+	serverCertValidity = 365 * 24 * time.Hour
+)
+
+// enrollTokenTTL limits time between zond creation and enrollment
+const enrollTokenTTL = 24 * time.Hour
+
+// CA is internal certificate authority issuing client certificates to zonds,
+// subject common name of certificate is zond uuid
+type CA struct {
+	Cert    *x509.Certificate
+	CertPEM []byte
+	Key     *ecdsa.PrivateKey
+	pool    *x509.CertPool
+}
+
+// ca is nil when certificate enrollment is disabled
+var ca *CA
+
+// LoadCA reads ca.crt and ca.key from dir, they are created on first start
+func LoadCA(dir string) (*CA, error) {
+	certFile := filepath.Join(dir, "ca.crt")
+	keyFile := filepath.Join(dir, "ca.key")
+
+	if _, err := os.Stat(certFile); os.IsNotExist(err) {
+		if err := createCA(dir, certFile, keyFile); err != nil {
+			return nil, err
+		}
+	}
+
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	certBlock, _ := pem.Decode(certPEM)
+	keyBlock, _ := pem.Decode(keyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, errors.New("ca: wrong PEM in " + dir)
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return &CA{Cert: cert, CertPEM: certPEM, Key: key, pool: pool}, nil
+}
+
+func createCA(dir string, certFile string, keyFile string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"gocc"}, CommonName: "gocc zond CA " + fqdn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		return err
+	}
+
+	log.Println("CA created in", dir)
+
+	return nil
+}
+
+func newSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+// SignZond issues client certificate of zond for public key of csr
+func (c *CA) SignZond(zondUUID string, csr *x509.CertificateRequest) ([]byte, *big.Int, error) {
+	serial, err := newSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"gocc"}, CommonName: zondUUID},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(zondCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, c.Cert, csr.PublicKey, c.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), serial, nil
+}
+
+// ServerCertificate issues certificate of TLS listener for hosts, names and addresses
+func (c *CA) ServerCertificate(hosts []string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"gocc"}, CommonName: hosts[0]},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(serverCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, c.Cert, &key.PublicKey, c.Key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der, c.Cert.Raw}, PrivateKey: key}, nil
+}
+
+// TLSConfig is config of listeners accepting zond certificates
+func (c *CA) TLSConfig(hosts []string, clientAuth tls.ClientAuthType) (*tls.Config, error) {
+	cert, err := c.ServerCertificate(hosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    c.pool,
+		ClientAuth:   clientAuth,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// Verify checks that client certificate is issued by CA
+func (c *CA) Verify(cert *x509.Certificate) error {
+	_, err := cert.Verify(x509.VerifyOptions{Roots: c.pool, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	return err
+}
+
+// NewEnrollToken returns one-time token zond exchanges for client certificate
+func NewEnrollToken(zondUUID string) string {
+	buff := make([]byte, 24)
+	rand.Read(buff)
+	token := hex.EncodeToString(buff)
+
+	Client.Set("enroll/"+token, zondUUID, enrollTokenTTL)
+
+	return token
+}
+
+// IssueEnrollToken returns new enrollment token for zond of user, token is
+// empty when enrollment is disabled
+func IssueEnrollToken(userUUID string, zondUUID string) (string, *ApiError) {
+	if ca == nil {
+		return "", NewApiError(http.StatusNotFound, api.ErrCodeNotFound, "certificate enrollment is disabled")
+	}
+	if _, apiErr := GetUserZond(userUUID, zondUUID); apiErr != nil {
+		return "", apiErr
+	}
+
+	return NewEnrollToken(zondUUID), nil
+}
+
+// EnrollZond exchanges enrollment token for client certificate signed by CA,
+// zond keeps its private key and sends only certificate request
+func EnrollZond(req EnrollRequest) (EnrollResponse, *ApiError) {
+	if ca == nil {
+		return EnrollResponse{}, NewApiError(http.StatusNotFound, api.ErrCodeNotFound, "certificate enrollment is disabled")
+	}
+	if req.Token == "" {
+		return EnrollResponse{}, NewApiError(http.StatusBadRequest, api.ErrCodeMissingParam, "token is required")
+	}
+
+	zondUUID, _ := Client.Get("enroll/" + req.Token).Result()
+	// token is used once, Del decides between concurrent requests
+	if zondUUID == "" || Client.Del("enroll/"+req.Token).Val() != 1 {
+		return EnrollResponse{}, NewApiError(http.StatusUnauthorized, api.ErrCodeUnauthorized, "token is unknown, used or expired")
+	}
+	if !Client.SIsMember("zonds", zondUUID).Val() {
+		return EnrollResponse{}, NewApiError(http.StatusUnauthorized, api.ErrCodeUnauthorized, "zond is disabled or deleted")
+	}
+
+	block, _ := pem.Decode([]byte(req.CSR))
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return EnrollResponse{}, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "csr must be PEM certificate request")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err == nil {
+		err = csr.CheckSignature()
+	}
+	if err != nil {
+		return EnrollResponse{}, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "csr is broken: "+err.Error())
+	}
+
+	certPEM, serial, err := ca.SignZond(zondUUID, csr)
+	if err != nil {
+		log.Println(err)
+		return EnrollResponse{}, NewApiError(http.StatusInternalServerError, api.ErrCodeInternal, "certificate is not issued")
+	}
+	Client.SAdd("zonds/"+zondUUID+"/certs", serial.Text(16))
+
+	log.Println("Zond", zondUUID, "enrolled, certificate", serial.Text(16))
+
+	return EnrollResponse{Status: "ok", UUID: zondUUID, Certificate: string(certPEM), CA: string(ca.CertPEM)}, nil
+}
+
+// RevokeZondCerts revokes all certificates issued to zond
+func RevokeZondCerts(zondUUID string) {
+	serials, _ := Client.SMembers("zonds/" + zondUUID + "/certs").Result()
+	for _, serial := range serials {
+		Client.SAdd("certs-revoked", serial)
+	}
+	Client.Del("zonds/" + zondUUID + "/certs")
+
+	if len(serials) > 0 {
+		log.Println("Zond", zondUUID, "certificates revoked:", len(serials))
+	}
+}
+
+// ZondFromCertificate returns zond uuid from subject of verified client
+// certificate, revoked certificates and disabled zonds are rejected
+func ZondFromCertificate(cert *x509.Certificate) (string, error) {
+	zondUUID := cert.Subject.CommonName
+	if len(zondUUID) != 36 {
+		return zondUUID, errors.New("wrong certificate subject")
+	}
+	if Client.SIsMember("certs-revoked", cert.SerialNumber.Text(16)).Val() {
+		return zondUUID, errors.New("certificate is revoked")
+	}
+	if !Client.SIsMember("zonds", zondUUID).Val() {
+		return zondUUID, errors.New("unknown uuid")
+	}
+	return zondUUID, nil
+}
+
+// RequestCertificate returns client certificate verified by TLS listener of
+// gocc or by nginx, nil without certificate. On TLS listener only handshake
+// proves possession of key, so header is not read there
+func RequestCertificate(r *http.Request) (*x509.Certificate, error) {
+	if r.TLS != nil {
+		if len(r.TLS.VerifiedChains) > 0 {
+			return r.TLS.VerifiedChains[0][0], nil
+		}
+		return nil, nil
+	}
+
+	escaped := r.Header.Get(HeaderClientCert)
+	if escaped == "" {
+		return nil, nil
+	}
+	if ca == nil {
+		return nil, errors.New("certificate enrollment is disabled")
+	}
+
+	certPEM, err := url.QueryUnescape(escaped)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return nil, errors.New("wrong client certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if err := ca.Verify(cert); err != nil {
+		return nil, fmt.Errorf("client certificate: %v", err)
+	}
+
+	return cert, nil
+}
+
+// AuthorizeZond returns uuid of zond from client certificate, requests
+// without certificate must be signed, see VerifyAgent. X-ZondUuid header is
+// set from certificate for next handlers
+func AuthorizeZond(r *http.Request, path string) (string, error) {
+	cert, err := RequestCertificate(r)
+	if err != nil {
+		return r.Header.Get(api.HeaderZondUUID), err
+	}
+	if cert == nil {
+		return VerifyAgent(r, api.HeaderZondUUID, "zonds", path)
+	}
+
+	zondUUID, err := ZondFromCertificate(cert)
+	if err != nil {
+		return zondUUID, err
+	}
+	if header := r.Header.Get(api.HeaderZondUUID); header != "" && header != zondUUID {
+		return zondUUID, errors.New("uuid does not match certificate")
+	}
+	r.Header.Set(api.HeaderZondUUID, zondUUID)
+
+	return zondUUID, nil
+}
+
+// hasClientCertificate tells that zond may be identified by certificate instead of header
+func hasClientCertificate(r *http.Request) bool {
+	return (r.TLS != nil && len(r.TLS.PeerCertificates) > 0) || r.Header.Get(HeaderClientCert) != ""
+}
+
+// ZondTLSHandler serves zond routes on TLS listener. Nothing is in front of
+// it, so headers nginx sets are dropped and X-Forwarded-For is the peer
+func ZondTLSHandler() http.Handler {
+	h := NewHandler(NewZondRouter())
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del("X-Forwarded-User")
+		r.Header.Del(HeaderClientCert)
+		r.Header.Del("X-Forwarded-For")
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			r.Header.Set("X-Forwarded-For", host)
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// ServeZondTLS starts HTTPS and gRPC listeners for zonds with client
+// certificates, they are optional on HTTPS where signed requests still work
+func ServeZondTLS() {
+	hosts := strings.Split(*tlshosts, ",")
+
+	if *tlsaddr != "" {
+		config, err := ca.TLSConfig(hosts, tls.VerifyClientCertIfGiven)
+		if err != nil {
+			log.Fatal(err)
+		}
+		server := &http.Server{Addr: *tlsaddr, Handler: ZondTLSHandler(), TLSConfig: config}
+		log.Printf("listening for zond certificates on %s", *tlsaddr)
+		go func() {
+			log.Fatal(server.ListenAndServeTLS("", ""))
+		}()
+	}
+
+	if *grpcaddr != "" {
+		server, err := NewGRPCServer(ca, hosts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		lis, err := net.Listen("tcp", *grpcaddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("gRPC zond service on %s", *grpcaddr)
+		go func() {
+			log.Fatal(server.Serve(lis))
+		}()
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ad/gocc/api"
+)
+
+// enrollTestZond creates zond with client certificate issued by ca
+func enrollTestZond(t *testing.T) (Zond, tls.Certificate, string) {
+	t.Helper()
+
+	zond, _ := CreateZond("alice", "z1")
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	csr, _ := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+	resp, apiErr := EnrollZond(EnrollRequest{
+		Token: NewEnrollToken(zond.UUID),
+		CSR:   string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr})),
+	})
+	if apiErr != nil {
+		t.Fatal(apiErr.Message)
+	}
+
+	block, _ := pem.Decode([]byte(resp.Certificate))
+	cert := tls.Certificate{Certificate: [][]byte{block.Bytes}, PrivateKey: key}
+
+	return zond, cert, resp.Certificate
+}
+
+func TestZondTLSListener(t *testing.T) {
+	useTestRedis(t)
+
+	var err error
+	if ca, err = LoadCA(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { ca = nil }()
+
+	zond, cert, certPEM := enrollTestZond(t)
+	task, _, apiErr := CreateTask("alice", TaskCreateRequest{Action: "ping", Param: "8.8.8.8", QueueTTL: 60})
+	if apiErr != nil {
+		t.Fatal(apiErr.Message)
+	}
+
+	config, err := ca.TLSConfig([]string{"127.0.0.1"}, tls.VerifyClientCertIfGiven)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewUnstartedServer(ZondTLSHandler())
+	srv.TLS = config
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Cert)
+	newClient := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certs}}}
+	}
+	block := func(client *http.Client, header http.Header) *http.Response {
+		js, _ := json.Marshal(api.Action{Action: task.Action, UUID: task.UUID})
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+api.PathZondBlock, bytes.NewReader(js))
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	// public certificate pasted into header does not prove possession of key
+	forged := http.Header{}
+	forged.Set(HeaderClientCert, url.QueryEscape(certPEM))
+	forged.Set(api.HeaderZondUUID, zond.UUID)
+	if resp := block(newClient(), forged); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("block with certificate in header: status %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	if resp := block(newClient(cert), nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("block with client certificate: status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	// only zond routes are served, user header of nginx is not trusted
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/v1/tasks", nil)
+	req.Header.Set("X-Forwarded-User", "alice")
+	resp, err := newClient().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("user api on TLS listener: status %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
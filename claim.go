@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ad/gocc/api"
+)
+
+// Claim timeouts, task that is not reported in time is returned to queue
+const (
+	zondClaimTimeout = 60 * time.Second
+	mngrClaimTimeout = 300 * time.Second
+)
+
+// auditLogSize is count of audit entries kept in audit/results
+const auditLogSize = 10000
+
+// Claim is record of task claimed by zond or manager, it is kept in
+// <agent>/<task>/processing until deadline and in task/<task>/claims history.
+// Geo data of zond is taken at claim time
+type Claim struct {
+	Agent    string `json:"agent"`
+	Task     string `json:"task"`
+	Attempt  int64  `json:"attempt"`
+	Claimed  int64  `json:"claimed"`
+	Deadline int64  `json:"deadline"`
+	City     string `json:"city,omitempty"`
+	Country  string `json:"country,omitempty"`
+	ASN      string `json:"asn,omitempty"`
+}
+
+func claimKey(agentUUID string, taskUUID string) string {
+	return agentUUID + "/" + taskUUID + "/processing"
+}
+
+// claimHistoryKey is list of all claims of task, oldest first
+func claimHistoryKey(taskUUID string) string {
+	return "task/" + taskUUID + "/claims"
+}
+
+// SaveClaim records claim of task by agent, every claim of task is a new attempt
+func SaveClaim(agentUUID string, taskUUID string, timeout time.Duration) Claim {
+	now := time.Now()
+	claim := Claim{
+		Agent:    agentUUID,
+		Task:     taskUUID,
+		Attempt:  Client.Incr("task/" + taskUUID + "/attempts").Val(),
+		Claimed:  now.Unix(),
+		Deadline: now.Add(timeout).Unix(),
+		City:     Client.HGet("zond:city", agentUUID).Val(),
+		Country:  Client.HGet("zond:country", agentUUID).Val(),
+		ASN:      Client.HGet("zond:asn", agentUUID).Val(),
+	}
+
+	js, _ := json.Marshal(claim)
+	Client.SAdd("tasks-process", agentUUID+"/"+taskUUID)
+	Client.Set(claimKey(agentUUID, taskUUID), string(js), timeout)
+	Client.RPush(claimHistoryKey(taskUUID), string(js))
+
+	return claim
+}
+
+// GetClaim returns claim of task by agent, false when task is not claimed by
+// agent or claim is expired
+func GetClaim(agentUUID string, taskUUID string) (Claim, bool) {
+	var claim Claim
+
+	if !Client.SIsMember("tasks-process", agentUUID+"/"+taskUUID).Val() {
+		return claim, false
+	}
+
+	js, _ := Client.Get(claimKey(agentUUID, taskUUID)).Result()
+	if js == "" {
+		return claim, false
+	}
+	if err := json.Unmarshal([]byte(js), &claim); err != nil {
+		log.Println(err.Error())
+		return claim, false
+	}
+
+	return claim, true
+}
+
+// CheckResult validates result of agent against its claim record, answer is
+// api.MessageOK or reason of rejection. Expired claim is returned to queue
+func CheckResult(agentUUID string, t Action, maxSize int) (Claim, string) {
+	claim, ok := GetClaim(agentUUID, t.UUID)
+	if !ok {
+		if Client.SIsMember("tasks-process", agentUUID+"/"+t.UUID).Val() {
+			RequeueTask(agentUUID, t.UUID)
+			return claim, api.MessageClaimExpired
+		}
+		return claim, api.MessageTaskNotFound
+	}
+
+	if time.Now().Unix() > claim.Deadline {
+		RequeueTask(agentUUID, t.UUID)
+		return claim, api.MessageClaimExpired
+	}
+	if t.Attempt != 0 && t.Attempt != claim.Attempt {
+		return claim, api.MessageWrongAttempt
+	}
+	if maxSize > 0 && len(t.Result) > maxSize {
+		return claim, api.MessageResultTooLarge
+	}
+
+	return claim, api.MessageOK
+}
+
+// AuditEntry is record of rejected agent request
+type AuditEntry struct {
+	Time    int64  `json:"time"`
+	Agent   string `json:"agent"`
+	Claimed string `json:"claimed,omitempty"`
+	Task    string `json:"task"`
+	Attempt int64  `json:"attempt,omitempty"`
+	Reason  string `json:"reason"`
+	Source  string `json:"source,omitempty"`
+}
+
+// Audit records rejected result or claim in audit/results list, newest first
+func Audit(entry AuditEntry) {
+	entry.Time = time.Now().Unix()
+	log.Println("audit:", entry.Agent, entry.Task, entry.Reason, entry.Claimed, entry.Source)
+
+	js, _ := json.Marshal(entry)
+	Client.LPush("audit/results", string(js))
+	Client.LTrim("audit/results", 0, auditLogSize-1)
+}
+
+// auditRejected records rejected claim or result of agent, claimed is agent
+// named in body when it differs from authenticated one
+func auditRejected(agentUUID string, t Action, reason string, source string) {
+	entry := AuditEntry{Agent: agentUUID, Task: t.UUID, Attempt: t.Attempt, Reason: reason, Source: source}
+	if t.ZondUUID != "" && t.ZondUUID != agentUUID {
+		entry.Claimed = t.ZondUUID
+	}
+	if t.MngrUUID != "" && t.MngrUUID != agentUUID {
+		entry.Claimed = t.MngrUUID
+	}
+	if t.Action == api.ActionResult {
+		entry.Reason += ", result of " + strconv.Itoa(len(t.Result)) + " bytes"
+	}
+	Audit(entry)
+}
+
+// requestSource is address of agent for audit log
+func requestSource(r *http.Request) string {
+	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
+		return ip
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ad/gocc/api"
+)
+
+// CreateZond registers zond, response has its uuid and secret
+func (c *Client) CreateZond(ctx context.Context, name string) (*api.CreateResponse, error) {
+	var resp api.CreateResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/zonds", api.CreateRequest{Name: name}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) ListZonds(ctx context.Context, page int) (*api.ZondListResponse, error) {
+	var resp api.ZondListResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/zonds?page="+strconv.Itoa(page), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// QueryZonds returns page of zonds selected by query: cursor, sort, from and to
+func (c *Client) QueryZonds(ctx context.Context, query url.Values) (*api.ZondListResponse, error) {
+	var resp api.ZondListResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/zonds?"+query.Encode(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CreateMngr registers manager, response has its uuid and secret
+func (c *Client) CreateMngr(ctx context.Context, name string) (*api.CreateResponse, error) {
+	var resp api.CreateResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/mngrs", api.CreateRequest{Name: name}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) ListMngrs(ctx context.Context, page int) (*api.MngrListResponse, error) {
+	var resp api.MngrListResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/mngrs?page="+strconv.Itoa(page), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// QueryMngrs returns page of managers selected by query: cursor, sort, from and to
+func (c *Client) QueryMngrs(ctx context.Context, query url.Values) (*api.MngrListResponse, error) {
+	var resp api.MngrListResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/mngrs?"+query.Encode(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateZond renames, disables or enables zond, nil fields of req are kept
+func (c *Client) UpdateZond(ctx context.Context, uuid string, req api.UpdateRequest) (*api.Zond, error) {
+	var resp api.ZondResponse
+	if err := c.doJSON(ctx, http.MethodPatch, "/api/v1/zonds/"+url.PathEscape(uuid), req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Zond, nil
+}
+
+func (c *Client) DeleteZond(ctx context.Context, uuid string) error {
+	return c.doJSON(ctx, http.MethodDelete, "/api/v1/zonds/"+url.PathEscape(uuid), nil, nil)
+}
+
+// RotateZond replaces zond uuid and secret, response has the new ones
+func (c *Client) RotateZond(ctx context.Context, uuid string) (*api.ZondResponse, error) {
+	var resp api.ZondResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/zonds/"+url.PathEscape(uuid)+"/rotate", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateMngr renames, disables or enables manager, nil fields of req are kept
+func (c *Client) UpdateMngr(ctx context.Context, uuid string, req api.UpdateRequest) (*api.Mngr, error) {
+	var resp api.MngrResponse
+	if err := c.doJSON(ctx, http.MethodPatch, "/api/v1/mngrs/"+url.PathEscape(uuid), req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Mngr, nil
+}
+
+func (c *Client) DeleteMngr(ctx context.Context, uuid string) error {
+	return c.doJSON(ctx, http.MethodDelete, "/api/v1/mngrs/"+url.PathEscape(uuid), nil, nil)
+}
+
+// RotateMngr replaces manager uuid and secret, response has the new ones
+func (c *Client) RotateMngr(ctx context.Context, uuid string) (*api.MngrResponse, error) {
+	var resp api.MngrResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/mngrs/"+url.PathEscape(uuid)+"/rotate", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
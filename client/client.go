@@ -0,0 +1,219 @@
+// Package client is a Go client for gocc /api/v1.
+//
+// Behind nginx the client logs in with email and password and keeps the
+// session cookie:
+//
+//	c, _ := client.New("https://cc.example.com")
+//	err := c.Login(ctx, "user@example.com", "password")
+//	task, err := c.CreateTask(ctx, api.TaskCreateRequest{Action: "ping", Param: "8.8.8.8"})
+//
+// When gocc port is reached directly (trusted network, tests), user can be
+// passed the same way nginx does it, with WithUser.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ad/gocc/api"
+)
+
+// Client talks to gocc, it is safe for concurrent use
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	user       string
+
+	mu        sync.Mutex
+	csrfToken string
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces default http client, it must have cookie jar for csrf protection
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithUser sets X-Forwarded-User header, use it only when gocc is reached without nginx
+func WithUser(user string) Option {
+	return func(c *Client) {
+		c.user = user
+	}
+}
+
+func New(baseURL string, opts ...Option) (*Client, error) {
+	if _, err := url.Parse(baseURL); err != nil {
+		return nil, err
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Jar: jar, Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// Login authorizes client with email and password, session is kept in cookie jar
+func (c *Client) Login(ctx context.Context, login string, password string) error {
+	// login form is the only page available without session, take csrf token from it
+	if _, err := c.fetchToken(ctx, "/login"); err != nil {
+		return err
+	}
+
+	form := url.Values{"login": {login}, "password": {password}}
+	_, err := c.do(ctx, http.MethodPost, "/login", "application/x-www-form-urlencoded", []byte(form.Encode()), nil)
+	return err
+}
+
+// token returns csrf token, fetching it from /api/v1/token on first use
+func (c *Client) token(ctx context.Context, refresh bool) (string, error) {
+	c.mu.Lock()
+	token := c.csrfToken
+	c.mu.Unlock()
+
+	if token != "" && !refresh {
+		return token, nil
+	}
+
+	return c.fetchToken(ctx, "/api/v1/token")
+}
+
+func (c *Client) fetchToken(ctx context.Context, path string) (string, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path, "", nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", decodeError(resp)
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+
+	token := resp.Header.Get("X-CSRF-Token")
+	if token == "" {
+		return "", &Error{StatusCode: resp.StatusCode, Code: api.ErrCodeInternal, Message: "no csrf token in response"}
+	}
+
+	c.mu.Lock()
+	c.csrfToken = token
+	c.mu.Unlock()
+
+	return token, nil
+}
+
+func (c *Client) newRequest(ctx context.Context, method string, path string, contentType string, body []byte) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Requested-With", "xmlhttprequest")
+	if c.user != "" {
+		req.Header.Set("X-Forwarded-User", c.user)
+	}
+
+	return req, nil
+}
+
+// do sends request, unsafe methods get csrf token, which is refreshed once when rejected
+func (c *Client) do(ctx context.Context, method string, path string, contentType string, body []byte, out interface{}) (*http.Response, error) {
+	unsafe := method != http.MethodGet && method != http.MethodHead
+
+	for attempt := 0; ; attempt++ {
+		req, err := c.newRequest(ctx, method, path, contentType, body)
+		if err != nil {
+			return nil, err
+		}
+
+		if unsafe {
+			token, err := c.token(ctx, attempt > 0)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("X-CSRF-Token", token)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		// gorilla/csrf answers with plain text 403 when token is rejected
+		if unsafe && attempt == 0 && resp.StatusCode == http.StatusForbidden && !isJSON(resp) {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+			continue
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return resp, decodeError(resp)
+		}
+
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return resp, fmt.Errorf("decode %s %s: %v", method, path, err)
+			}
+		}
+
+		return resp, nil
+	}
+}
+
+func (c *Client) doJSON(ctx context.Context, method string, path string, in interface{}, out interface{}) error {
+	var body []byte
+	var contentType string
+	if in != nil {
+		js, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = js
+		contentType = "application/json"
+	}
+
+	_, err := c.do(ctx, method, path, contentType, body, out)
+	return err
+}
+
+func isJSON(resp *http.Response) bool {
+	return strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json")
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/ad/gocc/api"
+)
+
+// Error is returned when gocc answers with non-2xx status
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return "gocc: " + http.StatusText(e.StatusCode) + ": " + e.Code + ": " + e.Message
+}
+
+func decodeError(resp *http.Response) error {
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	var envelope api.ErrorResponse
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Error != nil {
+		return &Error{StatusCode: resp.StatusCode, Code: envelope.Error.Code, Message: envelope.Error.Message}
+	}
+
+	code := api.ErrCodeBadRequest
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		code = api.ErrCodeUnauthorized
+	case http.StatusForbidden:
+		code = api.ErrCodeForbidden
+	case http.StatusNotFound:
+		code = api.ErrCodeNotFound
+	case http.StatusConflict:
+		code = api.ErrCodeConflict
+	default:
+		if resp.StatusCode >= 500 {
+			code = api.ErrCodeInternal
+		}
+	}
+
+	return &Error{StatusCode: resp.StatusCode, Code: code, Message: strings.TrimSpace(string(body))}
+}
+
+func hasCode(err error, code string) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Code == code
+}
+
+// IsNotFound reports whether task, zond or manager does not exist or belongs to other user
+func IsNotFound(err error) bool {
+	return hasCode(err, api.ErrCodeNotFound)
+}
+
+// IsUnauthorized reports whether session is missing or expired
+func IsUnauthorized(err error) bool {
+	return hasCode(err, api.ErrCodeUnauthorized)
+}
+
+// IsConflict reports whether operation is not allowed in current state, like canceling running task
+func IsConflict(err error) bool {
+	return hasCode(err, api.ErrCodeConflict)
+}
+
+// IsInvalid reports whether request was rejected by validation
+func IsInvalid(err error) bool {
+	return hasCode(err, api.ErrCodeInvalidParam) || hasCode(err, api.ErrCodeMissingParam) || hasCode(err, api.ErrCodeInvalidJSON) || hasCode(err, api.ErrCodeBadRequest)
+}
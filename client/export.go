@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/ad/gocc/api"
+)
+
+// ExportTasks streams tasks of user as JSON Lines and calls fn for every task
+// until export ends or fn returns error. Filter may have action, target, zond,
+// schedule, from and to values, format is always jsonl
+func (c *Client) ExportTasks(ctx context.Context, filter url.Values, fn func(api.ExportRecord) error) error {
+	query := url.Values{}
+	for key, values := range filter {
+		query[key] = values
+	}
+	query.Set("format", "jsonl")
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/api/v1/tasks/export?"+query.Encode(), "", nil)
+	if err != nil {
+		return err
+	}
+
+	// export of many tasks must not be limited by client timeout
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decodeError(resp)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var record api.ExportRecord
+		if err := decoder.Decode(&record); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/ad/gocc/api"
+)
+
+// GetRetention returns retention policy of user and of its schedules
+func (c *Client) GetRetention(ctx context.Context) (*api.RetentionResponse, error) {
+	var resp api.RetentionResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/retention", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetRetention replaces retention policy of user, empty policy returns to default of server
+func (c *Client) SetRetention(ctx context.Context, policy api.RetentionPolicy) (*api.RetentionResponse, error) {
+	var resp api.RetentionResponse
+	if err := c.doJSON(ctx, http.MethodPut, "/api/v1/retention", policy, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetScheduleRetention replaces retention policy of runs of schedule, empty policy removes it
+func (c *Client) SetScheduleRetention(ctx context.Context, uuid string, policy api.RetentionPolicy) (*api.RetentionResponse, error) {
+	var resp api.RetentionResponse
+	if err := c.doJSON(ctx, http.MethodPut, "/api/v1/tasks/repeatable/"+url.PathEscape(uuid)+"/retention", policy, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RetentionReport tells what compaction would remove from tasks of user now
+func (c *Client) RetentionReport(ctx context.Context) (*api.CompactionReport, error) {
+	var resp api.CompactionReport
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/retention/report", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
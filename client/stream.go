@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ad/gocc/api"
+	"github.com/ad/gocc/internal/sse"
+)
+
+// StreamResults subscribes to tasks/done channel with EventSource and calls fn
+// for every finished task until ctx is done or fn returns error.
+// The channel is served by nginx nchan at /sub, so it is not available when
+// gocc is reached directly.
+func (c *Client) StreamResults(ctx context.Context, fn func(api.Action) error) error {
+	req, err := c.newRequest(ctx, http.MethodGet, "/sub/tasks/done", "", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	// stream must not be limited by client timeout
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decodeError(resp)
+	}
+
+	err = sse.Read(resp.Body, func(data []byte) error {
+		var action api.Action
+		if err := json.Unmarshal(data, &action); err != nil {
+			return nil
+		}
+		// the same subscription receives destinations and updated notifications
+		if action.UUID == "" || action.Action == "destinations" || action.Action == "updated" {
+			return nil
+		}
+		return fn(action)
+	})
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ad/gocc/api"
+)
+
+// CreateTask creates task, task with Repeat also creates schedule
+func (c *Client) CreateTask(ctx context.Context, req api.TaskCreateRequest) (*api.Action, error) {
+	var resp api.TaskCreateResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/tasks", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Task, nil
+}
+
+func (c *Client) GetTask(ctx context.Context, uuid string) (*api.Action, error) {
+	var resp api.TaskResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/tasks/"+url.PathEscape(uuid), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Task, nil
+}
+
+// CreateTasks creates task for every target of req, targets are validated one
+// by one and rejected ones have Error in results
+func (c *Client) CreateTasks(ctx context.Context, req api.TaskBulkRequest) (*api.TaskBulkResponse, error) {
+	var resp api.TaskBulkResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/tasks/bulk", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetTaskDetail returns task with its attempts, zond, child tasks and other runs of its schedule
+func (c *Client) GetTaskDetail(ctx context.Context, uuid string) (*api.TaskDetailResponse, error) {
+	var resp api.TaskDetailResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/tasks/"+url.PathEscape(uuid)+"/detail", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetDNSConsistency returns answers of child tasks of dns measurement grouped by zonds that got them
+func (c *Client) GetDNSConsistency(ctx context.Context, uuid string) (*api.DNSConsistencyResponse, error) {
+	var resp api.DNSConsistencyResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/tasks/"+url.PathEscape(uuid)+"/dns", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListTasks returns page of tasks, pages start from 1
+func (c *Client) ListTasks(ctx context.Context, page int) (*api.TaskListResponse, error) {
+	var resp api.TaskListResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/tasks?page="+strconv.Itoa(page), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// QueryTasks returns page of tasks selected by query: cursor (next_cursor of
+// previous page), sort, action, status, target, dest, zond, schedule, from and to
+func (c *Client) QueryTasks(ctx context.Context, query url.Values) (*api.TaskListResponse, error) {
+	var resp api.TaskListResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/tasks?"+query.Encode(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CancelTask cancels queued task, IsConflict(err) is true when task is already claimed by zond
+func (c *Client) CancelTask(ctx context.Context, uuid string) (*api.Action, error) {
+	var resp api.TaskResponse
+	if err := c.doJSON(ctx, http.MethodDelete, "/api/v1/tasks/"+url.PathEscape(uuid), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Task, nil
+}
+
+// ListSchedules returns repeatable tasks waiting for next run
+func (c *Client) ListSchedules(ctx context.Context) ([]api.Action, error) {
+	var resp api.TaskListResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/tasks/repeatable", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// RemoveSchedule stops repeatable task, uuid is uuid of scheduled run from ListSchedules
+func (c *Client) RemoveSchedule(ctx context.Context, uuid string) error {
+	return c.doJSON(ctx, http.MethodDelete, "/api/v1/tasks/repeatable/"+url.PathEscape(uuid), nil, &api.StatusResponse{})
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ad/gocc/api"
+	"github.com/ad/gocc/client"
+)
+
+// newTestClient starts gocc handler in process and returns client of user
+func newTestClient(t *testing.T, user string) (*client.Client, *httptest.Server) {
+	t.Helper()
+	useTestRedis(t)
+
+	srv := httptest.NewServer(NewHandler(NewRouter()))
+	t.Cleanup(srv.Close)
+
+	c, err := client.New(srv.URL, client.WithUser(user))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, srv
+}
+
+func TestClientLogin(t *testing.T) {
+	_, srv := newTestClient(t, "")
+
+	hash, _ := HashPassword("secret")
+	Client.Set("user/pass/alice@example.com", hash, 0)
+
+	jar, _ := cookiejar.New(nil)
+	c, _ := client.New(srv.URL, client.WithHTTPClient(&http.Client{Jar: jar}))
+	if err := c.Login(context.Background(), "alice@example.com", "secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	u, _ := url.Parse(srv.URL)
+	found := false
+	for _, cookie := range jar.Cookies(u) {
+		found = found || cookie.Name == nsCookieName
+	}
+	if !found {
+		t.Error("no session cookie after login")
+	}
+}
+
+func TestClientUnauthorized(t *testing.T) {
+	c, _ := newTestClient(t, "")
+
+	_, err := c.ListTasks(context.Background(), 1)
+	if !client.IsUnauthorized(err) {
+		t.Errorf("ListTasks without user: %v, want unauthorized", err)
+	}
+}
+
+func TestClientTasks(t *testing.T) {
+	c, _ := newTestClient(t, "alice")
+	ctx := context.Background()
+
+	task, err := c.CreateTask(ctx, api.TaskCreateRequest{Action: "ping", Param: "8.8.8.8", QueueTTL: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.UUID == "" || task.Action != "ping" {
+		t.Fatalf("CreateTask = %+v", task)
+	}
+
+	if _, err := c.CreateTask(ctx, api.TaskCreateRequest{Action: "nope", Param: "8.8.8.8", QueueTTL: 60}); !client.IsInvalid(err) {
+		t.Errorf("CreateTask with unknown action: %v, want invalid", err)
+	}
+
+	got, err := c.GetTask(ctx, task.UUID)
+	if err != nil || got.UUID != task.UUID {
+		t.Errorf("GetTask = %+v, %v", got, err)
+	}
+	if _, err := c.GetTask(ctx, "00000000-0000-0000-0000-000000000000"); !client.IsNotFound(err) {
+		t.Errorf("GetTask of unknown task: %v, want not found", err)
+	}
+
+	detail, err := c.GetTaskDetail(ctx, task.UUID)
+	if err != nil || detail.Task.UUID != task.UUID {
+		t.Errorf("GetTaskDetail = %+v, %v", detail, err)
+	}
+	if _, err := c.GetDNSConsistency(ctx, task.UUID); !client.IsInvalid(err) && !client.IsNotFound(err) {
+		t.Errorf("GetDNSConsistency of ping task: %v, want error", err)
+	}
+
+	list, err := c.ListTasks(ctx, 1)
+	if err != nil || len(list.Results) != 1 {
+		t.Errorf("ListTasks = %+v, %v", list, err)
+	}
+	list, err = c.QueryTasks(ctx, url.Values{"action": {"ping"}})
+	if err != nil || len(list.Results) != 1 {
+		t.Errorf("QueryTasks = %+v, %v", list, err)
+	}
+
+	var exported []api.ExportRecord
+	err = c.ExportTasks(ctx, nil, func(record api.ExportRecord) error {
+		exported = append(exported, record)
+		return nil
+	})
+	if err != nil || len(exported) != 1 {
+		t.Errorf("ExportTasks = %d records, %v", len(exported), err)
+	}
+
+	bulk, err := c.CreateTasks(ctx, api.TaskBulkRequest{Action: "ping", Targets: []string{"1.1.1.1", "bad target"}, QueueTTL: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bulk.Results) != 2 || bulk.Results[0].UUID == "" || bulk.Results[1].Error == nil {
+		t.Errorf("CreateTasks = %+v", bulk)
+	}
+
+	canceled, err := c.CancelTask(ctx, task.UUID)
+	if err != nil || canceled.UUID != task.UUID {
+		t.Errorf("CancelTask = %+v, %v", canceled, err)
+	}
+	if _, err := c.CancelTask(ctx, task.UUID); !client.IsConflict(err) {
+		t.Errorf("CancelTask of canceled task: %v, want conflict", err)
+	}
+}
+
+func TestClientSchedules(t *testing.T) {
+	c, _ := newTestClient(t, "alice")
+	ctx := context.Background()
+
+	if _, err := c.CreateTask(ctx, api.TaskCreateRequest{Action: "ping", Param: "8.8.8.8", Repeat: "1hour", QueueTTL: 60}); err != nil {
+		t.Fatal(err)
+	}
+
+	schedules, err := c.ListSchedules(ctx)
+	if err != nil || len(schedules) != 1 {
+		t.Fatalf("ListSchedules = %+v, %v", schedules, err)
+	}
+
+	policy := api.RetentionPolicy{MaxRuns: 5}
+	retention, err := c.SetScheduleRetention(ctx, schedules[0].UUID, policy)
+	if err != nil || retention.Schedules[schedules[0].UUID] != policy {
+		t.Errorf("SetScheduleRetention = %+v, %v", retention, err)
+	}
+
+	if err := c.RemoveSchedule(ctx, schedules[0].UUID); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RemoveSchedule(ctx, schedules[0].UUID); !client.IsNotFound(err) {
+		t.Errorf("RemoveSchedule of removed schedule: %v, want not found", err)
+	}
+}
+
+func TestClientRetention(t *testing.T) {
+	c, _ := newTestClient(t, "alice")
+	ctx := context.Background()
+
+	policy := api.RetentionPolicy{MaxAge: 86400}
+	retention, err := c.SetRetention(ctx, policy)
+	if err != nil || retention.Policy != policy {
+		t.Errorf("SetRetention = %+v, %v", retention, err)
+	}
+	retention, err = c.GetRetention(ctx)
+	if err != nil || retention.Policy != policy {
+		t.Errorf("GetRetention = %+v, %v", retention, err)
+	}
+	if _, err := c.SetRetention(ctx, api.RetentionPolicy{MaxAge: -1}); !client.IsInvalid(err) {
+		t.Errorf("SetRetention with negative age: %v, want invalid", err)
+	}
+
+	if _, err := c.RetentionReport(ctx); err != nil {
+		t.Errorf("RetentionReport: %v", err)
+	}
+}
+
+func TestClientZonds(t *testing.T) {
+	c, _ := newTestClient(t, "alice")
+	ctx := context.Background()
+
+	created, err := c.CreateZond(ctx, "z1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.UUID == "" || created.Secret == "" {
+		t.Fatalf("CreateZond = %+v", created)
+	}
+
+	list, err := c.ListZonds(ctx, 1)
+	if err != nil || len(list.Results) != 1 {
+		t.Errorf("ListZonds = %+v, %v", list, err)
+	}
+	list, err = c.QueryZonds(ctx, url.Values{"q": {"z1"}})
+	if err != nil || len(list.Results) != 1 {
+		t.Errorf("QueryZonds = %+v, %v", list, err)
+	}
+
+	name := "z2"
+	zond, err := c.UpdateZond(ctx, created.UUID, api.UpdateRequest{Name: &name})
+	if err != nil || zond.Name != name {
+		t.Errorf("UpdateZond = %+v, %v", zond, err)
+	}
+
+	rotated, err := c.RotateZond(ctx, created.UUID)
+	if err != nil || rotated.Zond.UUID == created.UUID || rotated.Secret == "" {
+		t.Fatalf("RotateZond = %+v, %v", rotated, err)
+	}
+	if _, err := c.UpdateZond(ctx, created.UUID, api.UpdateRequest{Name: &name}); !client.IsNotFound(err) {
+		t.Errorf("UpdateZond of rotated uuid: %v, want not found", err)
+	}
+
+	if err := c.DeleteZond(ctx, rotated.Zond.UUID); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteZond(ctx, rotated.Zond.UUID); !client.IsNotFound(err) {
+		t.Errorf("DeleteZond of deleted zond: %v, want not found", err)
+	}
+}
+
+func TestClientMngrs(t *testing.T) {
+	c, _ := newTestClient(t, "alice")
+	ctx := context.Background()
+
+	created, err := c.CreateMngr(ctx, "m1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := c.ListMngrs(ctx, 1)
+	if err != nil || len(list.Results) != 1 {
+		t.Errorf("ListMngrs = %+v, %v", list, err)
+	}
+	list, err = c.QueryMngrs(ctx, url.Values{"q": {"m1"}})
+	if err != nil || len(list.Results) != 1 {
+		t.Errorf("QueryMngrs = %+v, %v", list, err)
+	}
+
+	disabled := true
+	mngr, err := c.UpdateMngr(ctx, created.UUID, api.UpdateRequest{Disabled: &disabled})
+	if err != nil || !mngr.Disabled {
+		t.Errorf("UpdateMngr = %+v, %v", mngr, err)
+	}
+
+	if _, err := c.UpdateMngr(ctx, "00000000-0000-0000-0000-000000000000", api.UpdateRequest{Disabled: &disabled}); !client.IsNotFound(err) {
+		t.Errorf("UpdateMngr of unknown manager: %v, want not found", err)
+	}
+
+	rotated, err := c.RotateMngr(ctx, created.UUID)
+	if err != nil || rotated.Mngr.UUID == created.UUID {
+		t.Fatalf("RotateMngr = %+v, %v", rotated, err)
+	}
+
+	if err := c.DeleteMngr(ctx, rotated.Mngr.UUID); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteMngr(ctx, rotated.Mngr.UUID); !client.IsNotFound(err) {
+		t.Errorf("DeleteMngr of deleted manager: %v, want not found", err)
+	}
+}
+
+func TestClientStreamResults(t *testing.T) {
+	c, _ := newTestClient(t, "alice")
+
+	// tasks/done is served by nchan, gocc itself answers with not found
+	err := c.StreamResults(context.Background(), func(api.Action) error { return nil })
+	if !client.IsNotFound(err) {
+		t.Errorf("StreamResults without nchan: %v, want not found", err)
+	}
+}
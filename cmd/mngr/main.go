@@ -0,0 +1,50 @@
+// Command mngr is reference manager for gocc: it claims measurements from
+// mngrtasks channel, fans them out as child tasks and reports aggregated result.
+//
+//	MNGR_SECRET=<manager secret> mngr -server https://cc.example.com -uuid <manager uuid>
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ad/gocc/agent"
+)
+
+func main() {
+	server := flag.String("server", "http://127.0.0.1", "gocc address")
+	mngrUUID := flag.String("uuid", os.Getenv("MNGR_UUID"), "manager uuid, created at /mngr/my")
+	wait := flag.Duration("wait", 240*time.Second, "How long to wait for results of child tasks, must be less than 300s")
+	secret := flag.String("secret", os.Getenv("MNGR_SECRET"), "manager secret, shown once on creation, prefer MNGR_SECRET environment variable")
+	flag.Parse()
+
+	if *mngrUUID == "" {
+		log.Fatal("manager uuid is required")
+	}
+	if *secret == "" {
+		log.Fatal("manager secret is required")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		cancel()
+	}()
+
+	m := agent.NewMngr(*server, *mngrUUID, *secret)
+	m.Wait = *wait
+
+	log.Println("manager", *mngrUUID, "connecting to", *server)
+	if err := m.Run(ctx); err != nil && err != context.Canceled {
+		log.Fatal(err)
+	}
+}
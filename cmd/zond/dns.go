@@ -0,0 +1,443 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ad/gocc/api"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// resolvConf is resolver configuration of zond host
+const resolvConf = "/etc/resolv.conf"
+
+// typeCAA is CAA record type, dnsmessage has no constant for it
+const typeCAA dnsmessage.Type = 257
+
+// dnsTypes are record types of dns params
+var dnsTypes = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CNAME": dnsmessage.TypeCNAME,
+	"MX":    dnsmessage.TypeMX,
+	"TXT":   dnsmessage.TypeTXT,
+	"NS":    dnsmessage.TypeNS,
+	"SOA":   dnsmessage.TypeSOA,
+	"CAA":   typeCAA,
+	"PTR":   dnsmessage.TypePTR,
+}
+
+// dnsTypeName returns name of record type, e.g. MX
+func dnsTypeName(t dnsmessage.Type) string {
+	for name, value := range dnsTypes {
+		if value == t {
+			return name
+		}
+	}
+	return "TYPE" + strconv.Itoa(int(t))
+}
+
+// rcodeNames are names of response codes of RFC 1035 and RFC 2136
+var rcodeNames = map[dnsmessage.RCode]string{
+	dnsmessage.RCodeSuccess:        "NOERROR",
+	dnsmessage.RCodeFormatError:    "FORMERR",
+	dnsmessage.RCodeServerFailure:  "SERVFAIL",
+	dnsmessage.RCodeNameError:      "NXDOMAIN",
+	dnsmessage.RCodeNotImplemented: "NOTIMP",
+	dnsmessage.RCodeRefused:        "REFUSED",
+}
+
+func rcodeName(rcode dnsmessage.RCode) string {
+	if name, ok := rcodeNames[rcode]; ok {
+		return name
+	}
+	return "RCODE" + strconv.Itoa(int(rcode))
+}
+
+// dnsServer is where queries of dns task are sent
+type dnsServer struct {
+	transport string
+	// address is host:port, url for DoH
+	address    string
+	serverName string
+}
+
+// newDNSServer returns server of dns params, LocalResolver is the first
+// nameserver of resolv.conf of zond
+func newDNSServer(p *api.DNSParams) (dnsServer, error) {
+	server := dnsServer{transport: p.Transport}
+	if server.transport == "" {
+		server.transport = api.DNSTransportUDP
+	}
+
+	host := p.Resolver
+	switch host {
+	case "":
+		host = api.DefaultResolver
+	case api.LocalResolver:
+		nameserver, err := localNameserver()
+		if err != nil {
+			return server, err
+		}
+		host = nameserver
+	}
+	server.serverName = host
+
+	port := p.Port
+	if port == 0 {
+		switch server.transport {
+		case api.DNSTransportDoT:
+			port = 853
+		case api.DNSTransportDoH:
+			port = 443
+		default:
+			port = 53
+		}
+	}
+	server.address = net.JoinHostPort(host, strconv.Itoa(port))
+
+	if server.transport == api.DNSTransportDoH {
+		path := p.Path
+		if path == "" {
+			path = "/dns-query"
+		}
+		server.address = "https://" + server.address + path
+	}
+	return server, nil
+}
+
+// localNameserver returns the first nameserver of resolv.conf
+func localNameserver() (string, error) {
+	f, err := os.Open(resolvConf)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			// zone of link-local address is not used by dialer
+			return strings.SplitN(fields[1], "%", 2)[0], nil
+		}
+	}
+	return "", errors.New("no nameserver in " + resolvConf)
+}
+
+// dnsQuery is one question of dns task
+type dnsQuery struct {
+	name       string
+	qtype      dnsmessage.Type
+	recursion  bool
+	dnssec     bool
+	noChecking bool
+}
+
+// pack returns query message with EDNS0, DO bit asks for DNSSEC
+func (q dnsQuery) pack() ([]byte, error) {
+	name, err := dnsmessage.NewName(q.name)
+	if err != nil {
+		return nil, err
+	}
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+		ID:               uint16(rand.Intn(65536)),
+		RecursionDesired: q.recursion,
+		CheckingDisabled: q.noChecking,
+	})
+	b.EnableCompression()
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(dnsmessage.Question{Name: name, Type: q.qtype, Class: dnsmessage.ClassINET}); err != nil {
+		return nil, err
+	}
+	if err := b.StartAdditionals(); err != nil {
+		return nil, err
+	}
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(4096, dnsmessage.RCodeSuccess, q.dnssec); err != nil {
+		return nil, err
+	}
+	if err := b.OPTResource(opt, dnsmessage.OPTResource{}); err != nil {
+		return nil, err
+	}
+	return b.Finish()
+}
+
+// exchange sends query to server and returns its answer, truncated UDP
+// answer is asked again over TCP
+func (s dnsServer) exchange(ctx context.Context, q dnsQuery) (*dnsmessage.Message, error) {
+	msg, err := q.pack()
+	if err != nil {
+		return nil, err
+	}
+
+	var answer []byte
+	switch s.transport {
+	case api.DNSTransportDoH:
+		answer, err = s.exchangeHTTPS(ctx, msg)
+	case api.DNSTransportUDP:
+		answer, err = s.exchangeUDP(ctx, msg)
+	default:
+		answer, err = s.exchangeStream(ctx, msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var m dnsmessage.Message
+	if err := m.Unpack(answer); err != nil {
+		return nil, err
+	}
+	if m.Header.Truncated && s.transport == api.DNSTransportUDP {
+		tcp := s
+		tcp.transport = api.DNSTransportTCP
+		return tcp.exchange(ctx, q)
+	}
+	return &m, nil
+}
+
+func (s dnsServer) exchangeUDP(ctx context.Context, msg []byte) ([]byte, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", s.address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if _, err := conn.Write(msg); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 65535)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// exchangeStream sends query over TCP or DoT, messages are prefixed with
+// two byte length
+func (s dnsServer) exchangeStream(ctx context.Context, msg []byte) ([]byte, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return nil, err
+	}
+	if s.transport == api.DNSTransportDoT {
+		conn = tls.Client(conn, &tls.Config{ServerName: s.serverName})
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	framed := make([]byte, 2+len(msg))
+	binary.BigEndian.PutUint16(framed, uint16(len(msg)))
+	copy(framed[2:], msg)
+	if _, err := conn.Write(framed); err != nil {
+		return nil, err
+	}
+
+	var length [2]byte
+	if _, err := io.ReadFull(conn, length[:]); err != nil {
+		return nil, err
+	}
+	answer := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(conn, answer); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+func (s dnsServer) exchangeHTTPS(ctx context.Context, msg []byte) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodPost, s.address, bytes.NewReader(msg))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("doh status %s", resp.Status)
+	}
+	return ioutil.ReadAll(io.LimitReader(resp.Body, 65535))
+}
+
+// recordValue returns value of answer in zone file format, false for
+// records of other types than asked, e.g. RRSIG
+func recordValue(r dnsmessage.Resource) (string, bool) {
+	switch body := r.Body.(type) {
+	case *dnsmessage.AResource:
+		return net.IP(body.A[:]).String(), true
+	case *dnsmessage.AAAAResource:
+		return net.IP(body.AAAA[:]).String(), true
+	case *dnsmessage.CNAMEResource:
+		return body.CNAME.String(), true
+	case *dnsmessage.MXResource:
+		return fmt.Sprintf("%d %s", body.Pref, body.MX.String()), true
+	case *dnsmessage.NSResource:
+		return body.NS.String(), true
+	case *dnsmessage.PTRResource:
+		return body.PTR.String(), true
+	case *dnsmessage.SOAResource:
+		return fmt.Sprintf("%s %s %d %d %d %d %d", body.NS.String(), body.MBox.String(),
+			body.Serial, body.Refresh, body.Retry, body.Expire, body.MinTTL), true
+	case *dnsmessage.TXTResource:
+		quoted := make([]string, len(body.TXT))
+		for i, txt := range body.TXT {
+			quoted[i] = strconv.Quote(txt)
+		}
+		return strings.Join(quoted, " "), true
+	case *dnsmessage.UnknownResource:
+		// CAA is flags, tag length, tag and value, RFC 8659
+		if body.Type == typeCAA && len(body.Data) >= 2 && len(body.Data) >= 2+int(body.Data[1]) {
+			tagEnd := 2 + int(body.Data[1])
+			return fmt.Sprintf("%d %s %s", body.Data[0], body.Data[2:tagEnd], strconv.Quote(string(body.Data[tagEnd:]))), true
+		}
+	}
+	return "", false
+}
+
+// dns asks resolver for records of host and reports api.DNSResult as JSON,
+// by default ip address is resolved to names and hostname to addresses
+func dns(ctx context.Context, p *api.DNSParams) string {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = 5
+	}
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	resolverAddress := p.Resolver
+	if resolverAddress == "" {
+		resolverAddress = api.DefaultResolver
+	}
+	result := api.DNSResult{Host: p.Host, Type: strings.ToUpper(p.Type), Resolver: resolverAddress, Transport: p.Transport, Answers: []api.DNSRecord{}}
+
+	server, err := newDNSServer(p)
+	if err != nil {
+		result.Error = err.Error()
+		return dnsResultJSON(result)
+	}
+	result.Transport, result.Server = server.transport, server.address
+
+	name := p.Host
+	recordType := strings.ToUpper(p.Type)
+	var qtypes []dnsmessage.Type
+	switch {
+	case recordType == "PTR" || (recordType == "" && net.ParseIP(p.Host) != nil):
+		if ip := net.ParseIP(p.Host); ip != nil {
+			name = reverseName(ip)
+		}
+		qtypes = []dnsmessage.Type{dnsmessage.TypePTR}
+	case recordType == "":
+		qtypes = []dnsmessage.Type{dnsmessage.TypeA, dnsmessage.TypeAAAA}
+	default:
+		qtype, ok := dnsTypes[recordType]
+		if !ok {
+			result.Error = "unsupported record type " + p.Type
+			return dnsResultJSON(result)
+		}
+		qtypes = []dnsmessage.Type{qtype}
+	}
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+
+	start := time.Now()
+	for i, qtype := range qtypes {
+		q := dnsQuery{name: name, qtype: qtype, recursion: !p.NoRecursion, dnssec: p.DNSSEC}
+		m, err := server.exchange(ctx, q)
+		if err != nil {
+			result.Error = err.Error()
+			break
+		}
+
+		if i == 0 {
+			result.RCode = rcodeName(m.Header.RCode)
+			result.Authoritative = m.Header.Authoritative
+			result.RecursionAvailable = m.Header.RecursionAvailable
+			if p.DNSSEC {
+				result.DNSSEC = dnssecStatus(ctx, server, q, m)
+			}
+		}
+		for _, answer := range m.Answers {
+			if answer.Header.Type != qtype && answer.Header.Type != dnsmessage.TypeCNAME {
+				continue
+			}
+			if value, ok := recordValue(answer); ok {
+				result.Answers = append(result.Answers, api.DNSRecord{
+					Name:  answer.Header.Name.String(),
+					Type:  dnsTypeName(answer.Header.Type),
+					TTL:   answer.Header.TTL,
+					Value: value,
+				})
+			}
+		}
+	}
+	result.RTT = milliseconds(time.Since(start))
+
+	return dnsResultJSON(result)
+}
+
+// dnssecStatus tells whether resolver validated answer. SERVFAIL that goes
+// away with checking disabled means answer failed validation
+func dnssecStatus(ctx context.Context, server dnsServer, q dnsQuery, m *dnsmessage.Message) string {
+	if m.Header.AuthenticData {
+		return api.DNSSECSecure
+	}
+	if m.Header.RCode == dnsmessage.RCodeServerFailure {
+		q.noChecking = true
+		if unchecked, err := server.exchange(ctx, q); err == nil && unchecked.Header.RCode != dnsmessage.RCodeServerFailure {
+			return api.DNSSECBogus
+		}
+	}
+	return api.DNSSECInsecure
+}
+
+// reverseName returns in-addr.arpa or ip6.arpa name of ip
+func reverseName(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa.", ip4[3], ip4[2], ip4[1], ip4[0])
+	}
+	const hexDigits = "0123456789abcdef"
+	name := make([]byte, 0, 72)
+	for i := len(ip) - 1; i >= 0; i-- {
+		name = append(name, hexDigits[ip[i]&0xf], '.', hexDigits[ip[i]>>4], '.')
+	}
+	return string(name) + "ip6.arpa."
+}
+
+func dnsResultJSON(result api.DNSResult) string {
+	js, err := json.Marshal(result)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return string(js)
+}
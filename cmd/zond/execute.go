@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ad/gocc/api"
+)
+
+// Execute runs task by its action, result is plain text shown to user.
+// Parameters are taken from params, tasks without them from param
+func Execute(ctx context.Context, action api.Action) string {
+	params, err := api.DecodeParams(action)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+
+	switch p := params.(type) {
+	case *api.PingParams:
+		return ping(ctx, p)
+	case *api.TracerouteParams:
+		return traceroute(ctx, p)
+	case *api.HeadParams:
+		return head(ctx, p)
+	case *api.DNSParams:
+		return dns(ctx, p)
+	case *api.TCPParams:
+		return tcp(ctx, p)
+	case *api.TLSParams:
+		return tlsCheck(ctx, p)
+	case *api.HTTPParams:
+		return httpCheck(ctx, p)
+	}
+	return "error: unsupported action " + action.Action
+}
+
+// withTimeout limits ctx by timeout of params in seconds, 0 keeps ctx
+func withTimeout(ctx context.Context, timeout int64) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+}
+
+func ping(ctx context.Context, p *api.PingParams) string {
+	count := p.Count
+	if count <= 0 {
+		count = 3
+	}
+	args := []string{"-c", strconv.Itoa(count)}
+	if isIPv6(p.Host) {
+		args = append([]string{"-6"}, args...)
+	}
+	if p.Size > 0 {
+		args = append(args, "-s", strconv.Itoa(p.Size))
+	}
+	if p.Timeout > 0 {
+		args = append(args, "-w", strconv.FormatInt(p.Timeout, 10))
+	}
+	return run(ctx, "ping", append(args, p.Host)...)
+}
+
+func traceroute(ctx context.Context, p *api.TracerouteParams) string {
+	wait := p.Timeout
+	if wait <= 0 {
+		wait = 2
+	}
+	args := []string{"-w", strconv.FormatInt(wait, 10)}
+	if isIPv6(p.Host) {
+		args = append([]string{"-6"}, args...)
+	}
+	if p.MaxTTL > 0 {
+		args = append(args, "-m", strconv.Itoa(p.MaxTTL))
+	}
+	return run(ctx, "traceroute", append(args, p.Host)...)
+}
+
+func run(ctx context.Context, name string, args ...string) string {
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil && len(out) == 0 {
+		return "error: " + err.Error()
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// head makes HEAD request without following redirects and returns status
+// line with headers, unexpected status is reported as error
+func head(ctx context.Context, p *api.HeadParams) string {
+	ctx, cancel := withTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodHead, p.URL, nil)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	req = req.WithContext(ctx)
+
+	httpClient := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	defer resp.Body.Close()
+
+	keys := make([]string, 0, len(resp.Header))
+	for key := range resp.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if p.ExpectStatus != 0 && resp.StatusCode != p.ExpectStatus {
+		return fmt.Sprintf("error: status %d, expected %d", resp.StatusCode, p.ExpectStatus)
+	}
+
+	lines := []string{resp.Proto + " " + resp.Status}
+	for _, key := range keys {
+		for _, value := range resp.Header[key] {
+			lines = append(lines, key+": "+value)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tcp connects to port of host and reports api.TCPResult as JSON
+func tcp(ctx context.Context, p *api.TCPParams) string {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = 5
+	}
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	result := api.TCPResult{Host: p.Host, Port: p.Port}
+
+	var d net.Dialer
+	start := time.Now()
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(p.Host, strconv.Itoa(p.Port)))
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.ConnectTime = float64(time.Since(start)) / float64(time.Millisecond)
+		result.Connected = true
+		result.Address = conn.RemoteAddr().String()
+		conn.Close()
+	}
+
+	js, err := json.Marshal(result)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return string(js)
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ad/gocc/api"
+)
+
+// maxAssertedBody is part of response body assertions look at
+const maxAssertedBody = 1024 * 1024
+
+// maxReadBody limits how much of response body is read to count its size
+const maxReadBody = 10 * 1024 * 1024
+
+// milliseconds returns duration in milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// httpTrace collects timing of the last request of http task
+type httpTrace struct {
+	timing  api.HTTPTiming
+	address string
+
+	start, dnsStart, connectStart, tlsStart time.Time
+}
+
+func (t *httpTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GetConn: func(hostPort string) {
+			// every redirect starts new request with new connection
+			t.timing = api.HTTPTiming{}
+			t.start = time.Now()
+		},
+		DNSStart: func(httptrace.DNSStartInfo) { t.dnsStart = time.Now() },
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.timing.DNS = milliseconds(time.Since(t.dnsStart))
+		},
+		ConnectStart: func(network, addr string) { t.connectStart = time.Now() },
+		ConnectDone: func(network, addr string, err error) {
+			if err == nil {
+				t.timing.Connect = milliseconds(time.Since(t.connectStart))
+			}
+		},
+		TLSHandshakeStart: func() { t.tlsStart = time.Now() },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.timing.TLS = milliseconds(time.Since(t.tlsStart))
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.address = info.Conn.RemoteAddr().String()
+		},
+		GotFirstResponseByte: func() {
+			t.timing.FirstByte = milliseconds(time.Since(t.start))
+		},
+	}
+}
+
+// httpCheck makes request of http task and reports api.HTTPResult as JSON,
+// response is checked by assertions of params
+func httpCheck(ctx context.Context, p *api.HTTPParams) string {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = 10
+	}
+	connectTimeout := p.ConnectTimeout
+	if connectTimeout <= 0 || connectTimeout > timeout {
+		connectTimeout = timeout
+	}
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	method := p.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	result := api.HTTPResult{Method: method, URL: p.URL}
+
+	var body io.Reader
+	if p.Body != "" {
+		body = strings.NewReader(p.Body)
+	}
+	req, err := http.NewRequest(method, p.URL, body)
+	if err != nil {
+		result.Error = err.Error()
+		return httpResultJSON(result)
+	}
+	for name, value := range p.Headers {
+		if strings.EqualFold(name, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+	trace := &httpTrace{}
+	req = req.WithContext(httptrace.WithClientTrace(ctx, trace.clientTrace()))
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			DialContext:       (&net.Dialer{Timeout: time.Duration(connectTimeout) * time.Second}).DialContext,
+			DisableKeepAlives: true,
+			ForceAttemptHTTP2: true,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > p.MaxRedirects {
+				return http.ErrUseLastResponse
+			}
+			result.Redirects = len(via)
+			return nil
+		},
+	}
+
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		result.Timing = trace.timing
+		result.Timing.Total = milliseconds(time.Since(start))
+		result.Error = err.Error()
+		return httpResultJSON(result)
+	}
+	defer resp.Body.Close()
+
+	asserted, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxAssertedBody))
+	// one byte over the limit tells that body is longer
+	limit := maxReadBody - int64(len(asserted))
+	rest, _ := io.CopyN(ioutil.Discard, resp.Body, limit+1)
+	if rest > limit {
+		rest = limit
+		result.BodyTruncated = true
+	}
+	result.Timing = trace.timing
+	result.Timing.Total = milliseconds(time.Since(start))
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	result.FinalURL = resp.Request.URL.String()
+	result.Address = trace.address
+	result.Proto = resp.Proto
+	result.StatusCode = resp.StatusCode
+	result.Headers = resp.Header
+	result.BodySize = int64(len(asserted)) + rest
+
+	result.Assertions = httpAssertions(p.Assert, resp, asserted, result.Timing.Total)
+	result.Passed = result.Error == ""
+	for _, assertion := range result.Assertions {
+		result.Passed = result.Passed && assertion.Passed
+	}
+
+	return httpResultJSON(result)
+}
+
+// httpAssertions checks response by assertions, body is its first
+// maxAssertedBody bytes and latency total time in milliseconds
+func httpAssertions(assert *api.HTTPAssertions, resp *http.Response, body []byte, latency float64) []api.HTTPAssertion {
+	if assert == nil {
+		return nil
+	}
+
+	var assertions []api.HTTPAssertion
+	if len(assert.Status) > 0 {
+		expected := make([]string, len(assert.Status))
+		passed := false
+		for i, status := range assert.Status {
+			expected[i] = strconv.Itoa(status)
+			passed = passed || status == resp.StatusCode
+		}
+		assertions = append(assertions, api.HTTPAssertion{
+			Check:    "status",
+			Expected: strings.Join(expected, ","),
+			Actual:   strconv.Itoa(resp.StatusCode),
+			Passed:   passed,
+		})
+	}
+
+	names := make([]string, 0, len(assert.Headers))
+	for name := range assert.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := assert.Headers[name]
+		actual, present := resp.Header[http.CanonicalHeaderKey(name)]
+		joined := strings.Join(actual, ", ")
+		assertions = append(assertions, api.HTTPAssertion{
+			Check:    "header:" + name,
+			Expected: value,
+			Actual:   joined,
+			Passed:   present && strings.Contains(joined, value),
+		})
+	}
+
+	if assert.BodyContains != "" {
+		passed := strings.Contains(string(body), assert.BodyContains)
+		assertions = append(assertions, api.HTTPAssertion{
+			Check:    "body_contains",
+			Expected: assert.BodyContains,
+			Actual:   strconv.FormatBool(passed),
+			Passed:   passed,
+		})
+	}
+
+	if assert.BodyRegex != "" {
+		assertion := api.HTTPAssertion{Check: "body_regex", Expected: assert.BodyRegex}
+		if re, err := regexp.Compile(assert.BodyRegex); err != nil {
+			assertion.Actual = err.Error()
+		} else {
+			assertion.Actual = re.FindString(string(body))
+			assertion.Passed = re.Match(body)
+		}
+		assertions = append(assertions, assertion)
+	}
+
+	if assert.MaxLatency > 0 {
+		assertions = append(assertions, api.HTTPAssertion{
+			Check:    "max_latency_ms",
+			Expected: strconv.FormatInt(assert.MaxLatency, 10),
+			Actual:   strconv.FormatFloat(latency, 'f', 1, 64),
+			Passed:   latency <= float64(assert.MaxLatency),
+		})
+	}
+
+	return assertions
+}
+
+// httpResultJSON returns result as JSON, headers are dropped when result
+// would be longer than api.MaxResultSize
+func httpResultJSON(result api.HTTPResult) string {
+	js, err := json.Marshal(result)
+	if err == nil && len(js) > api.MaxResultSize {
+		result.Headers = nil
+		js, err = json.Marshal(result)
+	}
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return string(js)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ad/gocc/api"
+)
+
+func TestHTTPCheckBodySize(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		size := maxReadBody - 1
+		if r.URL.Path == "/long" {
+			size = maxReadBody + 1
+		}
+		w.Write([]byte(strings.Repeat("x", size)))
+	}))
+	defer srv.Close()
+
+	for path, truncated := range map[string]bool{"/short": false, "/long": true} {
+		var result api.HTTPResult
+		if err := json.Unmarshal([]byte(httpCheck(context.Background(), &api.HTTPParams{URL: srv.URL + path})), &result); err != nil {
+			t.Fatal(err)
+		}
+
+		size := int64(maxReadBody - 1)
+		if truncated {
+			size = maxReadBody
+		}
+		if result.BodySize != size || result.BodyTruncated != truncated {
+			t.Errorf("%s: body_size %d, truncated %v, want %d, %v", path, result.BodySize, result.BodyTruncated, size, truncated)
+		}
+	}
+}
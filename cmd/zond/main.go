@@ -0,0 +1,132 @@
+// Command zond is reference probe for gocc: it subscribes to task channels,
+// claims tasks and runs ping, head, dns, traceroute, tcp, tls and http.
+//
+//	ZOND_SECRET=<zond secret> zond -server https://cc.example.com -uuid <zond uuid>
+//
+// With certificate enrollment enabled zond exchanges one-time token for
+// client certificate once and is identified by it afterwards:
+//
+//	zond -server https://cc.example.com -enroll <token> -cert zond.crt -key zond.key -ca ca.crt
+//	zond -server https://cc.example.com -cert zond.crt -key zond.key -ca ca.crt
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ad/gocc/agent"
+)
+
+func main() {
+	server := flag.String("server", "http://127.0.0.1", "gocc address")
+	zondUUID := flag.String("uuid", os.Getenv("ZOND_UUID"), "zond uuid, created at /zond/my")
+	secret := flag.String("secret", os.Getenv("ZOND_SECRET"), "zond secret, shown once on creation, prefer ZOND_SECRET environment variable")
+	enroll := flag.String("enroll", "", "One-time enrollment token, certificate is written to -cert, -key and -ca files")
+	certFile := flag.String("cert", "", "Client certificate of zond, used instead of secret")
+	keyFile := flag.String("key", "", "Private key of client certificate")
+	caFile := flag.String("ca", "", "CA certificate of gocc")
+	network := flag.String("network", "auto", "Address families zond reaches, e.g. ipv4,ipv6, auto detects them")
+	flag.Parse()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpClient, err := certificateClient(ctx, *server, *enroll, *certFile, *keyFile, *caFile, zondUUID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *zondUUID == "" {
+		log.Fatal("zond uuid is required")
+	}
+	if *secret == "" && httpClient == nil {
+		log.Fatal("zond secret or certificate is required")
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		cancel()
+	}()
+
+	z := agent.NewZond(*server, *zondUUID, *secret, agent.ExecutorFunc(Execute))
+	if httpClient != nil {
+		z.HTTPClient = httpClient
+	}
+	z.Networks = parseNetworks(*network)
+
+	log.Println("zond", *zondUUID, "connecting to", *server, "networks", z.Networks)
+	if err := z.Run(ctx); err != nil && err != context.Canceled {
+		log.Fatal(err)
+	}
+}
+
+// certificateClient enrolls zond when token is given and returns http client
+// with client certificate, nil when certificate is not configured. Zond uuid
+// is taken from certificate
+func certificateClient(ctx context.Context, server string, token string, certFile string, keyFile string, caFile string, zondUUID *string) (*http.Client, error) {
+	if certFile == "" {
+		if token != "" {
+			return nil, errors.New("-cert and -key are required for enrollment")
+		}
+		return nil, nil
+	}
+	if keyFile == "" {
+		return nil, errors.New("-key is required with -cert")
+	}
+
+	if token != "" {
+		enrollment, err := agent.Enroll(ctx, &http.Client{Timeout: 30 * time.Second}, server, token)
+		if err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(keyFile, enrollment.Key, 0600); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(certFile, enrollment.Certificate, 0644); err != nil {
+			return nil, err
+		}
+		if caFile != "" {
+			if err := ioutil.WriteFile(caFile, enrollment.CA, 0644); err != nil {
+				return nil, err
+			}
+		}
+		log.Println("zond", enrollment.UUID, "enrolled, certificate is written to", certFile)
+	}
+
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	var caPEM []byte
+	if caFile != "" {
+		if caPEM, err = ioutil.ReadFile(caFile); err != nil {
+			return nil, err
+		}
+	}
+
+	uuid, err := agent.CertificateUUID(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	if *zondUUID != "" && *zondUUID != uuid {
+		return nil, fmt.Errorf("certificate is issued to zond %s, not %s", uuid, *zondUUID)
+	}
+	*zondUUID = uuid
+
+	return agent.TLSClient(certPEM, keyPEM, caPEM)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"net"
+	"strings"
+
+	"github.com/ad/gocc/api"
+)
+
+// probeAddrs are addresses used to check that zond has route to address family
+var probeAddrs = map[string]string{
+	api.NetworkIPv4: "8.8.8.8:53",
+	api.NetworkIPv6: "[2001:4860:4860::8888]:53",
+}
+
+// detectNetworks returns address families zond has route to. UDP dial
+// sends nothing, it only picks source address
+func detectNetworks() []string {
+	var families []string
+	for _, family := range []string{api.NetworkIPv4, api.NetworkIPv6} {
+		conn, err := net.Dial("udp", probeAddrs[family])
+		if err != nil {
+			continue
+		}
+		conn.Close()
+		families = append(families, family)
+	}
+	return families
+}
+
+// parseNetworks reads -network flag value, auto detects families
+func parseNetworks(value string) []string {
+	if value == "" || value == "auto" {
+		return detectNetworks()
+	}
+
+	var families []string
+	for _, family := range strings.Split(value, ",") {
+		families = append(families, strings.ToLower(strings.TrimSpace(family)))
+	}
+	return families
+}
+
+// isIPv6 reports whether host is IPv6 address
+func isIPv6(host string) bool {
+	ip := net.ParseIP(host)
+	return ip != nil && ip.To4() == nil
+}
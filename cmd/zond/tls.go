@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/ad/gocc/api"
+	"golang.org/x/crypto/ocsp"
+)
+
+// tlsVersions are names of protocol versions reported in api.TLSResult
+var tlsVersions = map[uint16]string{
+	tls.VersionTLS10: "TLS 1.0",
+	tls.VersionTLS11: "TLS 1.1",
+	tls.VersionTLS12: "TLS 1.2",
+	tls.VersionTLS13: "TLS 1.3",
+}
+
+// ocspStatuses are names of OCSP certificate statuses
+var ocspStatuses = map[int]string{
+	ocsp.Good:    "good",
+	ocsp.Revoked: "revoked",
+	ocsp.Unknown: "unknown",
+}
+
+// tlsCheck makes TLS handshake with port of host and reports api.TLSResult
+// as JSON. Chain is verified after handshake, so certificates that don't
+// verify are reported too
+func tlsCheck(ctx context.Context, p *api.TLSParams) string {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = 10
+	}
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	port := p.Port
+	if port == 0 {
+		port = api.DefaultTLSPort
+	}
+	serverName := p.SNI
+	if serverName == "" && net.ParseIP(p.Host) == nil {
+		serverName = p.Host
+	}
+	result := api.TLSResult{Host: p.Host, Port: port, ServerName: serverName}
+
+	var d net.Dialer
+	start := time.Now()
+	rawConn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(p.Host, strconv.Itoa(port)))
+	if err != nil {
+		result.Error = err.Error()
+		return tlsResultJSON(result)
+	}
+	defer rawConn.Close()
+	result.Address = rawConn.RemoteAddr().String()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		rawConn.SetDeadline(deadline)
+	}
+	conn := tls.Client(rawConn, &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: true,
+	})
+	if err := conn.Handshake(); err != nil {
+		result.Error = err.Error()
+		return tlsResultJSON(result)
+	}
+	result.HandshakeTime = float64(time.Since(start)) / float64(time.Millisecond)
+
+	state := conn.ConnectionState()
+	result.Protocol = tlsVersions[state.Version]
+	if result.Protocol == "" {
+		result.Protocol = "0x" + strconv.FormatUint(uint64(state.Version), 16)
+	}
+	result.CipherSuite = tls.CipherSuiteName(state.CipherSuite)
+
+	for _, cert := range state.PeerCertificates {
+		result.Chain = append(result.Chain, certificateInfo(cert))
+	}
+	if len(state.PeerCertificates) > 0 {
+		leaf := state.PeerCertificates[0]
+		result.Expires = leaf.NotAfter.Unix()
+		result.DaysLeft = int64(time.Until(leaf.NotAfter) / (24 * time.Hour))
+		if err := verifyChain(state.PeerCertificates, serverName, p.Host); err != nil {
+			result.VerifyError = err.Error()
+		} else {
+			result.Verified = true
+		}
+	}
+
+	if len(state.OCSPResponse) > 0 {
+		result.OCSPStapled = true
+		var issuer *x509.Certificate
+		if len(state.PeerCertificates) > 1 {
+			issuer = state.PeerCertificates[1]
+		}
+		if resp, err := ocsp.ParseResponse(state.OCSPResponse, issuer); err == nil {
+			result.OCSPStatus = ocspStatuses[resp.Status]
+		}
+	}
+
+	return tlsResultJSON(result)
+}
+
+// verifyChain verifies leaf with system roots and intermediates sent by
+// server for server name, or for host when there is none
+func verifyChain(certs []*x509.Certificate, serverName string, host string) error {
+	if serverName == "" {
+		serverName = host
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		DNSName:       serverName,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+// certificateInfo returns certificate of chain of api.TLSResult
+func certificateInfo(cert *x509.Certificate) api.TLSCertificate {
+	fingerprint := sha256.Sum256(cert.Raw)
+	info := api.TLSCertificate{
+		Subject:            cert.Subject.String(),
+		Issuer:             cert.Issuer.String(),
+		Serial:             cert.SerialNumber.Text(16),
+		NotBefore:          cert.NotBefore.Unix(),
+		NotAfter:           cert.NotAfter.Unix(),
+		DNSNames:           cert.DNSNames,
+		SignatureAlgorithm: cert.SignatureAlgorithm.String(),
+		SHA256:             hex.EncodeToString(fingerprint[:]),
+	}
+	for _, ip := range cert.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	return info
+}
+
+func tlsResultJSON(result api.TLSResult) string {
+	js, err := json.Marshal(result)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return string(js)
+}
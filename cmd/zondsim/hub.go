@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ad/gocc/api"
+)
+
+// hub replaces nginx nchan: gocc publishes to it with -nchanaddr and it
+// delivers messages to simulated zonds subscribed to channels
+type hub struct {
+	stats *stats
+
+	mu       sync.RWMutex
+	channels map[string]map[*simZond]bool
+}
+
+func newHub(stats *stats) *hub {
+	return &hub{stats: stats, channels: make(map[string]map[*simZond]bool)}
+}
+
+func (h *hub) subscribe(z *simZond, channels []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, channel := range channels {
+		if h.channels[channel] == nil {
+			h.channels[channel] = make(map[*simZond]bool)
+		}
+		h.channels[channel][z] = true
+	}
+}
+
+func (h *hub) unsubscribe(z *simZond, channels []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, channel := range channels {
+		delete(h.channels[channel], z)
+		if len(h.channels[channel]) == 0 {
+			delete(h.channels, channel)
+		}
+	}
+}
+
+func (h *hub) subscribers(channel string) []*simZond {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	result := make([]*simZond, 0, len(h.channels[channel]))
+	for z := range h.channels[channel] {
+		result = append(result, z)
+	}
+	return result
+}
+
+// online is count of zonds subscribed to common tasks channel
+func (h *hub) online() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.channels["tasks"])
+}
+
+func (h *hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, "/pub/") {
+		http.NotFound(w, r)
+		return
+	}
+	channel := strings.TrimPrefix(r.URL.Path, "/pub/")
+
+	switch r.Method {
+	case http.MethodPost:
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "Error reading request body", http.StatusInternalServerError)
+			return
+		}
+		h.publish(channel, body)
+	case http.MethodDelete:
+		// nchan closes subscribers of deleted channel, CheckAlive does it for lost zonds
+		for _, z := range h.subscribers(channel) {
+			z.kick()
+		}
+	default:
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *hub) publish(channel string, body []byte) {
+	var action api.Action
+	if err := json.Unmarshal(body, &action); err != nil {
+		log.Println(channel, err)
+		return
+	}
+
+	switch {
+	case channel == "tasks/done":
+		h.stats.taskDone(action.UUID)
+		return
+	case channel == "destinations", channel == "mngrtasks":
+		return
+	case action.Action != api.ActionAlive:
+		h.stats.taskPublished(action.UUID)
+	}
+
+	for _, z := range h.subscribers(channel) {
+		z.deliver(action)
+	}
+}
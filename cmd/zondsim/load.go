@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ad/gocc/api"
+	"github.com/ad/gocc/client"
+)
+
+var taskParams = map[string]string{
+	"ping":       "8.8.8.8",
+	"traceroute": "1.1.1.1",
+	"head":       "http://example.com",
+	"dns":        "example.com-8.8.8.8",
+}
+
+// credentials of simulated zond
+type credentials struct {
+	UUID   string
+	Secret string
+}
+
+// ensureZonds returns credentials of count zonds owned by simulator user.
+// Secrets are shown only once, so existing zonds get new credentials with
+// rotation and missing ones are created
+func ensureZonds(ctx context.Context, c *client.Client, count int) ([]credentials, error) {
+	var existing []string
+	for page := 1; len(existing) < count; page++ {
+		list, err := c.ListZonds(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, zond := range list.Results {
+			existing = append(existing, zond.UUID)
+		}
+		if !list.HasNext {
+			break
+		}
+	}
+	if len(existing) > count {
+		existing = existing[:count]
+	}
+
+	var result []credentials
+	for _, uuid := range existing {
+		resp, err := c.RotateZond(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, credentials{UUID: resp.Zond.UUID, Secret: resp.Secret})
+	}
+
+	for len(result) < count {
+		resp, err := c.CreateZond(ctx, "zondsim")
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, credentials{UUID: resp.UUID, Secret: resp.Secret})
+	}
+
+	return result, nil
+}
+
+// generate creates tasks with given rate until ctx is done
+func (s *sim) generate(ctx context.Context, c *client.Client, rate float64) {
+	if rate <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	actions := make([]string, 0, len(taskParams))
+	for action := range taskParams {
+		actions = append(actions, action)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		action := actions[rand.Intn(len(actions))]
+		req := api.TaskCreateRequest{Action: action, Param: taskParams[action], Dest: s.destination()}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			// task must be created even when generation is stopped in the middle of request
+			task, err := c.CreateTask(context.Background(), req)
+			if err != nil {
+				s.stats.add(&s.stats.createErrors)
+				s.logf("create task: %v", err)
+				return
+			}
+			s.stats.taskCreated(task.UUID, start)
+		}()
+	}
+}
+
+// destination picks geo channel of one of simulated locations or common tasks channel
+func (s *sim) destination() string {
+	if !s.chance(s.geoDest) {
+		return ""
+	}
+
+	g := s.geos[rand.Intn(len(s.geos))]
+	switch rand.Intn(3) {
+	case 0:
+		return "zond:city:" + g.City
+	case 1:
+		return "zond:country:" + g.Country
+	}
+	return "zond:asn:" + g.ASN
+}
@@ -0,0 +1,169 @@
+// Command zondsim is load-test harness for gocc: it runs hundreds of fake
+// zonds with configurable geo data, latency, failures, disconnects and missed
+// pongs against real claim, result and pong endpoints, generates tasks and
+// reports throughput, claim contention and stuck tasks.
+//
+// zondsim takes place of nginx: it receives messages gocc publishes to nchan
+// and makes subscribe callbacks itself. Start gocc pointing to it, with rate
+// limits disabled, since all zonds share one address:
+//
+//	gocc -nchanaddr http://127.0.0.1:9080 -nolimit
+//	zondsim -server http://127.0.0.1:9000 -listen 127.0.0.1:9080 -zonds 300 -rate 50
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ad/gocc/client"
+)
+
+type sim struct {
+	server string
+	hub    *hub
+	stats  *stats
+	geos   []geo
+
+	latency    time.Duration
+	jitter     time.Duration
+	fail       float64
+	drop       float64
+	disconnect float64
+	offline    time.Duration
+	missPong   float64
+	geoDest    float64
+	verbose    bool
+}
+
+func (s *sim) logf(format string, v ...interface{}) {
+	if s.verbose {
+		log.Printf(format, v...)
+	}
+}
+
+// parseGeo reads list of city/country/asn separated by commas
+func parseGeo(value string) ([]geo, error) {
+	var result []geo
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(item), "/")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("wrong geo %q, must be city/country/asn", item)
+		}
+		result = append(result, geo{City: parts[0], Country: parts[1], ASN: parts[2]})
+	}
+	return result, nil
+}
+
+func main() {
+	server := flag.String("server", "http://127.0.0.1:9000", "gocc address, without nginx")
+	listen := flag.String("listen", "127.0.0.1:9080", "Address for nchan publish requests of gocc")
+	user := flag.String("user", "zondsim@localhost", "Owner of simulated zonds and tasks")
+	zonds := flag.Int("zonds", 100, "Number of simulated zonds")
+	geoList := flag.String("geo", "Moscow/RU/8359,Berlin/DE/3320,Amsterdam/NL/1136,New York/US/7922", "Fake locations as city/country/asn, zonds are spread between them")
+	rate := flag.Float64("rate", 10, "Tasks created per second")
+	geoDest := flag.Float64("geodest", 0.3, "Part of tasks sent to city, country or asn channel instead of common one")
+	duration := flag.Duration("duration", 2*time.Minute, "How long tasks are generated")
+	drain := flag.Duration("drain", 3*time.Minute, "How long to wait for results after generation, covers ResetProcessing and CheckAlive periods")
+	interval := flag.Duration("report", 10*time.Second, "Progress report interval")
+
+	s := &sim{}
+	flag.DurationVar(&s.latency, "latency", 500*time.Millisecond, "Mean task execution time")
+	flag.DurationVar(&s.jitter, "jitter", 250*time.Millisecond, "Random deviation of task execution time")
+	flag.Float64Var(&s.fail, "fail", 0.05, "Probability of failed task result")
+	flag.Float64Var(&s.drop, "drop", 0.01, "Probability that zond claims task and never reports result")
+	flag.Float64Var(&s.disconnect, "disconnect", 0.005, "Probability of disconnect, checked every 10 seconds for each zond")
+	flag.DurationVar(&s.offline, "offline", 30*time.Second, "How long disconnected zond stays offline")
+	flag.Float64Var(&s.missPong, "misspong", 0.02, "Probability to ignore alive check")
+	flag.BoolVar(&s.verbose, "v", false, "Log every failed request")
+	flag.Parse()
+
+	rand.Seed(time.Now().UnixNano())
+
+	geos, err := parseGeo(*geoList)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s.server = strings.TrimRight(*server, "/")
+	s.geos = geos
+	s.geoDest = *geoDest
+	s.stats = newStats()
+	s.hub = newHub(s.stats)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		cancel()
+	}()
+
+	go func() {
+		log.Fatal(http.ListenAndServe(*listen, s.hub))
+	}()
+
+	c, err := client.New(s.server, client.WithUser(*user))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	zondList, err := ensureZonds(ctx, c, *zonds)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("starting %d zonds in %d locations", len(zondList), len(geos))
+
+	zondsCtx, stopZonds := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	for i, zond := range zondList {
+		z := newSimZond(s, zond, geos[i%len(geos)])
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			z.run(zondsCtx)
+		}()
+	}
+
+	start := time.Now()
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	go func() {
+		for range ticker.C {
+			log.Println(s.stats.progress(time.Since(start), s.hub.online()))
+		}
+	}()
+
+	genCtx, stopGen := context.WithTimeout(ctx, *duration)
+	s.generate(genCtx, c, *rate)
+	stopGen()
+	log.Println("task generation finished, waiting for results")
+
+	deadline := time.After(*drain)
+wait:
+	for len(s.stats.pending()) > 0 {
+		select {
+		case <-ctx.Done():
+			break wait
+		case <-deadline:
+			break wait
+		case <-time.After(time.Second):
+		}
+	}
+
+	stopZonds()
+	wg.Wait()
+
+	s.stats.report(os.Stdout, time.Since(start), s.stats.pending())
+}
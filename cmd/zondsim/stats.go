@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// stats are updated by zonds, hub and task generator concurrently
+type stats struct {
+	created      int64
+	createErrors int64
+	published    int64
+	republished  int64
+	delivered    int64
+	queueFull    int64
+
+	claimed      int64
+	contention   int64
+	busy         int64
+	claimErrors  int64
+	failed       int64
+	dropped      int64
+	reported     int64
+	rejected     int64
+	completed    int64
+	pongs        int64
+	pongsMissed  int64
+	pongErrors   int64
+	disconnects  int64
+	kicked       int64
+	duplicates   int64
+	connectCalls int64
+
+	mu        sync.Mutex
+	started   map[string]time.Time
+	early     map[string]time.Time
+	done      map[string]bool
+	publishes map[string]int
+	latencies []time.Duration
+}
+
+func newStats() *stats {
+	return &stats{
+		started:   make(map[string]time.Time),
+		early:     make(map[string]time.Time),
+		done:      make(map[string]bool),
+		publishes: make(map[string]int),
+	}
+}
+
+func (s *stats) add(counter *int64) {
+	atomic.AddInt64(counter, 1)
+}
+
+func (s *stats) get(counter *int64) int64 {
+	return atomic.LoadInt64(counter)
+}
+
+// taskCreated remembers start of task, result may be already published before create request returned
+func (s *stats) taskCreated(uuid string, start time.Time) {
+	s.add(&s.created)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.started[uuid] = start
+	if doneAt, ok := s.early[uuid]; ok {
+		delete(s.early, uuid)
+		s.latencies = append(s.latencies, doneAt.Sub(start))
+	}
+}
+
+// taskPublished counts publications of task, more than one means task was resent by gocc
+func (s *stats) taskPublished(uuid string) {
+	s.add(&s.published)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.publishes[uuid]++
+	if s.publishes[uuid] > 1 {
+		s.add(&s.republished)
+	}
+}
+
+func (s *stats) taskDone(uuid string) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.done[uuid] {
+		s.add(&s.duplicates)
+		return
+	}
+	s.done[uuid] = true
+	s.add(&s.completed)
+
+	if start, ok := s.started[uuid]; ok {
+		s.latencies = append(s.latencies, now.Sub(start))
+	} else {
+		s.early[uuid] = now
+	}
+}
+
+// pending returns tasks created by simulator without result
+func (s *stats) pending() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []string
+	for uuid := range s.started {
+		if !s.done[uuid] {
+			result = append(result, uuid)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (s *stats) percentiles() (avg, p50, p95, max time.Duration) {
+	s.mu.Lock()
+	latencies := append([]time.Duration(nil), s.latencies...)
+	s.mu.Unlock()
+
+	if len(latencies) == 0 {
+		return
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	var sum time.Duration
+	for _, l := range latencies {
+		sum += l
+	}
+	avg = sum / time.Duration(len(latencies))
+	p50 = latencies[len(latencies)*50/100]
+	p95 = latencies[len(latencies)*95/100]
+	max = latencies[len(latencies)-1]
+	return
+}
+
+// progress is short line printed while simulation is running
+func (s *stats) progress(elapsed time.Duration, online int) string {
+	return fmt.Sprintf("%6s online=%d created=%d claimed=%d completed=%d contention=%d busy=%d dropped=%d republished=%d",
+		elapsed.Truncate(time.Second), online, s.get(&s.created), s.get(&s.claimed), s.get(&s.completed),
+		s.get(&s.contention), s.get(&s.busy), s.get(&s.dropped), s.get(&s.republished))
+}
+
+func (s *stats) report(w io.Writer, elapsed time.Duration, stuck []string) {
+	avg, p50, p95, max := s.percentiles()
+	completed := s.get(&s.completed)
+
+	// results of tasks that were not created by simulator, e.g. repeatable ones
+	s.mu.Lock()
+	unknown := len(s.early)
+	s.mu.Unlock()
+
+	fmt.Fprintf(w, "\nduration:         %s\n", elapsed.Truncate(time.Millisecond))
+	fmt.Fprintf(w, "tasks created:    %d (errors %d)\n", s.get(&s.created), s.get(&s.createErrors))
+	fmt.Fprintf(w, "tasks completed:  %d (duplicates %d, unknown %d)\n", completed, s.get(&s.duplicates), unknown)
+	fmt.Fprintf(w, "throughput:       %.2f tasks/s\n", float64(completed)/elapsed.Seconds())
+	fmt.Fprintf(w, "latency:          avg %s, p50 %s, p95 %s, max %s\n",
+		avg.Truncate(time.Millisecond), p50.Truncate(time.Millisecond), p95.Truncate(time.Millisecond), max.Truncate(time.Millisecond))
+	fmt.Fprintf(w, "published:        %d (republished %d, delivered %d, lost by slow zonds %d)\n",
+		s.get(&s.published), s.get(&s.republished), s.get(&s.delivered), s.get(&s.queueFull))
+	fmt.Fprintf(w, "claims:           %d ok, %d taken by other zond, %d zond busy, %d errors\n",
+		s.get(&s.claimed), s.get(&s.contention), s.get(&s.busy), s.get(&s.claimErrors))
+	fmt.Fprintf(w, "results:          %d reported (%d failed), %d rejected, %d dropped by zond\n",
+		s.get(&s.reported), s.get(&s.failed), s.get(&s.rejected), s.get(&s.dropped))
+	fmt.Fprintf(w, "pongs:            %d ok, %d missed, %d rejected\n", s.get(&s.pongs), s.get(&s.pongsMissed), s.get(&s.pongErrors))
+	fmt.Fprintf(w, "connections:      %d subscribes, %d disconnects, %d removed by gocc\n",
+		s.get(&s.connectCalls), s.get(&s.disconnects), s.get(&s.kicked))
+	fmt.Fprintf(w, "stuck tasks:      %d\n", len(stuck))
+	for i, uuid := range stuck {
+		if i == 10 {
+			fmt.Fprintf(w, "  ...\n")
+			break
+		}
+		fmt.Fprintf(w, "  %s\n", uuid)
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/ad/gocc/agent"
+	"github.com/ad/gocc/api"
+)
+
+// geo is fake location of simulated zond
+type geo struct {
+	City    string
+	Country string
+	ASN     string
+}
+
+// simZond talks to real claim, result and pong endpoints, but receives
+// messages from hub instead of nchan
+type simZond struct {
+	*agent.Zond
+	sim *sim
+	geo geo
+
+	inbox  chan api.Action
+	kicked chan struct{}
+}
+
+func newSimZond(s *sim, zond credentials, g geo) *simZond {
+	return &simZond{
+		Zond:   agent.NewZond(s.server, zond.UUID, zond.Secret, nil),
+		sim:    s,
+		geo:    g,
+		inbox:  make(chan api.Action, 100),
+		kicked: make(chan struct{}, 1),
+	}
+}
+
+// channels are the same as nginx gives to zond: own channel, geo channels and common tasks
+func (z *simZond) channels() []string {
+	return []string{"zond:" + z.UUID, "City:" + z.geo.City, "Country:" + z.geo.Country, "ASN:" + z.geo.ASN, "tasks"}
+}
+
+func (z *simZond) deliver(action api.Action) {
+	select {
+	case z.inbox <- action:
+		z.sim.stats.add(&z.sim.stats.delivered)
+	default:
+		z.sim.stats.add(&z.sim.stats.queueFull)
+	}
+}
+
+func (z *simZond) kick() {
+	select {
+	case z.kicked <- struct{}{}:
+	default:
+	}
+}
+
+func (z *simZond) connect(ctx context.Context) {
+	z.sim.hub.subscribe(z, z.channels())
+	z.sim.stats.add(&z.sim.stats.connectCalls)
+	z.callback(ctx, "/zond/sub")
+}
+
+func (z *simZond) disconnect(ctx context.Context) {
+	z.sim.hub.unsubscribe(z, z.channels())
+	z.callback(ctx, "/zond/unsub")
+}
+
+// callback makes request nginx sends to gocc on nchan subscribe and unsubscribe
+func (z *simZond) callback(ctx context.Context, path string) {
+	req, err := http.NewRequest(http.MethodGet, z.Server+path, nil)
+	if err != nil {
+		return
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set(api.HeaderZondUUID, z.UUID)
+	req.Header.Set("X-Subscriber-Type", "eventsource")
+	for i, channel := range z.channels() {
+		req.Header.Set(fmt.Sprintf("X-Channel-Id%d", i), channel)
+	}
+
+	resp, err := z.HTTPClient.Do(req)
+	if err != nil {
+		z.sim.logf("%s %s: %v", z.UUID, path, err)
+		return
+	}
+	resp.Body.Close()
+}
+
+func (z *simZond) run(ctx context.Context) {
+	z.connect(ctx)
+
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// ctx is already canceled, unsubscribe must still reach gocc
+			z.disconnect(context.Background())
+			return
+		case action := <-z.inbox:
+			z.handle(ctx, action)
+		case <-z.kicked:
+			z.sim.stats.add(&z.sim.stats.kicked)
+			z.sim.hub.unsubscribe(z, z.channels())
+			z.offline(ctx, z.Reconnect)
+		case <-ticker.C:
+			if z.sim.chance(z.sim.disconnect) {
+				z.sim.stats.add(&z.sim.stats.disconnects)
+				z.disconnect(ctx)
+				z.offline(ctx, z.sim.offline)
+			}
+		}
+	}
+}
+
+// offline waits with closed subscription, messages sent meanwhile are lost as with nchan
+func (z *simZond) offline(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(d):
+	}
+
+	for len(z.inbox) > 0 {
+		<-z.inbox
+	}
+	z.connect(ctx)
+}
+
+func (z *simZond) handle(ctx context.Context, action api.Action) {
+	switch action.Action {
+	case api.ActionAlive:
+		go z.pong(ctx, action)
+	case api.ActionDestinations, api.ActionUpdated, api.ActionResult, "":
+	default:
+		z.process(ctx, action)
+	}
+}
+
+func (z *simZond) pong(ctx context.Context, action api.Action) {
+	st := z.sim.stats
+	if z.sim.chance(z.sim.missPong) {
+		st.add(&st.pongsMissed)
+		return
+	}
+	if err := z.Pong(ctx, action.UUID); err != nil {
+		st.add(&st.pongErrors)
+		return
+	}
+	st.add(&st.pongs)
+}
+
+func (z *simZond) process(ctx context.Context, action api.Action) {
+	st := z.sim.stats
+
+	attempt, err := z.Block(ctx, action)
+	switch err {
+	case nil:
+		st.add(&st.claimed)
+		action.Attempt = attempt
+	case agent.ErrNotClaimed:
+		st.add(&st.contention)
+		return
+	case agent.ErrBusy:
+		st.add(&st.busy)
+		return
+	default:
+		st.add(&st.claimErrors)
+		z.sim.logf("%s claim %s: %v", z.UUID, action.UUID, err)
+		return
+	}
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(z.sim.taskLatency()):
+	}
+
+	if z.sim.chance(z.sim.drop) {
+		// zond crashed after claim, task stays in tasks-process until ResetProcessing
+		st.add(&st.dropped)
+		return
+	}
+
+	result := fmt.Sprintf("simulated %s of %s from %s", action.Action, action.Param, z.geo.City)
+	if z.sim.chance(z.sim.fail) {
+		st.add(&st.failed)
+		result = "error: simulated failure"
+	}
+
+	if err := z.SendResult(ctx, action, result); err != nil {
+		st.add(&st.rejected)
+		z.sim.logf("%s result %s: %v", z.UUID, action.UUID, err)
+		return
+	}
+	st.add(&st.reported)
+}
+
+func (s *sim) chance(p float64) bool {
+	return p > 0 && rand.Float64() < p
+}
+
+func (s *sim) taskLatency() time.Duration {
+	d := s.latency
+	if s.jitter > 0 {
+		d += time.Duration(rand.Int63n(int64(2*s.jitter))) - s.jitter
+	}
+	if d < 0 {
+		d = 0
+	}
+	return d
+}
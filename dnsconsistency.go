@@ -0,0 +1,101 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ad/gocc/api"
+)
+
+// dnsAnswerKey returns answer of dns result comparable between zonds: sorted
+// distinct values, names are case insensitive. Text results of older zonds
+// have no rcode, they are taken as NOERROR
+func dnsAnswerKey(result api.DNSResult) (string, []string) {
+	if result.Error != "" {
+		return "error\n" + result.Error, []string{}
+	}
+
+	seen := map[string]bool{}
+	values := []string{}
+	for _, answer := range result.Answers {
+		value := answer.Value
+		if answer.Type != "TXT" && answer.Type != "CAA" {
+			value = strings.ToLower(value)
+		}
+		if !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+
+	rcode := result.RCode
+	if rcode == "" {
+		rcode = "NOERROR"
+	}
+	return rcode + "\n" + strings.Join(values, "\n"), values
+}
+
+// GetDNSConsistency groups child tasks of dns measurement of user by their
+// answers, zonds outside of the majority group got inconsistent answers
+func GetDNSConsistency(userUUID string, taskUUID string) (DNSConsistencyResponse, *ApiError) {
+	t, apiErr := GetUserTask(userUUID, taskUUID)
+	if apiErr != nil {
+		return DNSConsistencyResponse{}, apiErr
+	}
+	if t.Action != "dns" {
+		return DNSConsistencyResponse{}, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "task is not dns measurement")
+	}
+
+	response := DNSConsistencyResponse{Status: "ok", Task: t, Groups: []DNSAnswerGroup{}}
+
+	children, _ := Client.SMembers("task/" + t.UUID + "/children").Result()
+	groups := map[string]*DNSAnswerGroup{}
+	var keys []string
+	for _, child := range loadTasks(children) {
+		if child.Result == "" {
+			response.Missing++
+			continue
+		}
+		response.Answered++
+
+		result, err := api.ParseDNSResult(child.Result)
+		if err != nil {
+			result = api.DNSResult{Error: "unparsable result"}
+		}
+		key, values := dnsAnswerKey(result)
+
+		group, ok := groups[key]
+		if !ok {
+			group = &DNSAnswerGroup{Answers: values, RCode: result.RCode, Error: result.Error, Zonds: []DNSZondAnswer{}}
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		group.Zonds = append(group.Zonds, DNSZondAnswer{
+			Task:     child.UUID,
+			Zond:     taskZond(child, TaskClaims(child.UUID)),
+			Resolver: result.Resolver,
+			DNSSEC:   result.DNSSEC,
+			RTT:      result.RTT,
+		})
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if len(groups[keys[i]].Zonds) != len(groups[keys[j]].Zonds) {
+			return len(groups[keys[i]].Zonds) > len(groups[keys[j]].Zonds)
+		}
+		return keys[i] < keys[j]
+	})
+	for _, key := range keys {
+		response.Groups = append(response.Groups, *groups[key])
+	}
+
+	// tie of the largest groups has no majority
+	if len(response.Groups) == 1 || (len(response.Groups) > 1 && len(response.Groups[0].Zonds) > len(response.Groups[1].Zonds)) {
+		response.Groups[0].Majority = true
+	}
+	response.Consistent = len(response.Groups) <= 1
+
+	return response, nil
+}
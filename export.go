@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ad/gocc/api"
+)
+
+// Export formats, ndjson is the same as jsonl
+const (
+	exportCSV    = "csv"
+	exportJSONL  = "jsonl"
+	exportNDJSON = "ndjson"
+)
+
+// exportCommonColumns are CSV columns of every task, in this order
+var exportCommonColumns = []string{
+	"uuid", "created", "updated", "type", "action", "param", "params", "target", "repeat",
+	"schedule", "parent", "batch", "zond", "manager", "status", "attempt", "error", "result",
+}
+
+// exportTypeColumns are CSV columns parsed from result, by action. They follow
+// common columns when export is filtered by action
+var exportTypeColumns = map[string][]string{
+	"ping":       {"transmitted", "received", "loss", "rtt_min", "rtt_avg", "rtt_max"},
+	"head":       {"proto", "status_code"},
+	"dns":        {"host", "resolver", "rcode", "dnssec", "answers"},
+	"traceroute": {"hops"},
+	"tcp":        {"address", "connected", "connect_ms", "connect_error"},
+	"tls":        {"address", "protocol", "cipher_suite", "issuer", "expires", "days_left", "verified", "ocsp_stapled", "sans", "tls_error"},
+	"http":       {"final_url", "status_code", "passed", "failed_checks", "dns_ms", "connect_ms", "tls_ms", "first_byte_ms", "total_ms", "http_error"},
+}
+
+var (
+	pingPacketsRegex = regexp.MustCompile(`(\d+) packets transmitted, (\d+) (?:packets )?received, .*?([\d.]+)% packet loss`)
+	pingRTTRegex     = regexp.MustCompile(`= ([\d.]+)/([\d.]+)/([\d.]+)`)
+	headStatusRegex  = regexp.MustCompile(`^(HTTP/[\d.]+) (\d{3})`)
+	hopRegex         = regexp.MustCompile(`(?m)^\s*\d+\s`)
+)
+
+// ResultFields parses result of task into values of columns of its action,
+// fields of failed or unfinished task are empty
+func ResultFields(t Action) map[string]string {
+	fields := map[string]string{}
+	if t.Result == "" || strings.HasPrefix(t.Result, "error") || t.Type == api.TypeMeasurement {
+		return fields
+	}
+
+	switch t.Action {
+	case "ping":
+		if m := pingPacketsRegex.FindStringSubmatch(t.Result); m != nil {
+			fields["transmitted"], fields["received"], fields["loss"] = m[1], m[2], m[3]
+		}
+		if m := pingRTTRegex.FindStringSubmatch(t.Result); m != nil {
+			fields["rtt_min"], fields["rtt_avg"], fields["rtt_max"] = m[1], m[2], m[3]
+		}
+	case "head":
+		if m := headStatusRegex.FindStringSubmatch(t.Result); m != nil {
+			fields["proto"], fields["status_code"] = m[1], m[2]
+		}
+	case "dns":
+		result, err := api.ParseDNSResult(t.Result)
+		if err != nil {
+			return fields
+		}
+		fields["host"], fields["resolver"] = result.Host, result.Resolver
+		fields["rcode"], fields["dnssec"] = result.RCode, result.DNSSEC
+		fields["answers"] = strings.Join(result.Values(), " ")
+	case "traceroute":
+		fields["hops"] = strconv.Itoa(len(hopRegex.FindAllString(t.Result, -1)))
+	case "tcp":
+		var result api.TCPResult
+		if err := json.Unmarshal([]byte(t.Result), &result); err != nil {
+			return fields
+		}
+		fields["address"] = result.Address
+		fields["connected"] = strconv.FormatBool(result.Connected)
+		if result.Connected {
+			fields["connect_ms"] = strconv.FormatFloat(result.ConnectTime, 'f', 3, 64)
+		}
+		fields["connect_error"] = result.Error
+	case "tls":
+		var result api.TLSResult
+		if err := json.Unmarshal([]byte(t.Result), &result); err != nil {
+			return fields
+		}
+		fields["address"] = result.Address
+		fields["tls_error"] = result.Error
+		if result.Error != "" {
+			return fields
+		}
+		fields["protocol"], fields["cipher_suite"] = result.Protocol, result.CipherSuite
+		fields["verified"] = strconv.FormatBool(result.Verified)
+		fields["ocsp_stapled"] = strconv.FormatBool(result.OCSPStapled)
+		if len(result.Chain) > 0 {
+			leaf := result.Chain[0]
+			fields["issuer"] = leaf.Issuer
+			fields["expires"] = strconv.FormatInt(result.Expires, 10)
+			fields["days_left"] = strconv.FormatInt(result.DaysLeft, 10)
+			fields["sans"] = strings.Join(append(append([]string{}, leaf.DNSNames...), leaf.IPAddresses...), " ")
+		}
+	case "http":
+		var result api.HTTPResult
+		if err := json.Unmarshal([]byte(t.Result), &result); err != nil {
+			return fields
+		}
+		fields["passed"] = strconv.FormatBool(result.Passed)
+		fields["http_error"] = result.Error
+		var failed []string
+		for _, assertion := range result.Assertions {
+			if !assertion.Passed {
+				failed = append(failed, assertion.Check)
+			}
+		}
+		fields["failed_checks"] = strings.Join(failed, " ")
+		if result.StatusCode == 0 {
+			return fields
+		}
+		fields["final_url"] = result.FinalURL
+		fields["status_code"] = strconv.Itoa(result.StatusCode)
+		for column, ms := range map[string]float64{
+			"dns_ms":        result.Timing.DNS,
+			"connect_ms":    result.Timing.Connect,
+			"tls_ms":        result.Timing.TLS,
+			"first_byte_ms": result.Timing.FirstByte,
+			"total_ms":      result.Timing.Total,
+		} {
+			fields[column] = strconv.FormatFloat(ms, 'f', 3, 64)
+		}
+	}
+
+	return fields
+}
+
+// NewExportRecord returns line of JSON Lines export of task
+func NewExportRecord(t Action) ExportRecord {
+	return ExportRecord{Action: t, Schedule: scheduleOf(t), Fields: ResultFields(t)}
+}
+
+// exportRow is CSV row of task for columns
+func exportRow(t Action, columns []string) []string {
+	fields := ResultFields(t)
+
+	var taskError string
+	if strings.HasPrefix(t.Result, "error") {
+		taskError = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(t.Result, "error"), ":"))
+	}
+
+	common := map[string]string{
+		"uuid":     t.UUID,
+		"created":  strconv.FormatInt(t.Created, 10),
+		"updated":  strconv.FormatInt(t.Updated, 10),
+		"type":     t.Type,
+		"action":   t.Action,
+		"param":    t.Param,
+		"params":   string(t.Params),
+		"target":   t.Target,
+		"repeat":   t.Repeat,
+		"schedule": scheduleOf(t),
+		"parent":   t.ParentUUID,
+		"batch":    t.Batch,
+		"zond":     t.ZondUUID,
+		"manager":  t.MngrUUID,
+		"status":   t.Status,
+		"attempt":  strconv.FormatInt(t.Attempt, 10),
+		"error":    taskError,
+		"result":   t.Result,
+	}
+
+	row := make([]string, len(columns))
+	for i, column := range columns {
+		if value, ok := common[column]; ok {
+			row[i] = csvCell(value)
+		} else {
+			row[i] = csvCell(fields[column])
+		}
+	}
+	return row
+}
+
+// csvCell escapes value spreadsheets would run as formula with leading ',
+// numbers like negative days_left are kept as they are
+func csvCell(value string) string {
+	if value == "" || !strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return value
+	}
+	if csvNumber.MatchString(value) {
+		return value
+	}
+	return "'" + value
+}
+
+var csvNumber = regexp.MustCompile(`^[-+]?[0-9]+(\.[0-9]+)?$`)
+
+// ExportColumns returns CSV columns of export, columns of result are added
+// only when export is filtered by action, so column set is stable
+func ExportColumns(action string) []string {
+	columns := append([]string{}, exportCommonColumns...)
+	return append(columns, exportTypeColumns[action]...)
+}
+
+// flushExport sends buffered export to client, false when writing failed
+func flushExport(csvWriter *csv.Writer, flush func()) bool {
+	if csvWriter != nil {
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			log.Println(err.Error())
+			return false
+		}
+	}
+	flush()
+	return true
+}
+
+// exportFlushEvery is count of tasks written between flushes of export
+const exportFlushEvery = 500
+
+// ExportTasks streams tasks of user passing filter in order of creation,
+// every exportFlushEvery tasks are flushed to client
+func ExportTasks(w http.ResponseWriter, userUUID string, format string, filter TaskFilter) {
+	flusher, _ := w.(http.Flusher)
+	flush := func() {
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	var csvWriter *csv.Writer
+	var encoder *json.Encoder
+	if format == exportCSV {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="tasks.csv"`)
+		csvWriter = csv.NewWriter(w)
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", `attachment; filename="tasks.jsonl"`)
+		encoder = json.NewEncoder(w)
+	}
+	w.WriteHeader(http.StatusOK)
+
+	columns := ExportColumns(filter.Action)
+	if csvWriter != nil {
+		csvWriter.Write(columns)
+	}
+
+	ensureIndex(listTasks, userUUID)
+
+	exported := 0
+	failed := false
+	scanIndex(listTasks, userUUID, ListQuery{Sort: sortCreated, Filter: filter}, func(entryUUID string, created int64, js string) bool {
+		var t Action
+		if err := json.Unmarshal([]byte(js), &t); err != nil {
+			log.Println(err.Error())
+			return true
+		}
+		if !filter.Match(t) {
+			return true
+		}
+
+		if csvWriter != nil {
+			csvWriter.Write(exportRow(t, columns))
+		} else if err := encoder.Encode(NewExportRecord(t)); err != nil {
+			log.Println(err.Error())
+			failed = true
+			return false
+		}
+
+		exported++
+		if exported%exportFlushEvery == 0 {
+			failed = !flushExport(csvWriter, flush)
+		}
+		return !failed
+	})
+
+	if !failed {
+		flushExport(csvWriter, flush)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestCSVCell(t *testing.T) {
+	for value, want := range map[string]string{
+		"":                         "",
+		"8.8.8.8":                  "8.8.8.8",
+		"=HYPERLINK(\"http://x\")": "'=HYPERLINK(\"http://x\")",
+		"+1+cmd|' /C calc'!A0":     "'+1+cmd|' /C calc'!A0",
+		"-2+3":                     "'-2+3",
+		"@SUM(A1)":                 "'@SUM(A1)",
+		"\t=1":                     "'\t=1",
+		"-5":                       "-5",
+		"+12.5":                    "+12.5",
+		"a=b":                      "a=b",
+	} {
+		if got := csvCell(value); got != want {
+			t.Errorf("csvCell(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestExportRowEscapesFormulas(t *testing.T) {
+	row := exportRow(Action{UUID: "u", Action: "dns", Param: "=cmd|'/C calc'!A0", Result: "@SUM(1)"}, []string{"param", "result", "attempt"})
+
+	want := []string{"'=cmd|'/C calc'!A0", "'@SUM(1)", "0"}
+	for i := range want {
+		if row[i] != want[i] {
+			t.Errorf("column %d = %q, want %q", i, row[i], want[i])
+		}
+	}
+}
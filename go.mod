@@ -4,6 +4,7 @@ go 1.14
 
 require (
 	github.com/AndyEverLie/go-pagination-bootstrap v0.0.0-20160303144606-22183c45086a
+	github.com/alicebob/miniredis/v2 v2.11.0
 	github.com/arschles/assert v2.0.0+incompatible // indirect
 	github.com/arschles/go-bindata-html-template v0.0.0-20170123182818-839a6918b9ff
 	github.com/blang/semver v3.5.1+incompatible
@@ -23,7 +24,9 @@ require (
 	github.com/tcnksm/go-gitconfig v0.1.2 // indirect
 	github.com/ulikunitz/xz v0.5.8 // indirect
 	github.com/ulule/limiter v2.2.0+incompatible
+	github.com/yuin/gopher-lua v1.1.1 // indirect
 	golang.org/x/crypto v0.1.0
+	golang.org/x/net v0.5.0
 	google.golang.org/grpc v1.53.0
 	google.golang.org/protobuf v1.28.1
 )
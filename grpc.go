@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+
+	"github.com/ad/gocc/api"
+	pb "github.com/ad/gocc/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type zondUUIDKey struct{}
+
+// zondStream carries zond uuid taken from client certificate
+type zondStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *zondStream) Context() context.Context {
+	return s.ctx
+}
+
+// GRPCZondAuth is stream interceptor of zond service, zond uuid is taken
+// from subject of client certificate and ZondUUID of messages must match it
+func GRPCZondAuth(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	p, ok := peer.FromContext(ss.Context())
+	if !ok {
+		return status.Error(codes.Unauthenticated, "no peer")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+		return status.Error(codes.Unauthenticated, "client certificate is required")
+	}
+
+	zondUUID, err := ZondFromCertificate(tlsInfo.State.VerifiedChains[0][0])
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	ctx := context.WithValue(ss.Context(), zondUUIDKey{}, zondUUID)
+	return handler(srv, &zondStream{ServerStream: ss, ctx: ctx})
+}
+
+// ZondUUIDFromContext returns zond uuid set by GRPCZondAuth
+func ZondUUIDFromContext(ctx context.Context) string {
+	zondUUID, _ := ctx.Value(zondUUIDKey{}).(string)
+	return zondUUID
+}
+
+// checkZondUUID rejects messages sent on behalf of other zond, empty uuid is the authenticated one
+func checkZondUUID(ctx context.Context, zondUUID string) (string, error) {
+	authenticated := ZondUUIDFromContext(ctx)
+	if zondUUID != "" && zondUUID != authenticated {
+		return authenticated, status.Error(codes.PermissionDenied, "uuid does not match certificate")
+	}
+	return authenticated, nil
+}
+
+// zondService is gRPC transport of zond protocol, tasks are delivered with
+// nchan as before, so Task stays unimplemented
+type zondService struct {
+	pb.UnimplementedZondServer
+}
+
+func (s *zondService) Init(stream pb.Zond_InitServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := checkZondUUID(stream.Context(), req.ZondUUID); err != nil {
+			return err
+		}
+		if err := stream.Send(&pb.InitResponse{Status: "ok"}); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *zondService) Block(stream pb.Zond_BlockServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		zondUUID, err := checkZondUUID(stream.Context(), req.ZondUUID)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&pb.BlockResponse{Status: BlockZondTask(zondUUID, req.UUID).Message}); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *zondService) Result(stream pb.Zond_ResultServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		zondUUID, err := checkZondUUID(stream.Context(), req.ZondUUID)
+		if err != nil {
+			return err
+		}
+		if req.Action != api.ActionResult {
+			return status.Error(codes.InvalidArgument, "action must be result")
+		}
+		t := Action{ZondUUID: req.ZondUUID, Action: req.Action, UUID: req.UUID, Result: req.Result}
+		if err := stream.Send(&pb.ResultResponse{Status: SaveZondResult(zondUUID, t, peerAddress(stream.Context()))}); err != nil {
+			return err
+		}
+	}
+}
+
+// Ping echoes pings of zond, every ping also answers alive check
+func (s *zondService) Ping(stream pb.Zond_PingServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		zondUUID, err := checkZondUUID(stream.Context(), req.ZondUUID)
+		if err != nil {
+			return err
+		}
+		// PingRequest has no uuid of alive message, ping on stream authorized
+		// by certificate answers the pending check
+		ZondAlive(zondUUID, Client.Get(zondUUID+"/alive").Val())
+		if err := stream.Send(&pb.PingResponse{ZondUUID: zondUUID}); err != nil {
+			return err
+		}
+	}
+}
+
+// peerAddress is address of zond for audit log
+func peerAddress(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// NewGRPCServer returns zond service accepting only certificates issued by ca
+func NewGRPCServer(c *CA, hosts []string) (*grpc.Server, error) {
+	config, err := c.TLSConfig(hosts, tls.RequireAndVerifyClientCert)
+	if err != nil {
+		return nil, err
+	}
+
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(config)), grpc.StreamInterceptor(GRPCZondAuth))
+	pb.RegisterZondServer(s, &zondService{})
+
+	return s, nil
+}
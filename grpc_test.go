@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/ad/gocc/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func TestGRPCPingAnswersAlive(t *testing.T) {
+	useTestRedis(t)
+
+	var err error
+	if ca, err = LoadCA(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { ca = nil }()
+
+	zond, cert, _ := enrollTestZond(t)
+	Client.Set(zond.UUID+"/alive", "check", 90*time.Second)
+
+	server, err := NewGRPCServer(ca, []string{"127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(lis)
+	defer server.Stop()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Cert)
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		ServerName:   "127.0.0.1",
+		RootCAs:      roots,
+		Certificates: []tls.Certificate{cert},
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := pb.NewZondClient(conn).Ping(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&pb.PingRequest{ZondUUID: zond.UUID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	if Client.Exists(zond.UUID+"/alive").Val() != 0 {
+		t.Error("alive check is pending after ping")
+	}
+}
@@ -1,12 +1,23 @@
 package main
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ad/gocc/api"
 	"github.com/gorilla/csrf"
 
 	"github.com/ulule/limiter"
@@ -18,6 +29,10 @@ var Fqdn = FQDN()
 var Version = ""
 
 func Throttle(period time.Duration, limit int64, f http.Handler) http.Handler {
+	if *nolimit {
+		return f
+	}
+
 	rateLimitStore := memory.NewStore()
 	rate := limiter.Rate{
 		Period: period,
@@ -28,49 +43,124 @@ func Throttle(period time.Duration, limit int64, f http.Handler) http.Handler {
 	return rateLimiter.Handler(f)
 }
 
-func ZondAuth(f http.Handler) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		var uuid = r.Header.Get("X-ZondUuid")
+// maxAgentBody limits body of signed agent requests
+const maxAgentBody = 1 << 20
 
-		if len(uuid) != 36 {
-			http.Error(w, "Not authorized", 401)
-			return
+// NewAgentSecret generates secret of zond or manager, it is stored apart
+// from agent record and is shown to owner only once
+func NewAgentSecret() string {
+	buff := make([]byte, 32)
+	rand.Read(buff)
+	return hex.EncodeToString(buff)
+}
+
+func agentSecretKey(agentUUID string) string {
+	return "secret/" + agentUUID
+}
+
+// VerifyAgent checks that agent from uuid header is in set and request is
+// signed with its secret, path is signed one, see api.SignatureBase. Body is
+// read and put back for next handler
+func VerifyAgent(r *http.Request, header string, set string, path string) (string, error) {
+	var agentUUID = r.Header.Get(header)
+	if len(agentUUID) != 36 {
+		return agentUUID, errors.New("wrong uuid")
+	}
+
+	// disabled and deleted agents are not in set
+	if !Client.SIsMember(set, agentUUID).Val() {
+		return agentUUID, errors.New("unknown uuid")
+	}
+
+	secret, _ := Client.Get(agentSecretKey(agentUUID)).Result()
+	if secret == "" {
+		return agentUUID, errors.New("no secret, credentials must be rotated")
+	}
+
+	timestamp := r.Header.Get(api.HeaderTimestamp)
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return agentUUID, errors.New("wrong timestamp")
+	}
+	if skew := time.Now().Unix() - ts; skew > api.SignatureMaxSkew || skew < -api.SignatureMaxSkew {
+		return agentUUID, fmt.Errorf("timestamp is %d seconds off", skew)
+	}
+
+	nonce := r.Header.Get(api.HeaderNonce)
+	if nonce == "" || len(nonce) > api.MaxNonceLength {
+		return agentUUID, errors.New("wrong nonce")
+	}
+
+	var body []byte
+	if r.Body != nil {
+		body, err = ioutil.ReadAll(io.LimitReader(r.Body, maxAgentBody+1))
+		r.Body.Close()
+		if err != nil {
+			return agentUUID, err
+		}
+		if len(body) > maxAgentBody {
+			return agentUUID, errors.New("body is too large")
 		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
 
-		isMember, _ := Client.SIsMember("zonds", uuid).Result()
-		if !isMember {
+	signature := api.Sign(secret, r.Method, path, timestamp, nonce, body)
+	if !hmac.Equal([]byte(signature), []byte(r.Header.Get(api.HeaderSignature))) {
+		return agentUUID, errors.New("wrong signature")
+	}
+
+	// nonce is checked after signature, so it can't be burned by others
+	if !Client.SetNX("nonce/"+agentUUID+"/"+nonce, timestamp, 2*api.SignatureMaxSkew*time.Second).Val() {
+		return agentUUID, errors.New("replayed request")
+	}
+
+	return agentUUID, nil
+}
+
+func ZondAuth(f http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if zondUUID, err := AuthorizeZond(r, r.URL.Path); err != nil {
+			log.Println("zond", zondUUID, "not authorized:", err)
 			http.Error(w, "Not authorized", 401)
 			return
 		}
 
-		// TODO: check zond state
-
 		f.ServeHTTP(w, r)
 	}
 }
 
 func MngrAuth(f http.Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var uuid = r.Header.Get("X-MngrUuid")
-
-		if len(uuid) != 36 {
+		if mngrUUID, err := VerifyAgent(r, api.HeaderMngrUUID, "mngrs", r.URL.Path); err != nil {
+			log.Println("manager", mngrUUID, "not authorized:", err)
 			http.Error(w, "Not authorized", 401)
 			return
 		}
 
-		isMember, _ := Client.SIsMember("mngrs", uuid).Result()
-		if !isMember {
-			http.Error(w, "Not authorized", 401)
+		f.ServeHTTP(w, r)
+	}
+}
+
+// Internal allows only requests from nginx on the same host, like nchan
+// subscribe callbacks. nginx hides these paths from outside with internal
+func Internal(f http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if ip := net.ParseIP(host); err != nil || ip == nil || !ip.IsLoopback() {
+			log.Println("internal request from", r.RemoteAddr, "rejected:", r.URL.Path)
+			http.Error(w, "Forbidden", 403)
 			return
 		}
 
-		// TODO: check zond state
-
 		f.ServeHTTP(w, r)
 	}
 }
 
 func NotFound(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/api/") {
+		WriteError(w, NewApiError(http.StatusNotFound, api.ErrCodeNotFound, "endpoint not found"))
+		return
+	}
 	w.WriteHeader(404)
 	// tmpl, _ := templ.New("error404", Asset).Parse("error404.html")
 	// tmpl.Execute(w, nil)
@@ -97,17 +187,25 @@ func GetHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func DispatchHandler(w http.ResponseWriter, r *http.Request, gogeoaddr *string) {
-	var uuid = r.Header.Get("X-ZondUuid")
-	var mngruuid = r.Header.Get("X-MngrUuid")
+	var uuid = r.Header.Get(api.HeaderZondUUID)
+	var mngruuid = r.Header.Get(api.HeaderMngrUUID)
 	var ip = r.Header.Get("X-Forwarded-For")
-	if len(uuid) == 36 {
-		isMember, _ := Client.SIsMember("zonds", uuid).Result()
-		if isMember {
+	if len(uuid) == 36 || hasClientCertificate(r) {
+		zondUUID, err := AuthorizeZond(r, api.PathSubscribe)
+		uuid = zondUUID
+		if err == nil {
 			var add = IPToWSChannels(ip, gogeoaddr)
+			// nchan passes only first channels to subscribe callback, so
+			// networks are stored here
+			families := ZondNetworks(r.Header.Get(api.HeaderZondNetwork), ip)
+			saveZondNetworks(uuid, families)
+			if len(families) > 0 {
+				add = add + "," + strings.Join(networkChannels(families), ",")
+			}
 			log.Println("/internal/sub/zond:" + uuid + "," + add + "," + ip)
 			w.Header().Add("X-Accel-Redirect", "/internal/sub/zond:"+uuid+","+add+","+ip)
 		} else {
-			log.Println("zond uuid not found: " + uuid + ", ip" + ip)
+			log.Println("zond " + uuid + " not authorized: " + err.Error() + ", ip" + ip)
 			w.WriteHeader(http.StatusBadRequest)
 			w.Header().Add("X-Accel-Redirect", "/404")
 			w.Header().Add("X-Accel-Buffering", "no")
@@ -115,12 +213,12 @@ func DispatchHandler(w http.ResponseWriter, r *http.Request, gogeoaddr *string)
 			return
 		}
 	} else if len(mngruuid) == 36 {
-		isMember, _ := Client.SIsMember("mngrs", mngruuid).Result()
-		if isMember {
+		_, err := VerifyAgent(r, api.HeaderMngrUUID, "mngrs", api.PathSubscribe)
+		if err == nil {
 			log.Println("/internal/sub/mngrtasks,mngr" + mngruuid + "," + ip)
 			w.Header().Add("X-Accel-Redirect", "/internal/sub/mngrtasks,mngr"+mngruuid+","+ip)
 		} else {
-			log.Println("mngr uuid not found: " + mngruuid + ", ip" + ip)
+			log.Println("mngr " + mngruuid + " not authorized: " + err.Error() + ", ip" + ip)
 			w.WriteHeader(http.StatusBadRequest)
 			w.Header().Add("X-Accel-Redirect", "/404")
 			w.Header().Add("X-Accel-Buffering", "no")
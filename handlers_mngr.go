@@ -6,12 +6,11 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
-	"strconv"
 
 	pagination "github.com/AndyEverLie/go-pagination-bootstrap"
+	"github.com/ad/gocc/api"
 	templ "github.com/arschles/go-bindata-html-template"
 	"github.com/gorilla/csrf"
-	uuid "github.com/nu7hatch/gouuid"
 )
 
 func MngrPong(w http.ResponseWriter, r *http.Request) {
@@ -26,11 +25,12 @@ func MngrPong(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				log.Println(err.Error())
 			}
-			tp, _ := Client.Get(t.MngrUUID + "/alive").Result()
+			mngrUUID := r.Header.Get(api.HeaderMngrUUID)
+			tp, _ := Client.Get(mngrUUID + "/alive").Result()
 			if t.UUID == tp {
-				Client.Del(t.MngrUUID + "/alive")
+				Client.Del(mngrUUID + "/alive")
 				// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-				fmt.Fprintf(w, `{"status": "ok"}`)
+				writeProtocolResult(w, Result{Message: api.MessageOK})
 			}
 		}
 	}
@@ -45,6 +45,7 @@ func MngrSub(w http.ResponseWriter, r *http.Request) {
 			Client.SAdd("mngr-online", uuid)
 			usersCount, _ := Client.SCard("mngr-online").Result()
 			fmt.Printf("Active Mngrs: %d\n", usersCount)
+			PublishMngrState(uuid, api.ActionOnline)
 		}
 	}
 }
@@ -53,73 +54,36 @@ func MngrUnsub(w http.ResponseWriter, r *http.Request) {
 	var uuid = r.Header.Get("X-MngrUuid")
 	if len(uuid) > 0 {
 		log.Println(r.Header.Get("X-MngrUuid"), "— disconnected")
-		Client.SRem("Mngr-online", r.Header.Get("X-MngrUuid"))
+		Client.SRem("mngr-online", r.Header.Get("X-MngrUuid"))
 		usersCount, _ := Client.SCard("mngr-online").Result()
 		fmt.Printf("Active Mngrs: %d\n", usersCount)
+		PublishMngrState(uuid, api.ActionOffline)
 	}
 }
 
 func ShowMyMngrs(w http.ResponseWriter, r *http.Request) {
-	var perPage int = 20
-	page, _ := strconv.ParseInt(r.FormValue("page"), 10, 0)
-	if page <= 0 {
-		page = 1
-	}
-	userUuid, _ := Client.Get("user/uuid/" + r.Header.Get("X-Forwarded-User")).Result()
-	if userUuid == "" {
-		u, _ := uuid.NewV4()
-		userUuid = u.String()
-		Client.Set(fmt.Sprintf("user/uuid/%s", r.Header.Get("X-Forwarded-User")), userUuid, 0)
-	}
-
-	count, _ := Client.SCard("user/mngrs/" + userUuid).Result()
-	currentPage, pages, hasPrev, hasNext := GetPaginator(int(page), int(count), perPage)
-
-	var results []Mngr
-	if count > 0 {
-		// log.Println(count)
-		var cursor = uint64(int64(perPage) * int64(currentPage-1))
-		// var cursorNew uint64
-		var keys []string
-		var err error
-		keys, _, err = Client.SScan("user/mngrs/"+userUuid, cursor, "", int64(perPage)).Result()
-
-		if err != nil {
-			log.Println(err)
-		} else {
-			// log.Println(keys)
-			for i, val := range keys {
-				keys[i] = "mngrs/" + val
-			}
+	userUuid := GetUserUUID(r)
 
-			items, _ := Client.MGet(keys...).Result()
-			for _, val := range items {
-				if val != nil {
-					var t Mngr
-					err := json.Unmarshal([]byte(val.(string)), &t)
-					if err != nil {
-						log.Println(err.Error())
-					}
-					results = append(results, t)
-				}
-			}
-			log.Println(len(results), count, results)
-		}
-		// log.Println(len(results), count, currentPage, cursor, cursorNew, perPage)
+	query, apiErr := ListQueryFromRequest(r)
+	if apiErr != nil {
+		http.Error(w, apiErr.Message, apiErr.HTTPStatus)
+		return
 	}
+	list := ListUserMngrs(userUuid, query)
 
-	pager := pagination.New(int(count), perPage, currentPage, "/my/mngrs")
+	pager := pagination.New(int(list.Count), listPerPage, list.Page, listPagerURL(r, "/mngr/my"))
 
 	varmap := map[string]interface{}{
 		"Version":        Version,
 		"User":           r.Header.Get("X-Forwarded-User"),
 		"UserUUID":       userUuid,
-		"Results":        results,
-		"AllCount":       count,
-		"Pages":          pages,
-		"Page":           page,
-		"HasPrev":        hasPrev,
-		"HasNext":        hasNext,
+		"Results":        list.Results,
+		"AllCount":       list.Count,
+		"Pages":          list.Pages,
+		"Page":           list.Page,
+		"HasPrev":        list.HasPrev,
+		"HasNext":        list.HasNext,
+		"Query":          r.URL.Query(),
 		"pager":          pager,
 		csrf.TemplateTag: csrf.TemplateField(r),
 	}
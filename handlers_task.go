@@ -6,22 +6,18 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
-	"strconv"
 	"time"
 
 	pagination "github.com/AndyEverLie/go-pagination-bootstrap"
+	"github.com/ad/gocc/api"
 	templ "github.com/arschles/go-bindata-html-template"
 	"github.com/gorilla/csrf"
+	"github.com/gorilla/mux"
 	uuid "github.com/nu7hatch/gouuid"
 )
 
 func ShowRepeatableTasks(w http.ResponseWriter, r *http.Request) {
-	userUuid, _ := Client.Get("user/uuid/" + r.Header.Get("X-Forwarded-User")).Result()
-	if userUuid == "" {
-		u, _ := uuid.NewV4()
-		userUuid = u.String()
-		Client.Set(fmt.Sprintf("user/uuid/%s", r.Header.Get("X-Forwarded-User")), userUuid, 0)
-	}
+	userUuid := GetUserUUID(r)
 
 	titles, _ := Client.Keys("tasks-repeatable-*").Result()
 	count := len(titles)
@@ -191,13 +187,6 @@ func ShowRepeatableTasks(w http.ResponseWriter, r *http.Request) {
 // 			// usersCount, _ := client.SCard("tasks-new").Result()
 // 			// log.Println("tasks-new", users, usersCount)
 
-// 			userUuid, _ := Client.Get("user/uuid/" + r.Header.Get("X-Forwarded-User")).Result()
-// 			if userUuid == "" {
-// 				u, _ := uuid.NewV4()
-// 				userUuid = u.String()
-// 				Client.Set(fmt.Sprintf("user/uuid/%s", r.Header.Get("X-Forwarded-User")), userUuid, 0)
-// 			}
-
 // 			action := Action{Action: taskType, Param: ip, UUID: Uuid, Created: msec, Creator: userUuid, Target: destination, Repeat: repeatType, Type: taskMainType, Count: taskCount}
 // 			js, _ := json.Marshal(action)
 
@@ -248,33 +237,72 @@ func TaskZondBlockHandler(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				log.Println(err.Error())
 			}
-			log.Println(t.ZondUUID, "wants to", t.Action, t.UUID)
-			zondBusy, err := Client.SIsMember("zond-busy", t.ZondUUID).Result()
-			if (err != nil) || (zondBusy != true) {
-				count := Client.SRem("tasks-new", t.UUID)
-				if count.Val() == int64(1) {
-					Client.SAdd("tasks-process", t.ZondUUID+"/"+t.UUID)
-					Client.SAdd("zond-busy", t.ZondUUID)
-					Client.Set(t.ZondUUID+"/"+t.UUID+"/processing", "1", 60*time.Second)
-					log.Println(t.ZondUUID, `{"status": "ok", "message": "ok"}`)
-					// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-					fmt.Fprintf(w, `{"status": "ok", "message": "ok"}`)
-				} else {
-					log.Println(t.ZondUUID, `{"status": "error", "message": "task not found"}`)
-					// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-					fmt.Fprintf(w, `{"status": "error", "message": "task not found"}`)
-				}
-			} else {
-				log.Println(`{"status": "error", "message": "only one task at time is allowed"}`)
-				// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-				fmt.Fprintf(w, `{"status": "error", "message": "only one task at time is allowed"}`)
+
+			// zond is the authenticated one, zond field of body must name it
+			zondUUID := r.Header.Get(api.HeaderZondUUID)
+			if t.ZondUUID != "" && t.ZondUUID != zondUUID {
+				auditRejected(zondUUID, t, api.MessageWrongAgent, requestSource(r))
+				writeProtocolResult(w, Result{Status: "error", Message: api.MessageWrongAgent})
+				return
 			}
+
+			writeProtocolResult(w, BlockZondTask(zondUUID, t.UUID))
 		}
 	} else {
 		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
 	}
 }
 
+// writeProtocolResult answers block or result request of agent, message is one of api.Message* values
+func writeProtocolResult(w http.ResponseWriter, res Result) {
+	if res.Status == "" {
+		res.Status = "ok"
+		if res.Message != api.MessageOK {
+			res.Status = "error"
+		}
+	}
+	WriteJSON(w, http.StatusOK, res)
+}
+
+// BlockZondTask claims task for zond, answer has one of api.Message* values
+// and attempt number of claim
+func BlockZondTask(zondUUID string, taskUUID string) Result {
+	log.Println(zondUUID, "wants to block", taskUUID)
+	zondBusy, err := Client.SIsMember("zond-busy", zondUUID).Result()
+	if (err == nil) && zondBusy {
+		log.Println(zondUUID, `{"status": "error", "message": "only one task at time is allowed"}`)
+		return Result{Message: api.MessageBusy}
+	}
+
+	var task Action
+	js, _ := Client.Get("task/" + taskUUID).Result()
+	if err := json.Unmarshal([]byte(js), &task); err == nil {
+		if TaskExpired(task) {
+			ExpireTask(task)
+			return Result{Message: api.MessageTaskNotFound}
+		}
+		if !ZondMatchesTarget(zondUUID, task.Target) {
+			log.Println(zondUUID, "does not match", task.Target, "of", taskUUID)
+			return Result{Message: api.MessageTaskNotFound}
+		}
+		if family := TaskFamily(task.Action, task.Param); !ZondReaches(zondUUID, family) {
+			log.Println(zondUUID, "does not reach", family, "of", taskUUID)
+			return Result{Message: api.MessageTaskNotFound}
+		}
+	}
+
+	count := Client.SRem("tasks-new", taskUUID)
+	if count.Val() != int64(1) {
+		log.Println(zondUUID, `{"status": "error", "message": "task not found"}`)
+		return Result{Message: api.MessageTaskNotFound}
+	}
+
+	Client.SAdd("zond-busy", zondUUID)
+	claim := SaveClaim(zondUUID, taskUUID, zondClaimTimeout)
+	log.Println(zondUUID, `{"status": "ok", "message": "ok"}`, claim.Attempt)
+	return Result{Message: api.MessageOK, Attempt: claim.Attempt}
+}
+
 func TaskZondResultHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "POST" {
 		body, err := ioutil.ReadAll(r.Body)
@@ -287,47 +315,11 @@ func TaskZondResultHandler(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				log.Println(err.Error())
 			}
-			log.Println(t.ZondUUID, "wants to", t.Action, t.UUID)
-			Client.SRem("zond-busy", t.ZondUUID)
-
-			if t.Action == "result" {
-				taskProcessing, err := Client.SIsMember("tasks-process", t.ZondUUID+"/"+t.UUID).Result()
-				if (err != nil) || (taskProcessing != true) {
-					log.Println(`{"status": "error", "message": "task not found"}`)
-					// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-					fmt.Fprintf(w, `{"status": "error", "message": "task not found"}`)
-				} else {
-					count := Client.SRem("tasks-process", t.ZondUUID+"/"+t.UUID)
-					if count.Val() == int64(1) {
-						Client.SAdd("tasks-done", t.ZondUUID+"/"+t.UUID+"/"+t.Result)
-						log.Println(t.ZondUUID, `{"status": "ok", "message": "ok"}`)
-						// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-						fmt.Fprintf(w, `{"status": "ok", "message": "ok"}`)
-
-						js, _ := Client.Get("task/" + t.UUID).Result()
-						// log.Println(js)
-						var task Action
-						err = json.Unmarshal([]byte(js), &task)
-						if err != nil {
-							log.Println(err.Error())
-						}
-						task.Result = t.Result
-						task.ZondUUID = t.ZondUUID
-						task.Updated = time.Now().Unix()
-
-						jsonBody, err := json.Marshal(task)
-						if err != nil {
-							http.Error(w, "Error converting results to json",
-								http.StatusInternalServerError)
-						}
-						Client.Set("task/"+t.UUID, jsonBody, 0)
-						go Post("http://127.0.0.1:80/pub/tasks/done", string(jsonBody))
-					} else {
-						log.Println(t.ZondUUID, `{"status": "error", "message": "task not found"}`)
-						// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-						fmt.Fprintf(w, `{"status": "error", "message": "task not found"}`)
-					}
-				}
+			zondUUID := r.Header.Get(api.HeaderZondUUID)
+			log.Println(zondUUID, "wants to", t.Action, t.UUID)
+
+			if t.Action == api.ActionResult {
+				writeProtocolResult(w, Result{Message: SaveZondResult(zondUUID, t, requestSource(r))})
 			}
 		}
 	} else {
@@ -335,6 +327,56 @@ func TaskZondResultHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// SaveZondResult stores result of task claimed by authenticated zond and
+// publishes it, rejected results are audited. Answer is one of api.Message* values
+func SaveZondResult(zondUUID string, t Action, source string) string {
+	if t.ZondUUID != "" && t.ZondUUID != zondUUID {
+		auditRejected(zondUUID, t, api.MessageWrongAgent, source)
+		return api.MessageWrongAgent
+	}
+
+	claim, message := CheckResult(zondUUID, t, api.MaxResultSize)
+	if message != api.MessageOK {
+		auditRejected(zondUUID, t, message, source)
+		return message
+	}
+
+	// concurrent result of the same claim
+	if Client.SRem("tasks-process", zondUUID+"/"+t.UUID).Val() != int64(1) {
+		return api.MessageTaskNotFound
+	}
+	Client.Del(claimKey(zondUUID, t.UUID))
+	Client.SRem("zond-busy", zondUUID)
+	Client.SAdd("tasks-done", zondUUID+"/"+t.UUID)
+	log.Println(zondUUID, `{"status": "ok", "message": "ok"}`)
+
+	js, _ := Client.Get("task/" + t.UUID).Result()
+	var task Action
+	err := json.Unmarshal([]byte(js), &task)
+	if err != nil {
+		log.Println(err.Error())
+	}
+	task.Result = t.Result
+	task.ZondUUID = zondUUID
+	task.Attempt = claim.Attempt
+	task.Updated = time.Now().Unix()
+
+	jsonBody, err := json.Marshal(task)
+	if err != nil {
+		log.Println(err.Error())
+		return api.MessageOK
+	}
+	Client.Set("task/"+t.UUID, jsonBody, 0)
+	go Post(*nchanaddr+"/pub/tasks/done", string(jsonBody))
+
+	// child task of measurement, manager aggregates its result
+	if task.ParentUUID != "" && task.MngrUUID != "" {
+		go Post(*nchanaddr+"/pub/"+api.ChannelMngr(task.MngrUUID), string(jsonBody))
+	}
+
+	return api.MessageOK
+}
+
 func TaskMngrBlockHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "POST" {
 		body, err := ioutil.ReadAll(r.Body)
@@ -347,26 +389,43 @@ func TaskMngrBlockHandler(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				log.Println(err.Error())
 			}
-			log.Println(t.MngrUUID, "wants to", t.Action, t.UUID)
-
-			count := Client.SRem("tasks-new", t.UUID)
-			if count.Val() == int64(1) {
-				Client.SAdd("tasks-process", t.MngrUUID+"/"+t.UUID)
-				Client.Set(t.MngrUUID+"/"+t.UUID+"/processing", "1", 300*time.Second) // FIXME: time
-				log.Println(t.MngrUUID, `{"status": "ok", "message": "ok"}`)
-				// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-				fmt.Fprintf(w, `{"status": "ok", "message": "ok"}`)
-			} else {
-				log.Println(t.MngrUUID, `{"status": "error", "message": "task not found"}`)
-				// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-				fmt.Fprintf(w, `{"status": "error", "message": "task not found"}`)
+
+			mngrUUID := r.Header.Get(api.HeaderMngrUUID)
+			if t.MngrUUID != "" && t.MngrUUID != mngrUUID {
+				auditRejected(mngrUUID, t, api.MessageWrongAgent, requestSource(r))
+				writeProtocolResult(w, Result{Status: "error", Message: api.MessageWrongAgent})
+				return
 			}
+			writeProtocolResult(w, BlockMngrTask(mngrUUID, t.Action, t.UUID))
 		}
 	} else {
 		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
 	}
 }
 
+// BlockMngrTask claims task for manager, answer has one of api.Message*
+// values and attempt number of claim
+func BlockMngrTask(mngrUUID string, action string, taskUUID string) Result {
+	log.Println(mngrUUID, "wants to", action, taskUUID)
+
+	var task Action
+	js, _ := Client.Get("task/" + taskUUID).Result()
+	if err := json.Unmarshal([]byte(js), &task); err == nil && TaskExpired(task) {
+		ExpireTask(task)
+		return Result{Message: api.MessageTaskNotFound}
+	}
+
+	count := Client.SRem("tasks-new", taskUUID)
+	if count.Val() != int64(1) {
+		log.Println(mngrUUID, `{"status": "error", "message": "task not found"}`)
+		return Result{Message: api.MessageTaskNotFound}
+	}
+
+	claim := SaveClaim(mngrUUID, taskUUID, mngrClaimTimeout)
+	log.Println(mngrUUID, `{"status": "ok", "message": "ok"}`, claim.Attempt)
+	return Result{Message: api.MessageOK, Attempt: claim.Attempt}
+}
+
 func TaskMngrResultHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "POST" {
 		body, err := ioutil.ReadAll(r.Body)
@@ -379,45 +438,10 @@ func TaskMngrResultHandler(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				log.Println(err.Error())
 			}
-			log.Println(t.MngrUUID, "wants to", t.Action, t.UUID)
-			if t.Action == "result" {
-				taskProcessing, err := Client.SIsMember("tasks-process", t.MngrUUID+"/"+t.UUID).Result()
-				if (err != nil) || (taskProcessing != true) {
-					log.Println(`{"status": "error", "message": "task not found"}`)
-					// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-					fmt.Fprintf(w, `{"status": "error", "message": "task not found"}`)
-				} else {
-					count := Client.SRem("tasks-process", t.MngrUUID+"/"+t.UUID)
-					if count.Val() == int64(1) {
-						Client.SAdd("tasks-done", t.MngrUUID+"/"+t.UUID+"/"+t.Result)
-						log.Println(t.MngrUUID, `{"status": "ok", "message": "ok"}`)
-						// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-						fmt.Fprintf(w, `{"status": "ok", "message": "ok"}`)
-
-						js, _ := Client.Get("task/" + t.UUID).Result()
-						// log.Println(js)
-						var task Action
-						err = json.Unmarshal([]byte(js), &task)
-						if err != nil {
-							log.Println(err.Error())
-						}
-						task.Result = t.Result
-						task.MngrUUID = t.MngrUUID
-						task.Updated = time.Now().Unix()
-
-						jsonBody, err := json.Marshal(task)
-						if err != nil {
-							http.Error(w, "Error converting results to json",
-								http.StatusInternalServerError)
-						}
-						Client.Set("task/"+t.UUID, jsonBody, 0)
-						go Post("http://127.0.0.1:80/pub/tasks/done", string(jsonBody))
-					} else {
-						log.Println(t.MngrUUID, `{"status": "error", "message": "task not found"}`)
-						// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-						fmt.Fprintf(w, `{"status": "error", "message": "task not found"}`)
-					}
-				}
+			mngrUUID := r.Header.Get(api.HeaderMngrUUID)
+			log.Println(mngrUUID, "wants to", t.Action, t.UUID)
+			if t.Action == api.ActionResult {
+				writeProtocolResult(w, Result{Message: SaveMngrResult(mngrUUID, t, requestSource(r))})
 			}
 		}
 	} else {
@@ -425,64 +449,170 @@ func TaskMngrResultHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func ShowMyTasks(w http.ResponseWriter, r *http.Request) {
-	var perPage int = 20
-	page, _ := strconv.ParseInt(r.FormValue("page"), 10, 0)
-	if page <= 0 {
-		page = 1
+// SaveMngrResult stores result of measurement claimed by authenticated
+// manager, rejected results are audited
+func SaveMngrResult(mngrUUID string, t Action, source string) string {
+	if t.MngrUUID != "" && t.MngrUUID != mngrUUID {
+		auditRejected(mngrUUID, t, api.MessageWrongAgent, source)
+		return api.MessageWrongAgent
 	}
-	userUuid, _ := Client.Get("user/uuid/" + r.Header.Get("X-Forwarded-User")).Result()
-	if userUuid == "" {
-		u, _ := uuid.NewV4()
-		userUuid = u.String()
-		Client.Set(fmt.Sprintf("user/uuid/%s", r.Header.Get("X-Forwarded-User")), userUuid, 0)
+
+	// measurement result is limited only by maxAgentBody
+	claim, message := CheckResult(mngrUUID, t, 0)
+	if message != api.MessageOK {
+		auditRejected(mngrUUID, t, message, source)
+		return message
+	}
+
+	if Client.SRem("tasks-process", mngrUUID+"/"+t.UUID).Val() != int64(1) {
+		return api.MessageTaskNotFound
+	}
+	Client.Del(claimKey(mngrUUID, t.UUID))
+	Client.SAdd("tasks-done", mngrUUID+"/"+t.UUID)
+	log.Println(mngrUUID, `{"status": "ok", "message": "ok"}`)
+
+	js, _ := Client.Get("task/" + t.UUID).Result()
+	var task Action
+	err := json.Unmarshal([]byte(js), &task)
+	if err != nil {
+		log.Println(err.Error())
 	}
+	task.Result = t.Result
+	task.MngrUUID = mngrUUID
+	task.Attempt = claim.Attempt
+	task.Updated = time.Now().Unix()
+
+	jsonBody, err := json.Marshal(task)
+	if err != nil {
+		log.Println(err.Error())
+		return api.MessageOK
+	}
+	Client.Set("task/"+t.UUID, jsonBody, 0)
+	go Post(*nchanaddr+"/pub/tasks/done", string(jsonBody))
 
-	count, _ := Client.SCard("user/tasks/" + userUuid).Result()
-	currentPage, pages, hasPrev, hasNext := GetPaginator(int(page), int(count), perPage)
+	return api.MessageOK
+}
 
-	var results []Action
-	if count > 0 {
-		// log.Println(count)
-		var cursor = uint64(int64(perPage) * int64(currentPage-1))
-		// var cursorNew uint64
-		var keys []string
-		var err error
-		keys, _, err = Client.SScan("user/tasks/"+userUuid, cursor, "", int64(perPage)).Result()
+func TaskMngrCreateHandler(w http.ResponseWriter, r *http.Request) {
+	var t Action
+	if apiErr := DecodeJSONBody(r, &t); apiErr != nil {
+		WriteJSON(w, http.StatusOK, ChildTasksResponse{Status: "error", Message: apiErr.Message})
+		return
+	}
 
-		if err != nil {
-			log.Println(err)
-		} else {
-			for i, val := range keys {
-				keys[i] = "task/" + val
-			}
+	tasks, message := CreateChildTasks(r.Header.Get(api.HeaderMngrUUID), t)
+	if message != "" {
+		log.Println(t.MngrUUID, t.ParentUUID, message)
+		WriteJSON(w, http.StatusOK, ChildTasksResponse{Status: "error", Message: message})
+		return
+	}
 
-			items, _ := Client.MGet(keys...).Result()
-			for _, val := range items {
-				var t Action
-				err := json.Unmarshal([]byte(val.(string)), &t)
-				if err != nil {
-					log.Println(err.Error())
-				}
-				results = append(results, t)
-			}
-			// log.Println(len(results), count, results)
+	WriteJSON(w, http.StatusOK, ChildTasksResponse{Status: "ok", Message: api.MessageOK, Tasks: tasks})
+}
+
+// CreateChildTasks publishes count copies of measurement as ordinary tasks,
+// action with param or params of measurement may be overridden by manager
+func CreateChildTasks(mngrUUID string, t Action) ([]Action, string) {
+	if !Client.SIsMember("tasks-process", mngrUUID+"/"+t.ParentUUID).Val() {
+		return nil, api.MessageTaskNotFound
+	}
+
+	js, _ := Client.Get("task/" + t.ParentUUID).Result()
+	var parent Action
+	if err := json.Unmarshal([]byte(js), &parent); err != nil {
+		return nil, api.MessageTaskNotFound
+	}
+
+	count := t.Count
+	if count <= 0 {
+		count = parent.Count
+	}
+	if count <= 0 {
+		count = 1
+	}
+	if count > api.MaxChildTasks {
+		return nil, fmt.Sprintf("no more than %d child tasks allowed", api.MaxChildTasks)
+	}
+
+	source := parent
+	if t.Action != "" {
+		source = t
+	}
+	// params win over param, tasks created with params carry both
+	param := source.Param
+	if len(source.Params) > 0 {
+		param = ""
+	}
+	taskType := source.Action
+	param, params, apiErr := ValidateTaskParams(taskType, param, source.Params)
+	if apiErr != nil {
+		return nil, apiErr.Message
+	}
+
+	target := parent.Target
+	if target == "" {
+		target = "tasks"
+	}
+
+	var tasks []Action
+	for i := int64(0); i < count; i++ {
+		u, _ := uuid.NewV4()
+		task := Action{
+			Action:     taskType,
+			Param:      param,
+			Params:     params,
+			UUID:       u.String(),
+			Created:    time.Now().Unix(),
+			Creator:    parent.Creator,
+			MngrUUID:   mngrUUID,
+			ParentUUID: parent.UUID,
+			Target:     target,
+			Repeat:     "single",
+			Type:       api.TypeTask,
+			Count:      1,
+			TimeOut:    60,
 		}
-		// log.Println(len(results), count, currentPage, cursor, cursorNew, perPage)
+		js, _ := json.Marshal(task)
+
+		Client.SAdd("tasks-new", task.UUID)
+		Client.Set("task/"+task.UUID, string(js), 0)
+		IndexAdd(listTasks, parent.Creator, task.UUID, task.Created)
+		Client.SAdd("task/"+parent.UUID+"/children", task.UUID)
+
+		PublishTask(task, string(js))
+
+		tasks = append(tasks, task)
+	}
+	log.Println(mngrUUID, "created", count, "child tasks of", parent.UUID)
+
+	return tasks, ""
+}
+
+func ShowMyTasks(w http.ResponseWriter, r *http.Request) {
+	userUuid := GetUserUUID(r)
+
+	query, apiErr := ListQueryFromRequest(r)
+	if apiErr != nil {
+		http.Error(w, apiErr.Message, apiErr.HTTPStatus)
+		return
 	}
+	list := ListUserTasks(userUuid, query)
 
-	pager := pagination.New(int(count), perPage, currentPage, "/my/tasks")
+	pager := pagination.New(int(list.Count), listPerPage, list.Page, listPagerURL(r, "/task/my"))
 
 	varmap := map[string]interface{}{
 		"Version":        Version,
 		"User":           r.Header.Get("X-Forwarded-User"),
 		"UserUUID":       userUuid,
-		"Results":        results,
-		"AllCount":       count,
-		"Pages":          pages,
-		"Page":           page,
-		"HasPrev":        hasPrev,
-		"HasNext":        hasNext,
+		"Results":        list.Results,
+		"AllCount":       list.Count,
+		"Pages":          list.Pages,
+		"Page":           list.Page,
+		"HasPrev":        list.HasPrev,
+		"HasNext":        list.HasNext,
+		"Query":          r.URL.Query(),
+		"Actions":        taskActions(),
+		"Statuses":       listStatuses,
 		"pager":          pager,
 		csrf.TemplateTag: csrf.TemplateField(r),
 	}
@@ -504,3 +634,29 @@ func ShowCreateForm(w http.ResponseWriter, r *http.Request) {
 	tmpl, _ := templ.New("dashboard", Asset).Parse("dashboard.html")
 	tmpl.Execute(w, varmap)
 }
+
+func ShowTask(w http.ResponseWriter, r *http.Request) {
+	userUuid := GetUserUUID(r)
+
+	detail, apiErr := GetTaskDetail(userUuid, mux.Vars(r)["uuid"])
+	if apiErr != nil {
+		http.Error(w, apiErr.Message, apiErr.HTTPStatus)
+		return
+	}
+
+	varmap := map[string]interface{}{
+		"Version":        Version,
+		"User":           r.Header.Get("X-Forwarded-User"),
+		"UserUUID":       userUuid,
+		"Detail":         detail,
+		csrf.TemplateTag: csrf.TemplateField(r),
+	}
+	if detail.Task.Action == "dns" && len(detail.Children) > 0 {
+		if consistency, apiErr := GetDNSConsistency(userUuid, detail.Task.UUID); apiErr == nil {
+			varmap["DNS"] = consistency
+		}
+	}
+
+	tmpl, _ := templ.New("task", Asset).Parse("task.html")
+	tmpl.Execute(w, varmap)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ad/gocc/api"
+)
+
+func TestBlockMngrTaskExpired(t *testing.T) {
+	useTestRedis(t)
+
+	for _, tc := range []struct {
+		name    string
+		age     int64
+		message string
+		status  string
+	}{
+		{"queued", 0, api.MessageOK, ""},
+		{"expired", 120, api.MessageTaskNotFound, api.TaskStatusExpired},
+	} {
+		task, _, apiErr := CreateTask("alice", TaskCreateRequest{Action: "ping", Param: "8.8.8.8", QueueTTL: 60})
+		if apiErr != nil {
+			t.Fatal(apiErr.Message)
+		}
+		task.Created = time.Now().Unix() - tc.age
+		js, _ := json.Marshal(task)
+		Client.Set("task/"+task.UUID, js, 0)
+
+		if res := BlockMngrTask("mngr", task.Action, task.UUID); res.Message != tc.message {
+			t.Errorf("%s: block %+v, want %s", tc.name, res, tc.message)
+		}
+
+		var saved Action
+		json.Unmarshal([]byte(Client.Get("task/"+task.UUID).Val()), &saved)
+		if saved.Status != tc.status {
+			t.Errorf("%s: status %q, want %q", tc.name, saved.Status, tc.status)
+		}
+	}
+}
+
+func TestCreateTaskWithoutZonds(t *testing.T) {
+	useTestRedis(t)
+
+	if _, _, apiErr := CreateTask("alice", TaskCreateRequest{Action: "ping", Param: "8.8.8.8"}); apiErr == nil || apiErr.Code != api.ErrCodeNoZonds {
+		t.Errorf("task without zonds: %+v, want %s", apiErr, api.ErrCodeNoZonds)
+	}
+
+	task, _, apiErr := CreateTask("alice", TaskCreateRequest{Action: "ping", Param: "8.8.8.8", Type: "measurement"})
+	if apiErr != nil {
+		t.Fatalf("measurement without zonds: %s", apiErr.Message)
+	}
+	if task.Type != "measurement" {
+		t.Errorf("type %q, want measurement", task.Type)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestZondSubInternal(t *testing.T) {
+	useTestRedis(t)
+	handler := NewHandler(NewRouter())
+
+	zond, _ := CreateZond("alice", "z1")
+
+	for _, tc := range []struct {
+		remoteAddr string
+		status     int
+		online     bool
+	}{
+		{"203.0.113.1:1234", http.StatusForbidden, false},
+		{"127.0.0.1:1234", http.StatusOK, true},
+	} {
+		Client.Del("Zond-online")
+
+		req := httptest.NewRequest(http.MethodGet, "/zond/sub", nil)
+		req.RemoteAddr = tc.remoteAddr
+		req.Header.Set("X-ZondUuid", zond.UUID)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != tc.status {
+			t.Errorf("sub from %s: status %d, want %d", tc.remoteAddr, w.Code, tc.status)
+		}
+		if online := Client.SIsMember("Zond-online", zond.UUID).Val(); online != tc.online {
+			t.Errorf("sub from %s: online %v, want %v", tc.remoteAddr, online, tc.online)
+		}
+	}
+}
+
+func TestPagesUseLegacyUserUUID(t *testing.T) {
+	useTestRedis(t)
+	handler := NewHandler(NewRouter())
+
+	for _, path := range []string{"/task/my", "/task/repeatable", "/zond/my", "/mngr/my"} {
+		Client.Del("user/uuid/erin")
+		Client.Set(legacyUserUUIDPrefix+"erin", "erin-legacy", 0)
+
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Forwarded-User", "erin")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		if got := Client.Get("user/uuid/erin").Val(); got != "erin-legacy" {
+			t.Errorf("%s: user uuid %q, want erin-legacy", path, got)
+		}
+	}
+}
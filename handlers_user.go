@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ad/gocc/api"
 	templ "github.com/arschles/go-bindata-html-template"
 	"github.com/gorilla/csrf"
 	"github.com/gorilla/securecookie"
@@ -50,6 +51,9 @@ func UserAuthHandler(w http.ResponseWriter, r *http.Request) {
 func UserLoginHandler(w http.ResponseWriter, r *http.Request) {
 	var errorMessage = ""
 
+	// API clients take csrf token from login form before session exists
+	w.Header().Set("X-CSRF-Token", csrf.Token(r))
+
 	if r.Method == "POST" {
 		login := r.PostFormValue("login")
 		login = strings.TrimSpace(login)
@@ -114,11 +118,7 @@ func UserLoginHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	if r.Header.Get("X-Requested-With") == "xmlhttprequest" {
-		status := "ok"
-		if errorMessage != "" {
-			status = "error"
-		}
-		fmt.Fprintf(w, `{"status": %s, "error": "%s"}`, status, errorMessage)
+		WriteUserResult(w, http.StatusUnauthorized, errorMessage)
 	} else {
 		varmap := map[string]interface{}{
 			"ErrorMessage":   errorMessage,
@@ -201,11 +201,7 @@ func UserRegisterHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Header.Get("X-Requested-With") == "xmlhttprequest" {
-		status := "ok"
-		if errorMessage != "" {
-			status = "error"
-		}
-		fmt.Fprintf(w, `{"status": %s, "error": "%s"}`, status, errorMessage)
+		WriteUserResult(w, http.StatusBadRequest, errorMessage)
 	} else {
 		varmap := map[string]interface{}{
 			"ErrorMessage":   errorMessage,
@@ -218,6 +214,7 @@ func UserRegisterHandler(w http.ResponseWriter, r *http.Request) {
 
 func UserRecoverHandler(w http.ResponseWriter, r *http.Request) {
 	var errorMessage = ""
+	var infoMessage = ""
 
 	if r.Method == "POST" {
 		login := r.PostFormValue("email")
@@ -243,18 +240,17 @@ func UserRecoverHandler(w http.ResponseWriter, r *http.Request) {
 
 				go SendMail(login, "Reset password", `<a href="http://`+Fqdn+`/reset?hash=`+password+`&email=`+login+`">Click to reset</a>`, Fqdn)
 
-				errorMessage = "Email with recovery link sent"
+				infoMessage = "Email with recovery link sent"
 			}
 		}
 	}
 
 	if r.Header.Get("X-Requested-With") == "xmlhttprequest" {
-		status := "ok"
-		if errorMessage != "" {
-			status = "error"
-		}
-		fmt.Fprintf(w, `{"status": %s, "error": "%s"}`, status, errorMessage)
+		WriteUserResult(w, http.StatusBadRequest, errorMessage)
 	} else {
+		if errorMessage == "" {
+			errorMessage = infoMessage
+		}
 		varmap := map[string]interface{}{
 			"ErrorMessage":   errorMessage,
 			csrf.TemplateTag: csrf.TemplateField(r),
@@ -329,12 +325,21 @@ func UserResetHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Header.Get("X-Requested-With") == "xmlhttprequest" {
-		status := "ok"
-		if errorMessage != "" {
-			status = "error"
-		}
-		fmt.Fprintf(w, `{"status": %s, "error": "%s"}`, status, errorMessage)
+		WriteUserResult(w, http.StatusBadRequest, errorMessage)
 	} else {
 		http.Redirect(w, r, redirectURL, http.StatusFound)
 	}
 }
+
+// WriteUserResult answers xmlhttprequest form submits with the API envelope
+func WriteUserResult(w http.ResponseWriter, errorStatus int, errorMessage string) {
+	if errorMessage != "" {
+		code := api.ErrCodeBadRequest
+		if errorStatus == http.StatusUnauthorized {
+			code = api.ErrCodeUnauthorized
+		}
+		WriteError(w, NewApiError(errorStatus, code, errorMessage))
+		return
+	}
+	WriteJSON(w, http.StatusOK, ApiStatusResponse{Status: "ok"})
+}
@@ -6,13 +6,12 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
-	"strconv"
 	"strings"
 
 	pagination "github.com/AndyEverLie/go-pagination-bootstrap"
+	"github.com/ad/gocc/api"
 	templ "github.com/arschles/go-bindata-html-template"
 	"github.com/gorilla/csrf"
-	uuid "github.com/nu7hatch/gouuid"
 )
 
 func ZondPong(w http.ResponseWriter, r *http.Request) {
@@ -28,18 +27,50 @@ func ZondPong(w http.ResponseWriter, r *http.Request) {
 				log.Println(err.Error())
 			}
 			// log.Println("pong from", t.ZondUuid, r.Header.Get("X-Forwarded-For"))
-			tp, _ := Client.Get(t.ZondUUID + "/alive").Result()
-			if t.UUID == tp {
-				Client.Del(t.ZondUUID + "/alive")
+			if ZondAlive(r.Header.Get(api.HeaderZondUUID), t.UUID) {
 				// log.Print(t.ZondUuid, "Zond pong")
 				// w.Header().Set("X-CSRF-Token", csrf.Token(r))
-				fmt.Fprintf(w, `{"status": "ok"}`)
+				writeProtocolResult(w, Result{Message: api.MessageOK})
 			}
 		}
 	}
 }
 
+// ZondAlive answers alive check of zond, aliveUUID is uuid of alive message.
+// It tells whether the pending check matched and is cleared
+func ZondAlive(zondUUID string, aliveUUID string) bool {
+	pending, _ := Client.Get(zondUUID + "/alive").Result()
+	if aliveUUID != pending {
+		return false
+	}
+	Client.Del(zondUUID + "/alive")
+	return true
+}
+
+// ZondEnrollHandler exchanges enrollment token for client certificate of zond
+func ZondEnrollHandler(w http.ResponseWriter, r *http.Request) {
+	var req EnrollRequest
+	if apiErr := DecodeJSONBody(r, &req); apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	resp, apiErr := EnrollZond(req)
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
 func ZondSub(w http.ResponseWriter, r *http.Request) {
+	// nginx sends subscribe callbacks of all agents here
+	if r.Header.Get("X-ZondUuid") == "" && r.Header.Get("X-MngrUuid") != "" {
+		MngrSub(w, r)
+		return
+	}
+
 	var uuid = r.Header.Get("X-ZondUuid")
 	if len(uuid) == 36 {
 		isMember, _ := Client.SIsMember("zonds", uuid).Result()
@@ -68,6 +99,11 @@ func ZondSub(w http.ResponseWriter, r *http.Request) {
 }
 
 func ZondUnsub(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-ZondUuid") == "" && r.Header.Get("X-MngrUuid") != "" {
+		MngrUnsub(w, r)
+		return
+	}
+
 	var uuid = r.Header.Get("X-ZondUuid")
 	if len(uuid) > 0 {
 		log.Println(r.Header.Get("X-ZondUuid"), "— disconnected")
@@ -93,66 +129,28 @@ func ZondUnsub(w http.ResponseWriter, r *http.Request) {
 }
 
 func ShowMyZonds(w http.ResponseWriter, r *http.Request) {
-	var perPage int = 20
-	page, _ := strconv.ParseInt(r.FormValue("page"), 10, 0)
-	if page <= 0 {
-		page = 1
-	}
-	userUuid, _ := Client.Get("user/uuid/" + r.Header.Get("X-Forwarded-User")).Result()
-	if userUuid == "" {
-		u, _ := uuid.NewV4()
-		userUuid = u.String()
-		Client.Set(fmt.Sprintf("user/uuid/%s", r.Header.Get("X-Forwarded-User")), userUuid, 0)
-	}
-
-	count, _ := Client.SCard("user/zonds/" + userUuid).Result()
-	currentPage, pages, hasPrev, hasNext := GetPaginator(int(page), int(count), perPage)
-
-	var results []Zond
-	if count > 0 {
-		// log.Println(count)
-		var cursor = uint64(int64(perPage) * int64(currentPage-1))
-		// var cursorNew uint64
-		var keys []string
-		var err error
-		keys, _, err = Client.SScan("user/zonds/"+userUuid, cursor, "", int64(perPage)).Result()
-
-		if err != nil {
-			log.Println(err)
-		} else {
-			// log.Println(keys)
-			for i, val := range keys {
-				keys[i] = "zonds/" + val
-			}
+	userUuid := GetUserUUID(r)
 
-			items, _ := Client.MGet(keys...).Result()
-			for _, val := range items {
-				if val != nil {
-					var t Zond
-					err := json.Unmarshal([]byte(val.(string)), &t)
-					if err != nil {
-						log.Println(err.Error())
-					}
-					results = append(results, t)
-				}
-			}
-			// log.Println(len(results), count, results)
-		}
-		// log.Println(len(results), count, currentPage, cursor, cursorNew, perPage)
+	query, apiErr := ListQueryFromRequest(r)
+	if apiErr != nil {
+		http.Error(w, apiErr.Message, apiErr.HTTPStatus)
+		return
 	}
+	list := ListUserZonds(userUuid, query)
 
-	pager := pagination.New(int(count), perPage, currentPage, "/my/zonds")
+	pager := pagination.New(int(list.Count), listPerPage, list.Page, listPagerURL(r, "/zond/my"))
 
 	varmap := map[string]interface{}{
 		"Version":        Version,
 		"User":           r.Header.Get("X-Forwarded-User"),
 		"UserUUID":       userUuid,
-		"Results":        results,
-		"AllCount":       count,
-		"Pages":          pages,
-		"Page":           page,
-		"HasPrev":        hasPrev,
-		"HasNext":        hasNext,
+		"Results":        list.Results,
+		"AllCount":       list.Count,
+		"Pages":          list.Pages,
+		"Page":           list.Page,
+		"HasPrev":        list.HasPrev,
+		"HasNext":        list.HasNext,
+		"Query":          r.URL.Query(),
 		"pager":          pager,
 		csrf.TemplateTag: csrf.TemplateField(r),
 	}
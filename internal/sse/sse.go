@@ -0,0 +1,39 @@
+// Package sse reads Server-Sent Events streams produced by nginx nchan.
+package sse
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Read calls fn with data of every event until stream ends or fn returns error
+func Read(r io.Reader, fn func(data []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line != "" {
+			if strings.HasPrefix(line, "data:") {
+				data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+			}
+			continue
+		}
+
+		// empty line ends event
+		if len(data) == 0 {
+			continue
+		}
+		message := strings.Join(data, "\n")
+		data = nil
+
+		if err := fn([]byte(message)); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
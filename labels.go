@@ -0,0 +1,308 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ad/gocc/api"
+)
+
+// labelsTargetPrefix marks Action.Target of task sent to zonds matching label
+// selector, such task is published to zond:<uuid> channel of every matching zond
+const labelsTargetPrefix = "labels:"
+
+// Limits of zond labels
+const (
+	maxLabels           = 32
+	maxLabelKeyLength   = 63
+	maxLabelValueLength = 63
+)
+
+// geoLabels are labels of online zond taken from its geo channels, they can't be set by user
+var geoLabels = map[string]string{
+	"city":    "zond:city",
+	"country": "zond:country",
+	"asn":     "zond:asn",
+}
+
+// networkLabelValue is value of ipv4 and ipv6 labels of zond reaching that
+// address family, the labels are taken from its network channels
+const networkLabelValue = "true"
+
+var (
+	labelKeyRegex   = regexp.MustCompile(`^[a-z0-9]([a-z0-9_.\-/]*[a-z0-9])?$`)
+	labelValueRegex = regexp.MustCompile(`^[^,=!]*$`)
+)
+
+// ValidateLabels checks labels set by user on zond
+func ValidateLabels(labels map[string]string) *ApiError {
+	if len(labels) > maxLabels {
+		return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, fmt.Sprintf("no more than %d labels allowed", maxLabels))
+	}
+	for key, value := range labels {
+		if len(key) > maxLabelKeyLength || !labelKeyRegex.MatchString(key) {
+			return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong label key "+key)
+		}
+		if _, ok := geoLabels[key]; ok {
+			return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "label "+key+" is set from geo data of zond")
+		}
+		if isNetworkLabel(key) {
+			return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "label "+key+" is set from network of zond")
+		}
+		if len(value) > maxLabelValueLength || !labelValueRegex.MatchString(value) || strings.TrimSpace(value) != value {
+			return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong value of label "+key)
+		}
+	}
+	return nil
+}
+
+// Operators of label requirement
+const (
+	labelEquals    = "="
+	labelNotEquals = "!="
+	labelExists    = ""
+	labelNotExists = "!"
+)
+
+type labelRequirement struct {
+	Key      string
+	Operator string
+	Value    string
+}
+
+// LabelSelector is comma separated list of requirements all of which zond
+// must satisfy: key=value, key!=value, key (label is set) and !key (label is
+// not set), e.g. country=DE,net=mobile,!ipv6
+type LabelSelector []labelRequirement
+
+// ParseLabelSelector parses label selector from task dest
+func ParseLabelSelector(s string) (LabelSelector, error) {
+	var selector LabelSelector
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, errors.New("empty label requirement")
+		}
+
+		var req labelRequirement
+		switch {
+		case strings.Contains(part, "!="):
+			kv := strings.SplitN(part, "!=", 2)
+			req = labelRequirement{Key: kv[0], Operator: labelNotEquals, Value: kv[1]}
+		case strings.Contains(part, "="):
+			kv := strings.SplitN(part, "=", 2)
+			req = labelRequirement{Key: kv[0], Operator: labelEquals, Value: kv[1]}
+		case strings.HasPrefix(part, "!"):
+			req = labelRequirement{Key: part[1:], Operator: labelNotExists}
+		default:
+			req = labelRequirement{Key: part, Operator: labelExists}
+		}
+
+		req.Key, req.Value = strings.TrimSpace(req.Key), strings.TrimSpace(req.Value)
+		if len(req.Key) > maxLabelKeyLength || !labelKeyRegex.MatchString(req.Key) {
+			return nil, errors.New("wrong label key " + req.Key)
+		}
+		if len(req.Value) > maxLabelValueLength || !labelValueRegex.MatchString(req.Value) {
+			return nil, errors.New("wrong value of label " + req.Key)
+		}
+		selector = append(selector, req)
+	}
+	return selector, nil
+}
+
+// String returns selector in the form accepted by ParseLabelSelector
+func (s LabelSelector) String() string {
+	parts := make([]string, len(s))
+	for i, req := range s {
+		if req.Operator == labelNotExists {
+			parts[i] = labelNotExists + req.Key
+		} else {
+			parts[i] = req.Key + req.Operator + req.Value
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Matches reports whether labels satisfy all requirements of selector
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, req := range s {
+		value, ok := labels[req.Key]
+		switch req.Operator {
+		case labelEquals:
+			if !ok || value != req.Value {
+				return false
+			}
+		case labelNotEquals:
+			if ok && value == req.Value {
+				return false
+			}
+		case labelExists:
+			if !ok {
+				return false
+			}
+		case labelNotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// onlineZondLabels returns labels of online zonds, set by user and taken
+// from geo and network channels, by zond uuid
+func onlineZondLabels() map[string]map[string]string {
+	result := map[string]map[string]string{}
+
+	zonds, _ := Client.SMembers("Zond-online").Result()
+	if len(zonds) == 0 {
+		return result
+	}
+
+	keys := make([]string, len(zonds))
+	for i, zondUUID := range zonds {
+		keys[i] = "zonds/" + zondUUID
+		result[zondUUID] = map[string]string{}
+	}
+
+	items, _ := Client.MGet(keys...).Result()
+	for i, val := range items {
+		if val == nil {
+			continue
+		}
+		var zond Zond
+		if err := json.Unmarshal([]byte(val.(string)), &zond); err != nil {
+			log.Println(err.Error())
+			continue
+		}
+		for key, value := range zond.Labels {
+			result[zonds[i]][key] = value
+		}
+	}
+
+	for label, hash := range geoLabels {
+		values, _ := Client.HGetAll(hash).Result()
+		for zondUUID, value := range values {
+			if labels, ok := result[zondUUID]; ok {
+				labels[label] = value
+			}
+		}
+	}
+
+	networks, _ := Client.HGetAll(zondNetworkHash).Result()
+	for zondUUID, labels := range result {
+		families, ok := networks[zondUUID]
+		if !ok {
+			families = api.NetworkIPv4
+		}
+		setNetworkLabels(labels, families)
+	}
+
+	return result
+}
+
+// isNetworkLabel tells labels taken from network channels of zond
+func isNetworkLabel(key string) bool {
+	for _, family := range networkFamilies {
+		if key == family {
+			return true
+		}
+	}
+	return false
+}
+
+// setNetworkLabels replaces ipv4 and ipv6 labels with families zond
+// advertised, zonds that did not advertise them are IPv4 only as in ZondReaches
+func setNetworkLabels(labels map[string]string, families string) {
+	for _, family := range networkFamilies {
+		delete(labels, family)
+	}
+	for _, family := range strings.Split(families, ",") {
+		if isNetworkLabel(family) {
+			labels[family] = networkLabelValue
+		}
+	}
+}
+
+// MatchingZonds returns online zonds satisfying selector
+func MatchingZonds(selector LabelSelector) []string {
+	var zonds []string
+	for zondUUID, labels := range onlineZondLabels() {
+		if selector.Matches(labels) {
+			zonds = append(zonds, zondUUID)
+		}
+	}
+	sort.Strings(zonds)
+	return zonds
+}
+
+// ZondMatchesTarget reports whether zond may take task published to target,
+// only label selector targets are checked here, channels are checked by nchan
+func ZondMatchesTarget(zondUUID string, target string) bool {
+	if !strings.HasPrefix(target, labelsTargetPrefix) {
+		return true
+	}
+
+	selector, err := ParseLabelSelector(strings.TrimPrefix(target, labelsTargetPrefix))
+	if err != nil {
+		log.Println(err.Error())
+		return false
+	}
+
+	labels, ok := ZondLabels(zondUUID)
+	return ok && selector.Matches(labels)
+}
+
+// ZondLabels returns labels of online zond, false when zond is offline
+func ZondLabels(zondUUID string) (map[string]string, bool) {
+	if !Client.SIsMember("Zond-online", zondUUID).Val() {
+		return nil, false
+	}
+
+	labels := map[string]string{}
+
+	var zond Zond
+	js, _ := Client.Get("zonds/" + zondUUID).Result()
+	if err := json.Unmarshal([]byte(js), &zond); err != nil {
+		log.Println(err.Error())
+	}
+	for key, value := range zond.Labels {
+		labels[key] = value
+	}
+
+	for label, hash := range geoLabels {
+		if value, err := Client.HGet(hash, zondUUID).Result(); err == nil {
+			labels[label] = value
+		}
+	}
+	families, err := Client.HGet(zondNetworkHash, zondUUID).Result()
+	if err != nil {
+		families = api.NetworkIPv4
+	}
+	setNetworkLabels(labels, families)
+
+	return labels, true
+}
+
+// ActiveLabels returns sorted values of user labels of online zonds by label key
+func ActiveLabels() map[string][]string {
+	values := map[string][]string{}
+	for _, labels := range onlineZondLabels() {
+		for key, value := range labels {
+			if _, ok := geoLabels[key]; !ok && !isNetworkLabel(key) {
+				values[key] = append(values[key], value)
+			}
+		}
+	}
+	for key := range values {
+		values[key] = SliceUniqMap(values[key])
+		sort.Strings(values[key])
+	}
+	return values
+}
@@ -0,0 +1,85 @@
+package main
+
+import "testing"
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     string
+		wrong    bool
+	}{
+		{selector: "country=DE", want: "country=DE"},
+		{selector: " country = DE , net!=mobile ", want: "country=DE,net!=mobile"},
+		{selector: "ipv6,!ipv4", want: "ipv6,!ipv4"},
+		{selector: "net=", want: "net="},
+		{selector: "", wrong: true},
+		{selector: "country=DE,", wrong: true},
+		{selector: ",country=DE", wrong: true},
+		{selector: "!", wrong: true},
+		{selector: "=DE", wrong: true},
+		{selector: "!=DE", wrong: true},
+		{selector: "!country=DE", wrong: true},
+		{selector: "!!country", wrong: true},
+		{selector: "country==DE", wrong: true},
+		{selector: "country=D!E", wrong: true},
+		{selector: "Country=DE", wrong: true},
+		{selector: "country-=DE", wrong: true},
+		// set based requirements are not supported
+		{selector: "country in (DE,FR)", wrong: true},
+		{selector: "country notin (DE,FR)", wrong: true},
+		{selector: "country in ()", wrong: true},
+		{selector: "in()", wrong: true},
+		{selector: "notin()", wrong: true},
+	}
+
+	for _, test := range tests {
+		selector, err := ParseLabelSelector(test.selector)
+		if test.wrong {
+			if err == nil {
+				t.Errorf("ParseLabelSelector(%q) = %q, want error", test.selector, selector)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseLabelSelector(%q): %v", test.selector, err)
+			continue
+		}
+		if selector.String() != test.want {
+			t.Errorf("ParseLabelSelector(%q) = %q, want %q", test.selector, selector, test.want)
+		}
+	}
+}
+
+func TestLabelSelectorMatches(t *testing.T) {
+	labels := map[string]string{"country": "DE", "net": "", "ipv4": networkLabelValue}
+
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"country=DE", true},
+		{"country=FR", false},
+		{"country!=FR", true},
+		{"country!=DE", false},
+		{"city!=Berlin", true},
+		{"net", true},
+		{"net=", true},
+		{"city", false},
+		{"!city", true},
+		{"!country", false},
+		{"!net", false},
+		{"ipv4,!ipv6", true},
+		{"ipv4,ipv6", false},
+		{"country=DE,!ipv4", false},
+	}
+
+	for _, test := range tests {
+		selector, err := ParseLabelSelector(test.selector)
+		if err != nil {
+			t.Fatalf("ParseLabelSelector(%q): %v", test.selector, err)
+		}
+		if got := selector.Matches(labels); got != test.want {
+			t.Errorf("%q matches %v = %v, want %v", test.selector, labels, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,471 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ad/gocc/api"
+	"github.com/go-redis/redis"
+)
+
+// Kinds of user lists, user/<kind>/<user> is set of uuids and
+// index/<kind>/<user> is the same uuids ordered by creation time
+const (
+	listTasks = "tasks"
+	listZonds = "zonds"
+	listMngrs = "mngrs"
+)
+
+// listItemPrefix is key prefix of entries of list kind
+var listItemPrefix = map[string]string{
+	listTasks: "task/",
+	listZonds: "zonds/",
+	listMngrs: "mngrs/",
+}
+
+// Sort orders of lists
+const (
+	sortCreated     = "created"
+	sortCreatedDesc = "-created"
+)
+
+// listPerPage is size of list page
+const listPerPage = 20
+
+// Listed task statuses, besides canceled and expired
+const (
+	listStatusPending    = "pending"
+	listStatusProcessing = "processing"
+	listStatusDone       = "done"
+	listStatusFailed     = "failed"
+)
+
+// listStatuses are statuses tasks are filtered by, in order of task life
+var listStatuses = []string{
+	listStatusPending,
+	listStatusProcessing,
+	listStatusDone,
+	listStatusFailed,
+	api.TaskStatusCanceled,
+	api.TaskStatusExpired,
+}
+
+func validListStatus(status string) bool {
+	for _, s := range listStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// taskActions returns sorted task actions for filter forms
+func taskActions() []string {
+	actions := make([]string, 0, len(taskTypes))
+	for action := range taskTypes {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+func userListKey(kind string, userUUID string) string {
+	return "user/" + kind + "/" + userUUID
+}
+
+func listIndexKey(kind string, userUUID string) string {
+	return "index/" + kind + "/" + userUUID
+}
+
+// IndexAdd adds entry created at created to list of user
+func IndexAdd(kind string, userUUID string, entryUUID string, created int64) {
+	Client.SAdd(userListKey(kind, userUUID), entryUUID)
+	Client.ZAdd(listIndexKey(kind, userUUID), redis.Z{Score: float64(created), Member: entryUUID})
+}
+
+// IndexRemove removes entry from list of user
+func IndexRemove(kind string, userUUID string, entryUUID string) {
+	Client.SRem(userListKey(kind, userUUID), entryUUID)
+	Client.ZRem(listIndexKey(kind, userUUID), entryUUID)
+}
+
+// indexRebuildBatch is count of entries loaded by one MGet when index is rebuilt
+const indexRebuildBatch = 500
+
+// ensureIndex rebuilds time ordered index of user list when it differs from
+// the set, entries added before index existed are indexed here. Entries of
+// missing objects are dropped from both
+func ensureIndex(kind string, userUUID string) {
+	setKey, indexKey := userListKey(kind, userUUID), listIndexKey(kind, userUUID)
+	if Client.SCard(setKey).Val() == Client.ZCard(indexKey).Val() {
+		return
+	}
+
+	members, _ := Client.SMembers(setKey).Result()
+	Client.Del(indexKey)
+	for start := 0; start < len(members); start += indexRebuildBatch {
+		end := start + indexRebuildBatch
+		if end > len(members) {
+			end = len(members)
+		}
+
+		keys := make([]string, end-start)
+		for i, entryUUID := range members[start:end] {
+			keys[i] = listItemPrefix[kind] + entryUUID
+		}
+
+		var entries []redis.Z
+		items, _ := Client.MGet(keys...).Result()
+		for i, val := range items {
+			if val == nil {
+				Client.SRem(setKey, members[start+i])
+				continue
+			}
+			var entry struct {
+				Created int64 `json:"created"`
+			}
+			if err := json.Unmarshal([]byte(val.(string)), &entry); err != nil {
+				log.Println(err.Error())
+			}
+			entries = append(entries, redis.Z{Score: float64(entry.Created), Member: members[start+i]})
+		}
+		if len(entries) > 0 {
+			Client.ZAdd(indexKey, entries...)
+		}
+	}
+
+	log.Println("index", indexKey, "rebuilt,", Client.ZCard(indexKey).Val(), "entries")
+}
+
+// TaskFilter selects listed or exported tasks, empty fields match everything.
+// Target is substring of param, Dest is target channel of task, From and To
+// limit creation time, both inclusive
+type TaskFilter struct {
+	Action   string
+	Status   string
+	Target   string
+	Dest     string
+	Zond     string
+	Schedule string
+	Batch    string
+	From     int64
+	To       int64
+}
+
+// ListStatus returns status of task as it is filtered in lists
+func ListStatus(t Action) string {
+	switch {
+	case t.Status != "":
+		return t.Status
+	case strings.HasPrefix(t.Result, "error"):
+		return listStatusFailed
+	case t.Result != "":
+		return listStatusDone
+	case t.ZondUUID != "" || t.MngrUUID != "":
+		return listStatusProcessing
+	}
+	return listStatusPending
+}
+
+// Match reports whether task passes filter
+func (f TaskFilter) Match(t Action) bool {
+	if f.Action != "" && t.Action != f.Action {
+		return false
+	}
+	if f.Status != "" && ListStatus(t) != f.Status {
+		return false
+	}
+	if f.Target != "" && !strings.Contains(t.Param, f.Target) {
+		return false
+	}
+	if f.Dest != "" && t.Target != f.Dest {
+		return false
+	}
+	if f.Zond != "" && t.ZondUUID != f.Zond {
+		return false
+	}
+	if f.Schedule != "" && scheduleOf(t) != f.Schedule {
+		return false
+	}
+	if f.Batch != "" && t.Batch != f.Batch {
+		return false
+	}
+	if f.From != 0 && t.Created < f.From {
+		return false
+	}
+	if f.To != 0 && t.Created > f.To {
+		return false
+	}
+	return true
+}
+
+// matchesAll reports whether filter checks only creation time, which is
+// checked by index itself
+func (f TaskFilter) matchesAll() bool {
+	return f == TaskFilter{From: f.From, To: f.To}
+}
+
+// parseListTime accepts unix seconds or RFC 3339 time
+func parseListTime(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return ts, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Unix(), nil
+}
+
+// filterDest converts dest of filter to target stored in tasks, dest may be
+// given as on task creation or as stored target
+func filterDest(dest string) string {
+	if target, apiErr := ResolveDestination(dest); apiErr == nil {
+		return target
+	}
+	if strings.HasPrefix(dest, "zond:uuid:") {
+		return "zond:" + strings.TrimPrefix(dest, "zond:uuid:")
+	}
+	return dest
+}
+
+// TaskFilterFromRequest reads task filter from query
+func TaskFilterFromRequest(r *http.Request) (TaskFilter, *ApiError) {
+	filter := TaskFilter{
+		Action:   r.FormValue("action"),
+		Status:   r.FormValue("status"),
+		Target:   r.FormValue("target"),
+		Zond:     r.FormValue("zond"),
+		Schedule: r.FormValue("schedule"),
+		Batch:    r.FormValue("batch"),
+	}
+	if filter.Action != "" && !taskTypes[filter.Action] {
+		return filter, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong action")
+	}
+	if filter.Status != "" && !validListStatus(filter.Status) {
+		return filter, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong status")
+	}
+	if dest := strings.TrimSpace(r.FormValue("dest")); dest != "" {
+		filter.Dest = filterDest(dest)
+	}
+
+	var err error
+	if filter.From, err = parseListTime(r.FormValue("from")); err != nil {
+		return filter, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "from must be unix time or RFC 3339")
+	}
+	if filter.To, err = parseListTime(r.FormValue("to")); err != nil {
+		return filter, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "to must be unix time or RFC 3339")
+	}
+
+	return filter, nil
+}
+
+// ListQuery is position, order and filter of page of user list. Cursor
+// continues list after last entry of previous page, Page is used without it
+type ListQuery struct {
+	Cursor string
+	Page   int
+	Sort   string
+	Filter TaskFilter
+}
+
+// ListQueryFromRequest reads list query from request, filters other than
+// creation time are used only by task lists
+func ListQueryFromRequest(r *http.Request) (ListQuery, *ApiError) {
+	query := ListQuery{Cursor: r.FormValue("cursor"), Page: PageFromRequest(r), Sort: r.FormValue("sort")}
+	if query.Sort == "" {
+		query.Sort = sortCreatedDesc
+	}
+	if query.Sort != sortCreated && query.Sort != sortCreatedDesc {
+		return query, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "sort must be created or -created")
+	}
+	if query.Cursor != "" {
+		if _, _, err := decodeCursor(query.Cursor, query.Sort); err != nil {
+			return query, NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "wrong cursor")
+		}
+	}
+
+	var apiErr *ApiError
+	query.Filter, apiErr = TaskFilterFromRequest(r)
+	return query, apiErr
+}
+
+var errBadCursor = errors.New("wrong cursor")
+
+// encodeCursor returns opaque cursor pointing after entry, it is bound to sort order
+func encodeCursor(sort string, created int64, entryUUID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(sort + "|" + strconv.FormatInt(created, 10) + "|" + entryUUID))
+}
+
+func decodeCursor(cursor string, sort string) (int64, string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, "", err
+	}
+	s := strings.SplitN(string(b), "|", 3)
+	if len(s) != 3 || s[0] != sort || s[2] == "" {
+		return 0, "", errBadCursor
+	}
+	created, err := strconv.ParseInt(s[1], 10, 64)
+	return created, s[2], err
+}
+
+// listPage is page read from index, entries are JSON of objects
+type listPage struct {
+	Entries    []string
+	Count      int64
+	Page       int
+	Pages      int
+	HasPrev    bool
+	HasNext    bool
+	NextCursor string
+}
+
+// scanPageSize is count of index entries read by one ZRangeByScore of scanIndex
+const scanPageSize = 500
+
+// scanIndex calls fn with uuid, creation time and JSON of user list entries
+// in order of query, starting after cursor, until fn returns false
+func scanIndex(kind string, userUUID string, query ListQuery, fn func(entryUUID string, created int64, js string) bool) {
+	desc := query.Sort == sortCreatedDesc
+
+	min, max := "-inf", "+inf"
+	if query.Filter.From != 0 {
+		min = strconv.FormatInt(query.Filter.From, 10)
+	}
+	if query.Filter.To != 0 {
+		max = strconv.FormatInt(query.Filter.To, 10)
+	}
+
+	var afterCreated int64
+	var afterUUID string
+	if query.Cursor != "" {
+		afterCreated, afterUUID, _ = decodeCursor(query.Cursor, query.Sort)
+		// entries created at the same second are ordered by uuid
+		if desc {
+			max = strconv.FormatInt(afterCreated, 10)
+		} else {
+			min = strconv.FormatInt(afterCreated, 10)
+		}
+	}
+
+	indexKey := listIndexKey(kind, userUUID)
+	for offset := int64(0); ; offset += scanPageSize {
+		rangeBy := redis.ZRangeBy{Min: min, Max: max, Offset: offset, Count: scanPageSize}
+		var entries []redis.Z
+		if desc {
+			entries, _ = Client.ZRevRangeByScoreWithScores(indexKey, rangeBy).Result()
+		} else {
+			entries, _ = Client.ZRangeByScoreWithScores(indexKey, rangeBy).Result()
+		}
+		if len(entries) == 0 {
+			return
+		}
+
+		keys := make([]string, len(entries))
+		for i, entry := range entries {
+			keys[i] = listItemPrefix[kind] + entry.Member.(string)
+		}
+
+		items, _ := Client.MGet(keys...).Result()
+		for i, val := range items {
+			entryUUID, created := entries[i].Member.(string), int64(entries[i].Score)
+			if query.Cursor != "" && created == afterCreated {
+				if desc && entryUUID >= afterUUID || !desc && entryUUID <= afterUUID {
+					continue
+				}
+			}
+			if val == nil {
+				continue
+			}
+			if !fn(entryUUID, created, val.(string)) {
+				return
+			}
+		}
+
+		if len(entries) < scanPageSize {
+			return
+		}
+	}
+}
+
+// readList returns page of user list, match filters JSON of entries, nil
+// match takes all entries of created range. Page of cursor query is 0
+func readList(kind string, userUUID string, query ListQuery, match func(js string) bool) listPage {
+	ensureIndex(kind, userUUID)
+
+	page := listPage{Entries: []string{}}
+
+	if match != nil {
+		scanIndex(kind, userUUID, ListQuery{Sort: query.Sort, Filter: query.Filter}, func(entryUUID string, created int64, js string) bool {
+			if match(js) {
+				page.Count++
+			}
+			return true
+		})
+	} else {
+		min, max := "-inf", "+inf"
+		if query.Filter.From != 0 {
+			min = strconv.FormatInt(query.Filter.From, 10)
+		}
+		if query.Filter.To != 0 {
+			max = strconv.FormatInt(query.Filter.To, 10)
+		}
+		page.Count = Client.ZCount(listIndexKey(kind, userUUID), min, max).Val()
+	}
+
+	skip := 0
+	if query.Cursor == "" {
+		page.Page, page.Pages, page.HasPrev, _ = GetPaginator(query.Page, int(page.Count), listPerPage)
+		skip = (page.Page - 1) * listPerPage
+	} else {
+		_, page.Pages, _, _ = GetPaginator(1, int(page.Count), listPerPage)
+		page.HasPrev = true
+	}
+
+	var lastCreated int64
+	var lastUUID string
+	scanIndex(kind, userUUID, query, func(entryUUID string, created int64, js string) bool {
+		if match != nil && !match(js) {
+			return true
+		}
+		if skip > 0 {
+			skip--
+			return true
+		}
+		if len(page.Entries) == listPerPage {
+			page.HasNext = true
+			return false
+		}
+		page.Entries = append(page.Entries, js)
+		lastCreated, lastUUID = created, entryUUID
+		return true
+	})
+
+	if page.HasNext {
+		page.NextCursor = encodeCursor(query.Sort, lastCreated, lastUUID)
+	}
+
+	return page
+}
+
+// listPagerURL returns link of list page at path keeping filters of request
+func listPagerURL(r *http.Request, path string) string {
+	values := r.URL.Query()
+	values.Del("page")
+	values.Del("cursor")
+	if len(values) == 0 {
+		return path
+	}
+	return path + "?" + values.Encode()
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+// readAllPages follows cursors of list from first page and returns uuids of entries
+func readAllPages(t *testing.T, query ListQuery, match func(js string) bool) []string {
+	t.Helper()
+
+	var uuids []string
+	for pages := 0; ; pages++ {
+		if pages > 100 {
+			t.Fatal("cursor does not advance")
+		}
+		page := readList(listTasks, "alice", query, match)
+		uuids = append(uuids, page.Entries...)
+		if !page.HasNext {
+			return uuids
+		}
+		if len(page.Entries) != listPerPage {
+			t.Fatalf("page with next has %d entries, want %d", len(page.Entries), listPerPage)
+		}
+		query.Cursor, query.Page = page.NextCursor, 0
+	}
+}
+
+func TestReadListCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		created func(i int) int64
+		count   int
+	}{
+		// entries of one second span several scan pages
+		{"same second", func(int) int64 { return 1000 }, scanPageSize + 2*listPerPage + 3},
+		{"page boundary inside second", func(i int) int64 { return 1000 + int64(i/(listPerPage+3)) }, 5*listPerPage + 1},
+		{"distinct seconds", func(i int) int64 { return 1000 + int64(i) }, 2 * listPerPage},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testReadListCursor(t, test.count, test.created)
+		})
+	}
+}
+
+// testReadListCursor indexes count entries in shuffled order and reads them page by page
+func testReadListCursor(t *testing.T, count int, entryCreated func(i int) int64) {
+	useTestRedis(t)
+
+	var want []string
+	for i := 0; i < count; i++ {
+		// uuid is stored as JSON of entry to compare order
+		entryUUID := fmt.Sprintf("%08x", (i*7919)%count)
+		Client.Set("task/"+entryUUID, entryUUID, 0)
+		IndexAdd(listTasks, "alice", entryUUID, entryCreated(i))
+		want = append(want, entryUUID)
+	}
+	created := map[string]int64{}
+	for i, entryUUID := range want {
+		created[entryUUID] = entryCreated(i)
+	}
+	sort.Slice(want, func(i, j int) bool {
+		if created[want[i]] != created[want[j]] {
+			return created[want[i]] < created[want[j]]
+		}
+		return want[i] < want[j]
+	})
+
+	for _, order := range []string{sortCreated, sortCreatedDesc} {
+		expected := append([]string(nil), want...)
+		if order == sortCreatedDesc {
+			for i, j := 0, len(expected)-1; i < j; i, j = i+1, j-1 {
+				expected[i], expected[j] = expected[j], expected[i]
+			}
+		}
+
+		for _, match := range []func(string) bool{nil, func(string) bool { return true }} {
+			got := readAllPages(t, ListQuery{Page: 1, Sort: order}, match)
+			if len(got) != len(expected) {
+				t.Errorf("%s: read %d entries, want %d", order, len(got), len(expected))
+				continue
+			}
+			for i := range got {
+				if got[i] != expected[i] {
+					t.Errorf("%s: entry %d is %s, want %s", order, i, got[i], expected[i])
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestReadListCursorOfRemovedEntry(t *testing.T) {
+	useTestRedis(t)
+
+	for i := 0; i < listPerPage+5; i++ {
+		entryUUID := fmt.Sprintf("%08x", i)
+		Client.Set("task/"+entryUUID, entryUUID, 0)
+		IndexAdd(listTasks, "alice", entryUUID, 1000)
+	}
+
+	page := readList(listTasks, "alice", ListQuery{Page: 1, Sort: sortCreated}, nil)
+	last := page.Entries[len(page.Entries)-1]
+	Client.Del("task/" + last)
+	IndexRemove(listTasks, "alice", last)
+
+	// cursor still points after removed entry
+	next := readList(listTasks, "alice", ListQuery{Cursor: page.NextCursor, Sort: sortCreated}, nil)
+	if len(next.Entries) != 5 || next.Entries[0] != fmt.Sprintf("%08x", listPerPage) {
+		t.Errorf("page after removed entry = %v", next.Entries)
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	tests := []struct {
+		cursor string
+		sort   string
+		wrong  bool
+	}{
+		{cursor: encodeCursor(sortCreated, 1000, "a"), sort: sortCreated},
+		{cursor: encodeCursor(sortCreated, -1, "a|b"), sort: sortCreated},
+		{cursor: encodeCursor(sortCreated, 1000, "a"), sort: sortCreatedDesc, wrong: true},
+		{cursor: encodeCursor(sortCreated, 1000, ""), sort: sortCreated, wrong: true},
+		{cursor: "not base64!", sort: sortCreated, wrong: true},
+		{cursor: "", sort: sortCreated, wrong: true},
+		{cursor: "Y3JlYXRlZHwxMDAw", sort: sortCreated, wrong: true}, // created|1000
+		{cursor: "Y3JlYXRlZHx4fGE", sort: sortCreated, wrong: true},  // created|x|a
+	}
+
+	for _, test := range tests {
+		_, _, err := decodeCursor(test.cursor, test.sort)
+		if test.wrong != (err != nil) {
+			t.Errorf("decodeCursor(%q, %q) error %v, want error %v", test.cursor, test.sort, err, test.wrong)
+		}
+	}
+}
@@ -30,11 +30,11 @@ func SendMail(to string, subject string, body string, hostname string) {
 	// Build RFC-2822 email
 	toAddresses := []string{}
 	for i, _ := range toEmails {
-		to := mail.Address{toNames[i], toEmails[i]}
+		to := mail.Address{Name: toNames[i], Address: toEmails[i]}
 		toAddresses = append(toAddresses, to.String())
 	}
 	toHeader := strings.Join(toAddresses, ", ")
-	from := mail.Address{fromName, fromEmail}
+	from := mail.Address{Name: fromName, Address: fromEmail}
 	fromHeader := from.String()
 	subjectHeader := subject
 	header := make(map[string]string)
@@ -11,6 +11,7 @@ import (
 
 	uuid "github.com/nu7hatch/gouuid"
 
+	"github.com/ad/gocc/api"
 	"github.com/gorilla/csrf"
 	"github.com/gorilla/mux"
 )
@@ -19,16 +20,25 @@ const version = "0.4.21"
 
 var port = flag.String("port", "9000", "Port to listen on")
 var gogeoaddr = flag.String("gogeoaddr", "http://127.0.0.1:9001", "Address:port of gogeo instance")
+var nchanaddr = flag.String("nchanaddr", "http://127.0.0.1:80", "Address:port of nchan publisher")
+var defaultQueueTTL = flag.Int64("queuettl", 0, "Seconds task without online zonds of its destination waits in queue by default, 0 rejects such tasks")
+var nolimit = flag.Bool("nolimit", false, "Disable rate limits, only for local load tests")
+var cadir = flag.String("cadir", "", "Directory of internal CA issuing zond certificates, created on first start, empty disables enrollment")
+var tlsaddr = flag.String("tlsaddr", "", "Address of HTTPS listener accepting zond certificates, needs -cadir")
+var grpcaddr = flag.String("grpcaddr", "", "Address of gRPC zond service with mutual TLS, needs -cadir")
+var tlshosts = flag.String("tlshosts", "localhost,127.0.0.1", "Names and addresses in certificate of -tlsaddr and -grpcaddr listeners")
 var serveruuid, _ = uuid.NewV4()
 var fqdn = FQDN()
 
 func init() {
 	log.SetFlags(log.Lmicroseconds | log.Lshortfile)
-	flag.Parse()
 	rand.Seed(time.Now().UnixNano())
 }
 
 func main() {
+	// flags are parsed here and not in init, so go test can pass its own
+	flag.Parse()
+
 	log.Printf("Started version %s at %s", version, fqdn)
 
 	go StartSelfupdate("ad/gocc", version, fqdn)
@@ -43,12 +53,33 @@ func main() {
 		}
 	}(resetProcessingTicker)
 
+	expireTasksTicker := time.NewTicker(60 * time.Second)
+	go func(expireTasksTicker *time.Ticker) {
+		for {
+			select {
+			case <-expireTasksTicker.C:
+				ExpireTasks()
+			}
+		}
+	}(expireTasksTicker)
+
+	compactTicker := time.NewTicker(time.Hour)
+	go func(compactTicker *time.Ticker) {
+		for {
+			select {
+			case <-compactTicker.C:
+				CompactTasks(*compactDryRun)
+			}
+		}
+	}(compactTicker)
+
 	checkAliveTicker := time.NewTicker(60 * time.Second)
 	go func(checkAliveTicker *time.Ticker) {
 		for {
 			select {
 			case <-checkAliveTicker.C:
 				CheckAlive()
+				CheckMngrAlive()
 			}
 		}
 	}(checkAliveTicker)
@@ -73,6 +104,32 @@ func main() {
 		}
 	}(getActiveDestinationsTicker)
 
+	r := NewRouter()
+
+	for _, drift := range OpenAPIDrift(r) {
+		log.Println("openapi:", drift)
+	}
+
+	log.Printf("listening on port %s", *port)
+
+	MigrateLegacyUserUUIDs()
+
+	go ResendOffline()
+	go ResendRepeatable(true)
+
+	if *cadir != "" {
+		var err error
+		if ca, err = LoadCA(*cadir); err != nil {
+			log.Fatal(err)
+		}
+		go ServeZondTLS()
+	}
+
+	log.Fatal(http.ListenAndServe("127.0.0.1:"+*port, NewHandler(r)))
+}
+
+// NewRouter registers all routes of control center
+func NewRouter() *mux.Router {
 	r := mux.NewRouter()
 
 	r.Handle("/", Throttle(time.Minute, 60, http.HandlerFunc(GetHandler))).Methods("GET")
@@ -88,47 +145,104 @@ func main() {
 	r.Handle("/login", Throttle(time.Minute, 5, http.HandlerFunc(UserLoginHandler)))
 	r.Handle("/register", Throttle(time.Minute, 5, http.HandlerFunc(UserRegisterHandler)))
 
-	r.Handle("/api/token", Throttle(time.Minute, 60, http.HandlerFunc(ApiTokenHandler))).Methods("GET")
+	r.Handle("/api/token", Throttle(time.Minute, 60, Deprecated("/api/v1/token", http.HandlerFunc(ApiTokenHandler)))).Methods("GET")
 
 	r.Handle("/task/my", Throttle(time.Minute, 60, http.HandlerFunc(ShowMyTasks))).Methods("GET")
-	r.Handle("/api/task/my", Throttle(time.Minute, 60, http.HandlerFunc(ApiShowMyTasks))).Methods("GET")
+	r.Handle("/api/task/my", Throttle(time.Minute, 60, Deprecated("/api/v1/tasks", http.HandlerFunc(ApiShowMyTasks)))).Methods("GET")
 
 	r.Handle("/zond/my", Throttle(time.Minute, 60, http.HandlerFunc(ShowMyZonds))).Methods("GET")
-	r.Handle("/api/zond/my", Throttle(time.Minute, 60, http.HandlerFunc(ApiShowMyZonds))).Methods("GET")
+	r.Handle("/api/zond/my", Throttle(time.Minute, 60, Deprecated("/api/v1/zonds", http.HandlerFunc(ApiShowMyZonds)))).Methods("GET")
 
 	r.Handle("/mngr/my", Throttle(time.Minute, 60, http.HandlerFunc(ShowMyMngrs))).Methods("GET")
-	r.Handle("/api/mngr/my", Throttle(time.Minute, 60, http.HandlerFunc(ApiShowMyMngrs))).Methods("GET")
+	r.Handle("/api/mngr/my", Throttle(time.Minute, 60, Deprecated("/api/v1/mngrs", http.HandlerFunc(ApiShowMyMngrs)))).Methods("GET")
 
 	r.Handle("/task/repeatable", Throttle(time.Minute, 60, http.HandlerFunc(ShowRepeatableTasks))).Methods("GET")
-	r.Handle("/api/task/repeatable", Throttle(time.Minute, 60, http.HandlerFunc(ApiShowRepeatableTasks))).Methods("GET")
-
-	r.Handle("/api/task/create", Throttle(time.Minute, 10, http.HandlerFunc(ApiTaskCreateHandler))).Methods("POST")
-	r.Handle("/api/zond/create", Throttle(time.Minute, 10, http.HandlerFunc(ApiZondCreateHandler))).Methods("POST")
-	r.Handle("/api/mngr/create", Throttle(time.Minute, 10, http.HandlerFunc(ApiMngrCreateHandler))).Methods("POST")
-	r.Handle("/api/task/repeatable/remove", Throttle(time.Minute, 10, http.HandlerFunc(ApiTaskRepeatableRemoveHandler))).Methods("POST")
+	r.Handle("/api/task/export", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1TaskExportHandler)))).Methods("GET")
+	r.Handle("/api/task/repeatable", Throttle(time.Minute, 60, Deprecated("/api/v1/tasks/repeatable", http.HandlerFunc(ApiShowRepeatableTasks)))).Methods("GET")
+
+	r.Handle("/api/task/bulk", Throttle(time.Minute, 10, Deprecated("/api/v1/tasks/bulk", ApiAuth(http.HandlerFunc(ApiV1TaskBulkHandler))))).Methods("POST")
+	r.Handle("/api/task/create", Throttle(time.Minute, 10, Deprecated("/api/v1/tasks", http.HandlerFunc(ApiTaskCreateHandler)))).Methods("POST")
+	r.Handle("/api/zond/create", Throttle(time.Minute, 10, Deprecated("/api/v1/zonds", http.HandlerFunc(ApiZondCreateHandler)))).Methods("POST")
+	r.Handle("/api/mngr/create", Throttle(time.Minute, 10, Deprecated("/api/v1/mngrs", http.HandlerFunc(ApiMngrCreateHandler)))).Methods("POST")
+	r.Handle("/api/task/repeatable/remove", Throttle(time.Minute, 10, Deprecated("/api/v1/tasks/repeatable/{uuid}", http.HandlerFunc(ApiTaskRepeatableRemoveHandler)))).Methods("POST")
+
+	// after other /task/ routes, so uuid doesn't catch them
+	r.Handle("/task/{uuid}", Throttle(time.Minute, 60, http.HandlerFunc(ShowTask))).Methods("GET")
+
+	// versioned api
+	v1 := r.PathPrefix("/api/v1").Subrouter()
+	v1.Handle("/token", Throttle(time.Minute, 60, ApiAuth(http.HandlerFunc(ApiV1TokenHandler)))).Methods("GET")
+	v1.Handle("/tasks", Throttle(time.Minute, 60, ApiAuth(http.HandlerFunc(ApiV1TaskListHandler)))).Methods("GET")
+	v1.Handle("/tasks", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1TaskCreateHandler)))).Methods("POST")
+	v1.Handle("/tasks/repeatable", Throttle(time.Minute, 60, ApiAuth(http.HandlerFunc(ApiV1TaskRepeatableListHandler)))).Methods("GET")
+	v1.Handle("/tasks/repeatable/{uuid}", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1TaskRepeatableRemoveHandler)))).Methods("DELETE")
+	v1.Handle("/tasks/bulk", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1TaskBulkHandler)))).Methods("POST")
+	v1.Handle("/tasks/export", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1TaskExportHandler)))).Methods("GET")
+	v1.Handle("/tasks/repeatable/{uuid}/retention", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1ScheduleRetentionHandler)))).Methods("PUT")
+	v1.Handle("/tasks/{uuid}", Throttle(time.Minute, 60, ApiAuth(http.HandlerFunc(ApiV1TaskGetHandler)))).Methods("GET")
+	v1.Handle("/tasks/{uuid}/detail", Throttle(time.Minute, 60, ApiAuth(http.HandlerFunc(ApiV1TaskDetailHandler)))).Methods("GET")
+	v1.Handle("/tasks/{uuid}/dns", Throttle(time.Minute, 60, ApiAuth(http.HandlerFunc(ApiV1TaskDNSHandler)))).Methods("GET")
+	v1.Handle("/tasks/{uuid}", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1TaskCancelHandler)))).Methods("DELETE")
+	v1.Handle("/retention", Throttle(time.Minute, 60, ApiAuth(http.HandlerFunc(ApiV1RetentionGetHandler)))).Methods("GET")
+	v1.Handle("/retention", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1RetentionSetHandler)))).Methods("PUT")
+	v1.Handle("/retention/report", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1RetentionReportHandler)))).Methods("GET")
+	v1.Handle("/zonds", Throttle(time.Minute, 60, ApiAuth(http.HandlerFunc(ApiV1ZondListHandler)))).Methods("GET")
+	v1.Handle("/zonds", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1ZondCreateHandler)))).Methods("POST")
+	v1.Handle("/zonds/{uuid}", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1ZondUpdateHandler)))).Methods("PATCH")
+	v1.Handle("/zonds/{uuid}", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1ZondDeleteHandler)))).Methods("DELETE")
+	v1.Handle("/zonds/{uuid}/rotate", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1ZondRotateHandler)))).Methods("POST")
+	v1.Handle("/zonds/{uuid}/enroll", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1ZondEnrollTokenHandler)))).Methods("POST")
+	v1.Handle("/mngrs", Throttle(time.Minute, 60, ApiAuth(http.HandlerFunc(ApiV1MngrListHandler)))).Methods("GET")
+	v1.Handle("/mngrs", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1MngrCreateHandler)))).Methods("POST")
+	v1.Handle("/mngrs/{uuid}", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1MngrUpdateHandler)))).Methods("PATCH")
+	v1.Handle("/mngrs/{uuid}", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1MngrDeleteHandler)))).Methods("DELETE")
+	v1.Handle("/mngrs/{uuid}/rotate", Throttle(time.Minute, 10, ApiAuth(http.HandlerFunc(ApiV1MngrRotateHandler)))).Methods("POST")
+
+	r.Handle("/api/openapi.json", Throttle(time.Minute, 60, http.HandlerFunc(ApiOpenAPIHandler))).Methods("GET")
 
 	// requests from zonds
-	r.Handle("/zond/task/block", Throttle(time.Minute, 60, ZondAuth(http.HandlerFunc(TaskZondBlockHandler)))).Methods("POST")
-	r.Handle("/zond/task/result", Throttle(time.Minute, 60, ZondAuth(http.HandlerFunc(TaskZondResultHandler)))).Methods("POST")
-	r.Handle("/zond/pong", Throttle(time.Minute, 15, ZondAuth(http.HandlerFunc(ZondPong)))).Methods("POST")
+	addZondRoutes(r)
 
 	// internal requests
-	r.Handle("/zond/sub", Throttle(time.Minute, 60, http.HandlerFunc(ZondSub))).Methods("GET")
-	r.Handle("/zond/unsub", Throttle(time.Minute, 60, http.HandlerFunc(ZondUnsub))).Methods("GET")
+	r.Handle("/zond/sub", Throttle(time.Minute, 60, Internal(http.HandlerFunc(ZondSub)))).Methods("GET")
+	r.Handle("/zond/unsub", Throttle(time.Minute, 60, Internal(http.HandlerFunc(ZondUnsub)))).Methods("GET")
 
 	r.Handle("/mngr/my", Throttle(time.Minute, 60, http.HandlerFunc(ShowMyMngrs)))
 
 	// requests from managers
-	r.Handle("/mngr/task/block", MngrAuth(http.HandlerFunc(TaskMngrBlockHandler))).Methods("POST")
-	r.Handle("/mngr/task/result", MngrAuth(http.HandlerFunc(TaskMngrResultHandler))).Methods("POST")
-	r.Handle("/mngr/pong", Throttle(time.Minute, 5, MngrAuth(http.HandlerFunc(MngrPong)))).Methods("POST")
+	r.Handle(api.PathMngrBlock, MngrAuth(http.HandlerFunc(TaskMngrBlockHandler))).Methods("POST")
+	r.Handle(api.PathMngrResult, MngrAuth(http.HandlerFunc(TaskMngrResultHandler))).Methods("POST")
+	r.Handle(api.PathMngrTaskCreate, MngrAuth(http.HandlerFunc(TaskMngrCreateHandler))).Methods("POST")
+	r.Handle(api.PathMngrPong, Throttle(time.Minute, 5, MngrAuth(http.HandlerFunc(MngrPong)))).Methods("POST")
 
 	// internal requests
-	r.Handle("/mngr/sub", Throttle(time.Minute, 60, http.HandlerFunc(MngrSub))).Methods("GET")
-	r.Handle("/mngr/unsub", Throttle(time.Minute, 60, http.HandlerFunc(MngrUnsub))).Methods("GET")
+	r.Handle("/mngr/sub", Throttle(time.Minute, 60, Internal(http.HandlerFunc(MngrSub)))).Methods("GET")
+	r.Handle("/mngr/unsub", Throttle(time.Minute, 60, Internal(http.HandlerFunc(MngrUnsub)))).Methods("GET")
 
 	r.NotFoundHandler = http.HandlerFunc(NotFound)
 
+	return r
+}
+
+// NewZondRouter registers only routes of zonds, it serves TLS listener that
+// is reached without nginx
+func NewZondRouter() *mux.Router {
+	r := mux.NewRouter()
+	addZondRoutes(r)
+	r.NotFoundHandler = http.HandlerFunc(NotFound)
+
+	return r
+}
+
+func addZondRoutes(r *mux.Router) {
+	r.Handle(api.PathZondBlock, Throttle(time.Minute, 60, ZondAuth(http.HandlerFunc(TaskZondBlockHandler)))).Methods("POST")
+	r.Handle(api.PathZondResult, Throttle(time.Minute, 60, ZondAuth(http.HandlerFunc(TaskZondResultHandler)))).Methods("POST")
+	r.Handle(api.PathZondEnroll, Throttle(time.Minute, 10, http.HandlerFunc(ZondEnrollHandler))).Methods("POST")
+	r.Handle(api.PathZondPong, Throttle(time.Minute, 15, ZondAuth(http.HandlerFunc(ZondPong)))).Methods("POST")
+}
+
+// NewHandler wraps router with csrf protection and request logging
+func NewHandler(r http.Handler) http.Handler {
 	CSRF := csrf.Protect(
 		[]byte(RandStr(32)),
 		csrf.FieldName("token"),
@@ -138,7 +252,7 @@ func main() {
 
 	skipCheck := func(h http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
-			for _, path := range []string{"/zond/task", "/zond/pong", "/mngr/task", "/mngr/pong"} {
+			for _, path := range []string{"/zond/task", "/zond/pong", api.PathZondEnroll, "/mngr/task", "/mngr/pong"} {
 				if strings.HasPrefix(r.URL.Path, path) {
 					r = csrf.UnsafeSkipCheck(r)
 				}
@@ -153,9 +267,9 @@ func main() {
 			t := time.Now()
 			h.ServeHTTP(w, r)
 			elapsed := time.Since(t)
-			UUID := r.Header.Get("X-ZondUuid")
+			UUID := r.Header.Get(api.HeaderZondUUID)
 			if UUID == "" {
-				UUID = r.Header.Get("X-MngrUuid")
+				UUID = r.Header.Get(api.HeaderMngrUUID)
 			}
 			fmt.Printf(
 				"%s - %s%s - [%s] \"%s %s %s\" %s\n",
@@ -172,10 +286,5 @@ func main() {
 		return http.HandlerFunc(fn)
 	}
 
-	log.Printf("listening on port %s", *port)
-
-	go ResendOffline()
-	go ResendRepeatable(true)
-
-	log.Fatal(http.ListenAndServe("127.0.0.1:"+*port, skipCheck(CSRF(loggingHandler(r)))))
+	return skipCheck(CSRF(loggingHandler(r)))
 }
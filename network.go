@@ -0,0 +1,127 @@
+package main
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ad/gocc/api"
+)
+
+// zondNetworkHash keeps comma separated address families of online zonds by zond uuid
+const zondNetworkHash = "zond:net"
+
+// networkFamilies are address families zond may advertise
+var networkFamilies = []string{api.NetworkIPv4, api.NetworkIPv6}
+
+// ipFamily returns address family of ip, empty for hostnames
+func ipFamily(host string) string {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return ""
+	}
+	if ip.To4() != nil {
+		return api.NetworkIPv4
+	}
+	return api.NetworkIPv6
+}
+
+// ZondNetworks returns families advertised by zond in header, unknown ones are
+// skipped. Without header family of address zond connects from is used
+func ZondNetworks(header string, addr string) []string {
+	seen := map[string]bool{}
+	for _, family := range strings.Split(header, ",") {
+		seen[strings.ToLower(strings.TrimSpace(family))] = true
+	}
+
+	var families []string
+	for _, family := range networkFamilies {
+		if seen[family] {
+			families = append(families, family)
+		}
+	}
+	if len(families) > 0 {
+		return families
+	}
+
+	// X-Forwarded-For may list proxies after address of zond
+	addr = strings.TrimSpace(strings.Split(addr, ",")[0])
+	if family := ipFamily(addr); family != "" {
+		return []string{family}
+	}
+	return nil
+}
+
+// networkChannels returns Net: channels of families
+func networkChannels(families []string) []string {
+	channels := make([]string, len(families))
+	for i, family := range families {
+		channels[i] = api.ChannelNetwork(family)
+	}
+	return channels
+}
+
+// saveZondNetworks stores families zond advertised on subscription
+func saveZondNetworks(zondUUID string, families []string) {
+	if len(families) == 0 {
+		Client.HDel(zondNetworkHash, zondUUID)
+		return
+	}
+	sort.Strings(families)
+	Client.HSet(zondNetworkHash, zondUUID, strings.Join(families, ","))
+}
+
+// ZondReaches reports whether online zond reaches address family. Zonds
+// subscribed before they advertised families are counted as IPv4 only
+func ZondReaches(zondUUID string, family string) bool {
+	if family == "" {
+		return true
+	}
+	value, err := Client.HGet(zondNetworkHash, zondUUID).Result()
+	if err != nil {
+		return family == api.NetworkIPv4
+	}
+	for _, f := range strings.Split(value, ",") {
+		if f == family {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskFamily returns address family zond needs to run task with validated
+// param: family of ip target, of url host for head and http, of resolver for dns and
+// of host for tcp and tls. Empty when task runs over any family, e.g. for hostname
+// targets
+func TaskFamily(taskType string, param string) string {
+	switch taskType {
+	case "head", "http":
+		u, err := url.Parse(param)
+		if err != nil {
+			return ""
+		}
+		return ipFamily(u.Hostname())
+	case "dns":
+		if i := strings.LastIndex(param, "-"); i > 0 {
+			return ipFamily(param[i+1:])
+		}
+		return ""
+	case "tcp", "tls":
+		host, _, err := net.SplitHostPort(param)
+		if err != nil {
+			return ""
+		}
+		return ipFamily(host)
+	}
+	return ipFamily(param)
+}
+
+// taskChannel returns channel task is published to, IPv6 tasks of common
+// tasks channel go to zonds reaching IPv6 only
+func taskChannel(action Action) string {
+	if action.Target == "tasks" && TaskFamily(action.Action, action.Param) == api.NetworkIPv6 {
+		return api.ChannelNetwork(api.NetworkIPv6)
+	}
+	return action.Target
+}
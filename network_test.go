@@ -0,0 +1,166 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ad/gocc/api"
+)
+
+func TestIPFamily(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"8.8.8.8", api.NetworkIPv4},
+		{"2001:db8::1", api.NetworkIPv6},
+		{"::1", api.NetworkIPv6},
+		{"::", api.NetworkIPv6},
+		// v4-mapped address is reached over IPv4
+		{"::ffff:8.8.8.8", api.NetworkIPv4},
+		{"::ffff:808:808", api.NetworkIPv4},
+		{"0:0:0:0:0:ffff:8.8.8.8", api.NetworkIPv4},
+		// v4-compatible address is IPv6
+		{"::8.8.8.8", api.NetworkIPv6},
+		{"64:ff9b::8.8.8.8", api.NetworkIPv6},
+		{"[2001:db8::1]", ""},
+		{"fe80::1%eth0", ""},
+		{"example.com", ""},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if got := ipFamily(test.host); got != test.want {
+			t.Errorf("ipFamily(%q) = %q, want %q", test.host, got, test.want)
+		}
+	}
+}
+
+func TestZondNetworks(t *testing.T) {
+	tests := []struct {
+		header string
+		addr   string
+		want   []string
+	}{
+		{"ipv4,ipv6", "", []string{api.NetworkIPv4, api.NetworkIPv6}},
+		{" IPv6 , ipv6 ", "8.8.8.8", []string{api.NetworkIPv6}},
+		{"ipv5", "2001:db8::1", []string{api.NetworkIPv6}},
+		{"", "2001:db8::1, 10.0.0.1", []string{api.NetworkIPv6}},
+		{"", "::ffff:10.0.0.1", []string{api.NetworkIPv4}},
+		{"", " 10.0.0.1 ,2001:db8::1", []string{api.NetworkIPv4}},
+		// zond reports no network from unknown address
+		{"", "", nil},
+		{",", "unknown", nil},
+		{"", ", 10.0.0.1", nil},
+	}
+
+	for _, test := range tests {
+		if got := ZondNetworks(test.header, test.addr); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ZondNetworks(%q, %q) = %v, want %v", test.header, test.addr, got, test.want)
+		}
+	}
+}
+
+func TestTaskFamily(t *testing.T) {
+	tests := []struct {
+		action string
+		param  string
+		want   string
+	}{
+		{"ping", "8.8.8.8", api.NetworkIPv4},
+		{"ping", "::ffff:8.8.8.8", api.NetworkIPv4},
+		{"ping", "2001:db8::1", api.NetworkIPv6},
+		{"ping", "example.com", ""},
+		{"http", "http://[2001:db8::1]:8080/", api.NetworkIPv6},
+		{"http", "http://[::ffff:8.8.8.8]/", api.NetworkIPv4},
+		{"head", "https://example.com/", ""},
+		{"dns", "example.com-2001:db8::53", api.NetworkIPv6},
+		{"dns", "example.com-8.8.8.8", api.NetworkIPv4},
+		{"dns", "example.com", ""},
+		{"tcp", "[2001:db8::1]:443", api.NetworkIPv6},
+		{"tls", "[::ffff:8.8.8.8]:443", api.NetworkIPv4},
+		{"tcp", "example.com:443", ""},
+	}
+
+	for _, test := range tests {
+		if got := TaskFamily(test.action, test.param); got != test.want {
+			t.Errorf("TaskFamily(%q, %q) = %q, want %q", test.action, test.param, got, test.want)
+		}
+	}
+}
+
+func TestZondWithoutNetworks(t *testing.T) {
+	useTestRedis(t)
+
+	dual, _ := CreateZond("alice", "dual")
+	silent, _ := CreateZond("alice", "silent")
+	Client.SAdd("Zond-online", dual.UUID, silent.UUID)
+	saveZondNetworks(dual.UUID, []string{api.NetworkIPv6, api.NetworkIPv4})
+	saveZondNetworks(silent.UUID, []string{api.NetworkIPv6})
+	// reconnect without advertised families drops previous ones
+	saveZondNetworks(silent.UUID, ZondNetworks("", ""))
+
+	if value := Client.HGet(zondNetworkHash, dual.UUID).Val(); value != "ipv4,ipv6" {
+		t.Errorf("families of dual zond = %q", value)
+	}
+
+	tests := []struct {
+		zondUUID string
+		family   string
+		want     bool
+	}{
+		{dual.UUID, "", true},
+		{dual.UUID, api.NetworkIPv4, true},
+		{dual.UUID, api.NetworkIPv6, true},
+		// zond without families is counted as IPv4 only
+		{silent.UUID, "", true},
+		{silent.UUID, api.NetworkIPv4, true},
+		{silent.UUID, api.NetworkIPv6, false},
+	}
+	for _, test := range tests {
+		if got := ZondReaches(test.zondUUID, test.family); got != test.want {
+			t.Errorf("ZondReaches(%s, %q) = %v, want %v", test.zondUUID, test.family, got, test.want)
+		}
+	}
+
+	// network labels agree with ZondReaches
+	labels, _ := ZondLabels(silent.UUID)
+	if labels[api.NetworkIPv4] != networkLabelValue || labels[api.NetworkIPv6] != "" {
+		t.Errorf("labels of zond without families = %v", labels)
+	}
+	selector, _ := ParseLabelSelector("ipv4,!ipv6")
+	if zonds := MatchingZonds(selector); len(zonds) != 1 || zonds[0] != silent.UUID {
+		t.Errorf("zonds matching %s = %v, want %s", selector, zonds, silent.UUID)
+	}
+	if zonds := EligibleZonds("tasks", api.NetworkIPv6); len(zonds) != 1 || zonds[0] != dual.UUID {
+		t.Errorf("zonds reaching IPv6 = %v, want %s", zonds, dual.UUID)
+	}
+}
+
+func TestSetNetworkLabels(t *testing.T) {
+	tests := []struct {
+		families string
+		want     map[string]string
+	}{
+		{"ipv4,ipv6", map[string]string{"net": "x", "ipv4": networkLabelValue, "ipv6": networkLabelValue}},
+		{"ipv6", map[string]string{"net": "x", "ipv6": networkLabelValue}},
+		// zond reporting no network reaches no family
+		{"", map[string]string{"net": "x"}},
+		{"ipv5,,IPV4", map[string]string{"net": "x"}},
+	}
+
+	for _, test := range tests {
+		labels := map[string]string{"net": "x", "ipv4": "stale"}
+		setNetworkLabels(labels, test.families)
+		if len(labels) != len(test.want) {
+			t.Errorf("setNetworkLabels(%q) = %v, want %v", test.families, labels, test.want)
+			continue
+		}
+		for key, value := range test.want {
+			if labels[key] != value {
+				t.Errorf("setNetworkLabels(%q) = %v, want %v", test.families, labels, test.want)
+				break
+			}
+		}
+	}
+}
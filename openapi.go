@@ -0,0 +1,437 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ad/gocc/api"
+	"github.com/gorilla/mux"
+)
+
+// openAPIOperation describes one route registered in NewRouter.
+// Request and response schemas are generated from the Go types handlers use.
+type openAPIOperation struct {
+	Method      string
+	Path        string
+	Tag         string
+	Summary     string
+	Security    []string
+	Internal    bool
+	Deprecated  bool
+	Params      []openAPIParam
+	Form        []string
+	Request     interface{}
+	Required    []string
+	Status      int
+	Response    interface{}
+	ContentType string
+}
+
+type openAPIParam struct {
+	Name     string
+	In       string
+	Type     string
+	Required bool
+}
+
+// agentAuthDescription explains signature of zond and manager requests, see api.SignatureBase
+const agentAuthDescription = "Agent uuid. Request must also have X-Timestamp (unix seconds, at most 300 seconds off), " +
+	"unique X-Nonce and X-Signature: hex HMAC-SHA256 with agent secret of method, path, timestamp, nonce " +
+	"and hex SHA-256 of body, joined with newlines"
+
+var (
+	userSecurity  = []string{"userAuth"}
+	userWrite     = []string{"userAuth", "csrfToken"}
+	zondSecurity  = []string{"zondAuth"}
+	mngrSecurity  = []string{"mngrAuth"}
+	pageParam     = openAPIParam{Name: "page", In: "query", Type: "integer"}
+	uuidPathParam = openAPIParam{Name: "uuid", In: "path", Type: "string", Required: true}
+	zondHeader    = openAPIParam{Name: "X-ZondUuid", In: "header", Type: "string"}
+	mngrHeader    = openAPIParam{Name: "X-MngrUuid", In: "header", Type: "string"}
+	channelHeader = openAPIParam{Name: "X-Channel-Id1", In: "header", Type: "string"}
+	networkHeader = openAPIParam{Name: "X-Zond-Network", In: "header", Type: "string"}
+	taskForm      = []string{"type", "ip", "params", "maintype", "taskcount", "dest", "repeat", "queuettl"}
+	loginForm     = []string{"login", "password"}
+	bulkForm      = []string{"action", "targets", "type", "count", "dest", "repeat", "queue_ttl"}
+	listParams    = []openAPIParam{
+		pageParam,
+		{Name: "cursor", In: "query", Type: "string"},
+		{Name: "sort", In: "query", Type: "string"},
+		{Name: "from", In: "query", Type: "string"},
+		{Name: "to", In: "query", Type: "string"},
+	}
+	taskFilterParams = []openAPIParam{
+		{Name: "action", In: "query", Type: "string"},
+		{Name: "status", In: "query", Type: "string"},
+		{Name: "target", In: "query", Type: "string"},
+		{Name: "dest", In: "query", Type: "string"},
+		{Name: "zond", In: "query", Type: "string"},
+		{Name: "schedule", In: "query", Type: "string"},
+		{Name: "batch", In: "query", Type: "string"},
+	}
+	taskListParams = append(append([]openAPIParam{}, listParams...), taskFilterParams...)
+	exportParams   = append([]openAPIParam{
+		{Name: "format", In: "query", Type: "string"},
+		{Name: "from", In: "query", Type: "string"},
+		{Name: "to", In: "query", Type: "string"},
+	}, taskFilterParams...)
+	emailForm = []string{"email"}
+)
+
+var openAPIOperations = []openAPIOperation{
+	{Method: "GET", Path: "/", Tag: "misc", Summary: "List online zonds", Response: []string{}},
+	{Method: "GET", Path: "/auth", Tag: "user", Summary: "nginx auth_request endpoint, sets X-Forwarded-User", Internal: true},
+	{Method: "GET", Path: "/dispatch/", Tag: "misc", Summary: "nginx subscriber dispatch, redirects to nchan channels by X-ZondUuid or X-MngrUuid, request must be signed as GET /sub. Zond may list address families it reaches in X-Zond-Network", Internal: true, Params: []openAPIParam{zondHeader, mngrHeader, networkHeader}},
+	{Method: "GET", Path: "/version", Tag: "misc", Summary: "Version of control center", ContentType: "text/plain"},
+
+	{Method: "GET", Path: "/user", Tag: "user", Summary: "Current user info", Security: userSecurity, ContentType: "text/plain"},
+	{Method: "GET", Path: "/user/auth", Tag: "user", Summary: "Check authorization", Security: userSecurity, ContentType: "text/plain"},
+	{Method: "GET", Path: "/login", Tag: "user", Summary: "Login form", ContentType: "text/html"},
+	{Method: "POST", Path: "/login", Tag: "user", Summary: "Login, answers with envelope for xmlhttprequest", Form: loginForm, Response: ApiStatusResponse{}},
+	{Method: "GET", Path: "/register", Tag: "user", Summary: "Registration form", ContentType: "text/html"},
+	{Method: "POST", Path: "/register", Tag: "user", Summary: "Register, password is sent by email", Form: emailForm, Response: ApiStatusResponse{}},
+	{Method: "GET", Path: "/recover", Tag: "user", Summary: "Password recovery form", ContentType: "text/html"},
+	{Method: "POST", Path: "/recover", Tag: "user", Summary: "Send password recovery link", Form: emailForm, Response: ApiStatusResponse{}},
+	{Method: "GET", Path: "/reset", Tag: "user", Summary: "Reset password by link from email", Params: []openAPIParam{{Name: "hash", In: "query", Type: "string", Required: true}, {Name: "email", In: "query", Type: "string", Required: true}}, Response: ApiStatusResponse{}},
+
+	{Method: "GET", Path: "/task/create", Tag: "task", Summary: "Dashboard page", Security: userSecurity, ContentType: "text/html"},
+	{Method: "GET", Path: "/task/my", Tag: "task", Summary: "My tasks page", Security: userSecurity, Params: taskListParams, ContentType: "text/html"},
+	{Method: "GET", Path: "/task/repeatable", Tag: "task", Summary: "My repeatable tasks page", Security: userSecurity, ContentType: "text/html"},
+	{Method: "GET", Path: "/api/task/export", Tag: "task", Summary: "Export my tasks, the same as /api/v1/tasks/export", Security: userSecurity, Params: exportParams, ContentType: "text/csv"},
+	{Method: "GET", Path: "/task/{uuid}", Tag: "task", Summary: "My task page", Security: userSecurity, Params: []openAPIParam{uuidPathParam}, ContentType: "text/html"},
+	{Method: "GET", Path: "/zond/my", Tag: "zond", Summary: "My zonds page", Security: userSecurity, Params: listParams, ContentType: "text/html"},
+	{Method: "GET", Path: "/mngr/my", Tag: "mngr", Summary: "My managers page", Security: userSecurity, Params: listParams, ContentType: "text/html"},
+
+	{Method: "GET", Path: "/api/openapi.json", Tag: "misc", Summary: "This document", Response: map[string]interface{}{}},
+
+	{Method: "GET", Path: "/api/token", Tag: "legacy", Summary: "CSRF token in X-CSRF-Token header", Security: userSecurity, Deprecated: true},
+	{Method: "GET", Path: "/api/task/my", Tag: "legacy", Summary: "List my tasks", Security: userSecurity, Deprecated: true, Params: taskListParams, Response: TaskListResponse{}},
+	{Method: "GET", Path: "/api/task/repeatable", Tag: "legacy", Summary: "List my repeatable tasks", Security: userSecurity, Deprecated: true, Response: TaskListResponse{}},
+	{Method: "POST", Path: "/api/task/bulk", Tag: "legacy", Summary: "Create tasks for list of targets", Security: userWrite, Deprecated: true, Request: TaskBulkRequest{}, Form: bulkForm, Required: []string{"action", "targets"}, Response: TaskBulkResponse{}},
+	{Method: "POST", Path: "/api/task/create", Tag: "legacy", Summary: "Create task", Security: userWrite, Deprecated: true, Form: taskForm, Response: TaskCreateResponse{}},
+	{Method: "POST", Path: "/api/task/repeatable/remove", Tag: "legacy", Summary: "Remove repeatable task", Security: userWrite, Deprecated: true, Form: []string{"uuid"}, Response: ApiStatusResponse{}},
+	{Method: "GET", Path: "/api/zond/my", Tag: "legacy", Summary: "List my zonds", Security: userSecurity, Deprecated: true, Params: listParams, Response: ZondListResponse{}},
+	{Method: "POST", Path: "/api/zond/create", Tag: "legacy", Summary: "Create zond", Security: userWrite, Deprecated: true, Form: []string{"name"}, Response: ZondCreateLegacyResponse{}},
+	{Method: "GET", Path: "/api/mngr/my", Tag: "legacy", Summary: "List my managers", Security: userSecurity, Deprecated: true, Params: listParams, Response: MngrListResponse{}},
+	{Method: "POST", Path: "/api/mngr/create", Tag: "legacy", Summary: "Create manager", Security: userWrite, Deprecated: true, Form: []string{"name"}, Response: CreateResponse{}},
+
+	{Method: "GET", Path: "/api/v1/token", Tag: "v1", Summary: "CSRF token in X-CSRF-Token header", Security: userSecurity, Response: ApiStatusResponse{}},
+	{Method: "GET", Path: "/api/v1/tasks", Tag: "v1", Summary: "List my tasks ordered by creation time (sort=-created, default, or created), next_cursor of page continues list when passed as cursor, status is pending, processing, done, failed, canceled or expired, target is substring of param, dest is destination as on creation", Security: userSecurity, Params: taskListParams, Response: TaskListResponse{}},
+	{Method: "POST", Path: "/api/v1/tasks", Tag: "v1", Summary: "Create task, task without online zonds of destination is rejected with no_zonds unless queue_ttl is set", Security: userWrite, Request: TaskCreateRequest{}, Required: []string{"action", "param"}, Status: http.StatusCreated, Response: TaskCreateResponse{}},
+	{Method: "GET", Path: "/api/v1/tasks/repeatable", Tag: "v1", Summary: "List my repeatable tasks", Security: userSecurity, Response: TaskListResponse{}},
+	{Method: "DELETE", Path: "/api/v1/tasks/repeatable/{uuid}", Tag: "v1", Summary: "Remove repeatable task", Security: userWrite, Params: []openAPIParam{uuidPathParam}, Response: ApiStatusResponse{}},
+	{Method: "POST", Path: "/api/v1/tasks/bulk", Tag: "v1", Summary: "Create task for every target with shared action, type, count, dest, repeat and queue_ttl, sent as JSON or as multipart form with targets file of one target per line, JSON may also have params array of structured params of tasks, targets and params are validated one by one and created tasks share batch id", Security: userWrite, Request: TaskBulkRequest{}, Form: bulkForm, Required: []string{"action", "targets"}, Response: TaskBulkResponse{}},
+	{Method: "GET", Path: "/api/v1/tasks/export", Tag: "v1", Summary: "Stream my tasks as CSV (format=csv, default) or JSON Lines of ExportRecord (format=jsonl or ndjson), CSV has columns parsed from result when filtered by action, from and to are unix time or RFC 3339", Security: userSecurity, Params: exportParams, ContentType: "text/csv"},
+	{Method: "PUT", Path: "/api/v1/tasks/repeatable/{uuid}/retention", Tag: "v1", Summary: "Set retention policy of runs of repeatable task, uuid is uuid of scheduled or first run, empty policy removes it", Security: userWrite, Params: []openAPIParam{uuidPathParam}, Request: RetentionPolicy{}, Response: RetentionResponse{}},
+	{Method: "GET", Path: "/api/v1/tasks/{uuid}", Tag: "v1", Summary: "Get my task", Security: userSecurity, Params: []openAPIParam{uuidPathParam}, Response: TaskResponse{}},
+	{Method: "GET", Path: "/api/v1/tasks/{uuid}/detail", Tag: "v1", Summary: "Get my task with status, fields parsed from result, zond with geo data at claim, lifecycle times, attempts, child tasks and newest other runs of its schedule", Security: userSecurity, Params: []openAPIParam{uuidPathParam}, Response: TaskDetailResponse{}},
+	{Method: "GET", Path: "/api/v1/tasks/{uuid}/dns", Tag: "v1", Summary: "Compare answers of child tasks of my dns measurement, zonds are grouped by answer and groups outside of majority are inconsistent", Security: userSecurity, Params: []openAPIParam{uuidPathParam}, Response: DNSConsistencyResponse{}},
+	{Method: "DELETE", Path: "/api/v1/tasks/{uuid}", Tag: "v1", Summary: "Cancel my task while it is queued", Security: userWrite, Params: []openAPIParam{uuidPathParam}, Response: TaskResponse{}},
+	{Method: "GET", Path: "/api/v1/retention", Tag: "v1", Summary: "Get my retention policy and policies of my schedules", Security: userSecurity, Response: RetentionResponse{}},
+	{Method: "PUT", Path: "/api/v1/retention", Tag: "v1", Summary: "Set my retention policy of finished tasks, empty policy returns to default of server", Security: userWrite, Request: RetentionPolicy{}, Response: RetentionResponse{}},
+	{Method: "GET", Path: "/api/v1/retention/report", Tag: "v1", Summary: "Dry run of compaction of my tasks, nothing is removed", Security: userSecurity, Response: CompactionReport{}},
+	{Method: "GET", Path: "/api/v1/zonds", Tag: "v1", Summary: "List my zonds ordered by creation time, see /api/v1/tasks for cursor and sort", Security: userSecurity, Params: listParams, Response: ZondListResponse{}},
+	{Method: "POST", Path: "/api/v1/zonds", Tag: "v1", Summary: "Create zond", Security: userWrite, Request: CreateRequest{}, Status: http.StatusCreated, Response: CreateResponse{}},
+	{Method: "PATCH", Path: "/api/v1/zonds/{uuid}", Tag: "v1", Summary: "Rename, label, disable or enable zond, disabled zond is disconnected and its tasks are returned to queue", Security: userWrite, Params: []openAPIParam{uuidPathParam}, Request: UpdateRequest{}, Response: ZondResponse{}},
+	{Method: "DELETE", Path: "/api/v1/zonds/{uuid}", Tag: "v1", Summary: "Delete zond, it is disconnected and its tasks are returned to queue", Security: userWrite, Params: []openAPIParam{uuidPathParam}, Response: ApiStatusResponse{}},
+	{Method: "POST", Path: "/api/v1/zonds/{uuid}/rotate", Tag: "v1", Summary: "Replace zond uuid, secret and certificates, old ones stop working at once", Security: userWrite, Params: []openAPIParam{uuidPathParam}, Response: ZondResponse{}},
+	{Method: "POST", Path: "/api/v1/zonds/{uuid}/enroll", Tag: "v1", Summary: "Issue one-time token for zond certificate enrollment, 404 when CA is disabled", Security: userWrite, Params: []openAPIParam{uuidPathParam}, Response: CreateResponse{}},
+	{Method: "GET", Path: "/api/v1/mngrs", Tag: "v1", Summary: "List my managers ordered by creation time, see /api/v1/tasks for cursor and sort", Security: userSecurity, Params: listParams, Response: MngrListResponse{}},
+	{Method: "POST", Path: "/api/v1/mngrs", Tag: "v1", Summary: "Create manager", Security: userWrite, Request: CreateRequest{}, Status: http.StatusCreated, Response: CreateResponse{}},
+	{Method: "PATCH", Path: "/api/v1/mngrs/{uuid}", Tag: "v1", Summary: "Rename, disable or enable manager, disabled manager is disconnected and its measurements are returned to queue", Security: userWrite, Params: []openAPIParam{uuidPathParam}, Request: UpdateRequest{}, Response: MngrResponse{}},
+	{Method: "DELETE", Path: "/api/v1/mngrs/{uuid}", Tag: "v1", Summary: "Delete manager, it is disconnected and its measurements are returned to queue", Security: userWrite, Params: []openAPIParam{uuidPathParam}, Response: ApiStatusResponse{}},
+	{Method: "POST", Path: "/api/v1/mngrs/{uuid}/rotate", Tag: "v1", Summary: "Replace manager uuid, old one stops working at once", Security: userWrite, Params: []openAPIParam{uuidPathParam}, Response: MngrResponse{}},
+
+	{Method: "POST", Path: "/zond/task/block", Tag: "zond protocol", Summary: "Claim task received from channel, one task at time per zond", Security: zondSecurity, Request: Action{}, Required: []string{"zond", "uuid", "action"}, Response: Result{}},
+	{Method: "POST", Path: "/zond/task/result", Tag: "zond protocol", Summary: "Report result of claimed task, action must be \"result\"", Security: zondSecurity, Request: Action{}, Required: []string{"zond", "uuid", "action", "result"}, Response: Result{}},
+	{Method: "POST", Path: "/zond/enroll", Tag: "zond protocol", Summary: "Exchange one-time token for client certificate of zond, csr is PEM certificate request", Request: EnrollRequest{}, Required: []string{"token", "csr"}, Response: EnrollResponse{}},
+	{Method: "POST", Path: "/zond/pong", Tag: "zond protocol", Summary: "Answer \"alive\" message, uuid is uuid of alive message", Security: zondSecurity, Request: Action{}, Required: []string{"zond", "uuid"}, Response: ApiStatusResponse{}},
+	{Method: "GET", Path: "/zond/sub", Tag: "zond protocol", Summary: "nchan subscribe callback", Internal: true, Params: []openAPIParam{zondHeader, channelHeader}},
+	{Method: "GET", Path: "/zond/unsub", Tag: "zond protocol", Summary: "nchan unsubscribe callback", Internal: true, Params: []openAPIParam{zondHeader, channelHeader}},
+
+	{Method: "POST", Path: "/mngr/task/block", Tag: "mngr protocol", Summary: "Claim measurement received from mngrtasks channel", Security: mngrSecurity, Request: Action{}, Required: []string{"manager", "uuid", "action"}, Response: Result{}},
+	{Method: "POST", Path: "/mngr/task/result", Tag: "mngr protocol", Summary: "Report result of claimed measurement, action must be \"result\"", Security: mngrSecurity, Request: Action{}, Required: []string{"manager", "uuid", "action", "result"}, Response: Result{}},
+	{Method: "POST", Path: "/mngr/task/create", Tag: "mngr protocol", Summary: "Create child tasks of claimed measurement, action, param and count of measurement are used when not set", Security: mngrSecurity, Request: Action{}, Required: []string{"manager", "parent"}, Response: ChildTasksResponse{}},
+	{Method: "POST", Path: "/mngr/pong", Tag: "mngr protocol", Summary: "Answer \"alive\" message, uuid is uuid of alive message", Security: mngrSecurity, Request: Action{}, Required: []string{"manager", "uuid"}, Response: ApiStatusResponse{}},
+	{Method: "GET", Path: "/mngr/sub", Tag: "mngr protocol", Summary: "nchan subscribe callback", Internal: true, Params: []openAPIParam{mngrHeader}},
+	{Method: "GET", Path: "/mngr/unsub", Tag: "mngr protocol", Summary: "nchan unsubscribe callback", Internal: true, Params: []openAPIParam{mngrHeader}},
+}
+
+// openAPISchemas collects component schemas generated from Go types
+type openAPISchemas map[string]interface{}
+
+// paramsType is type of structured task params, its schema is one of params
+// schemas of actions
+var paramsType = reflect.TypeOf(api.Params(nil))
+
+func (s openAPISchemas) schemaFor(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == paramsType {
+		return s.paramsSchema()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": s.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": s.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		if _, ok := s[t.Name()]; !ok {
+			s[t.Name()] = map[string]interface{}{}
+			s[t.Name()] = s.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	}
+
+	return map[string]interface{}{}
+}
+
+// paramsSchema is schema of task params of any action
+func (s openAPISchemas) paramsSchema() map[string]interface{} {
+	var schemas []interface{}
+	for _, action := range taskActions() {
+		if params, err := api.NewParams(action); err == nil {
+			schemas = append(schemas, s.schemaFor(reflect.TypeOf(params)))
+		}
+	}
+	return map[string]interface{}{"type": "object", "oneOf": schemas}
+}
+
+func (s openAPISchemas) structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := field.Name
+		if tag := field.Tag.Get("json"); tag != "" {
+			name = strings.Split(tag, ",")[0]
+		}
+		if name == "-" {
+			continue
+		}
+		properties[name] = s.schemaFor(field.Type)
+	}
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func (s openAPISchemas) bodySchema(v interface{}, required []string) map[string]interface{} {
+	schema := s.schemaFor(reflect.TypeOf(v))
+	if len(required) > 0 {
+		return map[string]interface{}{"allOf": []interface{}{schema}, "required": required}
+	}
+	return schema
+}
+
+func (op openAPIOperation) build(schemas openAPISchemas) map[string]interface{} {
+	operation := map[string]interface{}{
+		"tags":        []string{op.Tag},
+		"summary":     op.Summary,
+		"operationId": strings.ToLower(op.Method) + strings.NewReplacer("/", "_", "{", "", "}", "", ".", "_").Replace(op.Path),
+	}
+	if op.Deprecated {
+		operation["deprecated"] = true
+	}
+	if op.Internal {
+		operation["x-internal"] = true
+	}
+
+	security := []interface{}{}
+	if len(op.Security) > 0 {
+		requirement := map[string][]string{}
+		for _, name := range op.Security {
+			requirement[name] = []string{}
+		}
+		security = append(security, requirement)
+	}
+	operation["security"] = security
+
+	var parameters []interface{}
+	for _, p := range op.Params {
+		parameters = append(parameters, map[string]interface{}{
+			"name":     p.Name,
+			"in":       p.In,
+			"required": p.Required,
+			"schema":   map[string]interface{}{"type": p.Type},
+		})
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	if op.Request != nil {
+		content := map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schemas.bodySchema(op.Request, op.Required)},
+		}
+		// request may also be sent as multipart form with the same fields
+		if len(op.Form) > 0 {
+			properties := map[string]interface{}{}
+			for _, name := range op.Form {
+				properties[name] = map[string]interface{}{"type": "string"}
+			}
+			content["multipart/form-data"] = map[string]interface{}{"schema": map[string]interface{}{"type": "object", "properties": properties}}
+		}
+		operation["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  content,
+		}
+	} else if len(op.Form) > 0 {
+		properties := map[string]interface{}{}
+		for _, name := range op.Form {
+			properties[name] = map[string]interface{}{"type": "string"}
+		}
+		operation["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{
+				"application/x-www-form-urlencoded": map[string]interface{}{"schema": map[string]interface{}{"type": "object", "properties": properties}},
+			},
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := map[string]interface{}{"description": http.StatusText(status)}
+	if op.Response != nil {
+		success["content"] = map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schemas.schemaFor(reflect.TypeOf(op.Response))},
+		}
+	} else if op.ContentType != "" {
+		success["content"] = map[string]interface{}{
+			op.ContentType: map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+		}
+	}
+	responses := map[string]interface{}{strconv.Itoa(status): success}
+	if op.Request != nil || op.Response != nil {
+		responses["default"] = map[string]interface{}{
+			"description": "Error",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": schemas.schemaFor(reflect.TypeOf(ApiErrorResponse{}))},
+			},
+		}
+	}
+	operation["responses"] = responses
+
+	return operation
+}
+
+// OpenAPISpec returns OpenAPI 3 document for all routes of control center
+func OpenAPISpec() map[string]interface{} {
+	schemas := openAPISchemas{}
+	paths := map[string]map[string]interface{}{}
+	tags := map[string]bool{}
+
+	for _, op := range openAPIOperations {
+		if paths[op.Path] == nil {
+			paths[op.Path] = map[string]interface{}{}
+		}
+		paths[op.Path][strings.ToLower(op.Method)] = op.build(schemas)
+		tags[op.Tag] = true
+	}
+
+	// messages published to zonds and managers over nchan channels
+	schemas.schemaFor(reflect.TypeOf(Channels{}))
+	// structured results reported by zonds as JSON text of result
+	for _, result := range []interface{}{api.TCPResult{}, api.TLSResult{}, api.HTTPResult{}} {
+		schemas.schemaFor(reflect.TypeOf(result))
+	}
+
+	var tagList []interface{}
+	for tag := range tags {
+		tagList = append(tagList, map[string]interface{}{"name": tag})
+	}
+	sort.Slice(tagList, func(i, j int) bool {
+		return tagList[i].(map[string]interface{})["name"].(string) < tagList[j].(map[string]interface{})["name"].(string)
+	})
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "gocc",
+			"version": version,
+			"description": "Control center for zonds (probes) and managers. " +
+				"Zonds subscribe to /sub with X-ZondUuid header and receive Action messages on zond:<uuid>, City:<city>, Country:<country>, ASN:<asn> and tasks channels, " +
+				"managers subscribe with X-MngrUuid and receive measurements on mngrtasks channel. " +
+				"Action with action \"alive\" must be answered with pong, any other Action is claimed with block and answered with result.",
+		},
+		"tags":  tagList,
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"userAuth":  map[string]interface{}{"type": "apiKey", "in": "cookie", "name": nsCookieName, "description": "Session cookie checked by nginx auth_request, user is passed as X-Forwarded-User"},
+				"csrfToken": map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-CSRF-Token", "description": "Token from X-CSRF-Token header of /api/v1/token"},
+				"zondAuth":  map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-ZondUuid", "description": agentAuthDescription},
+				"mngrAuth":  map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-MngrUuid", "description": agentAuthDescription},
+			},
+		},
+	}
+}
+
+// OpenAPIDrift compares routes registered in router with documented ones
+func OpenAPIDrift(r *mux.Router) []string {
+	documented := map[string]bool{}
+	for _, op := range openAPIOperations {
+		documented[op.Method+" "+op.Path] = true
+		documented[op.Path] = true
+	}
+
+	var drift []string
+	registered := map[string]bool{}
+	r.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		if route.GetHandler() == nil {
+			return nil
+		}
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil || len(methods) == 0 {
+			registered[path] = true
+			if !documented[path] {
+				drift = append(drift, "route "+path+" is not documented")
+			}
+			return nil
+		}
+		for _, method := range methods {
+			registered[method+" "+path] = true
+			if !documented[method+" "+path] {
+				drift = append(drift, "route "+method+" "+path+" is not documented")
+			}
+		}
+		return nil
+	})
+
+	for _, op := range openAPIOperations {
+		if !registered[op.Method+" "+op.Path] && !registered[op.Path] {
+			drift = append(drift, "documented "+op.Method+" "+op.Path+" is not registered")
+		}
+	}
+
+	return drift
+}
+
+func ApiOpenAPIHandler(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, OpenAPISpec())
+}
@@ -0,0 +1,9 @@
+package main
+
+import "testing"
+
+func TestOpenAPIDrift(t *testing.T) {
+	for _, drift := range OpenAPIDrift(NewRouter()) {
+		t.Error(drift)
+	}
+}
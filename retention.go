@@ -0,0 +1,334 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ad/gocc/api"
+)
+
+var retentionMaxAge = flag.Int64("retention", 0, "Seconds finished tasks are kept when user has no retention policy, 0 keeps them forever")
+var retentionMaxRuns = flag.Int64("retentionruns", 0, "Newest finished runs kept per schedule when user has no retention policy, 0 keeps all")
+var archiveDir = flag.String("archivedir", "", "Directory where compacted tasks are appended as JSON lines by user and day, empty only deletes them")
+var compactDryRun = flag.Bool("compactdryrun", false, "Compactor only logs what it would remove")
+
+// compactBatch is count of tasks loaded by one MGet during compaction
+const compactBatch = 500
+
+func userRetentionKey(userUUID string) string {
+	return "retention/user/" + userUUID
+}
+
+// scheduleRetentionKey is hash of retention policies of user schedules by schedule uuid
+func scheduleRetentionKey(userUUID string) string {
+	return "retention/schedules/" + userUUID
+}
+
+// DefaultRetention is retention policy of users without own policy
+func DefaultRetention() RetentionPolicy {
+	return RetentionPolicy{MaxAge: *retentionMaxAge, MaxRuns: *retentionMaxRuns}
+}
+
+// ValidateRetention checks retention policy from request
+func ValidateRetention(policy RetentionPolicy) *ApiError {
+	if policy.MaxAge < 0 || policy.MaxRuns < 0 {
+		return NewApiError(http.StatusBadRequest, api.ErrCodeInvalidParam, "max_age and max_runs can't be negative")
+	}
+	return nil
+}
+
+// UserRetention returns retention policy of user, default one when user has none
+func UserRetention(userUUID string) RetentionPolicy {
+	policy := DefaultRetention()
+
+	js, _ := Client.Get(userRetentionKey(userUUID)).Result()
+	if js != "" {
+		if err := json.Unmarshal([]byte(js), &policy); err != nil {
+			log.Println(err.Error())
+			return DefaultRetention()
+		}
+	}
+
+	return policy
+}
+
+// ScheduleRetentions returns retention policies of user schedules by schedule uuid
+func ScheduleRetentions(userUUID string) map[string]RetentionPolicy {
+	policies := map[string]RetentionPolicy{}
+
+	values, _ := Client.HGetAll(scheduleRetentionKey(userUUID)).Result()
+	for scheduleUUID, js := range values {
+		var policy RetentionPolicy
+		if err := json.Unmarshal([]byte(js), &policy); err != nil {
+			log.Println(err.Error())
+			continue
+		}
+		policies[scheduleUUID] = policy
+	}
+
+	return policies
+}
+
+// GetRetention returns retention policies of user
+func GetRetention(userUUID string) RetentionResponse {
+	return RetentionResponse{Status: "ok", Policy: UserRetention(userUUID), Default: DefaultRetention(), Schedules: ScheduleRetentions(userUUID)}
+}
+
+// SetUserRetention replaces retention policy of user, empty policy returns
+// user to default one
+func SetUserRetention(userUUID string, policy RetentionPolicy) (RetentionResponse, *ApiError) {
+	if apiErr := ValidateRetention(policy); apiErr != nil {
+		return RetentionResponse{}, apiErr
+	}
+
+	if policy == (RetentionPolicy{}) {
+		Client.Del(userRetentionKey(userUUID))
+	} else {
+		js, _ := json.Marshal(policy)
+		Client.Set(userRetentionKey(userUUID), string(js), 0)
+	}
+
+	return GetRetention(userUUID), nil
+}
+
+// SetScheduleRetention replaces retention policy of runs of schedule, uuid
+// is uuid of scheduled run or of the first run. Empty policy removes it
+func SetScheduleRetention(userUUID string, taskUUID string, policy RetentionPolicy) (RetentionResponse, *ApiError) {
+	if apiErr := ValidateRetention(policy); apiErr != nil {
+		return RetentionResponse{}, apiErr
+	}
+
+	var scheduleUUID string
+	for _, t := range ListRepeatableTasks(userUUID) {
+		if t.UUID == taskUUID || t.ParentUUID == taskUUID {
+			scheduleUUID = scheduleOf(t)
+			break
+		}
+	}
+	if scheduleUUID == "" {
+		return RetentionResponse{}, NewApiError(http.StatusNotFound, api.ErrCodeNotFound, "schedule not found")
+	}
+
+	if policy == (RetentionPolicy{}) {
+		Client.HDel(scheduleRetentionKey(userUUID), scheduleUUID)
+	} else {
+		js, _ := json.Marshal(policy)
+		Client.HSet(scheduleRetentionKey(userUUID), scheduleUUID, string(js))
+	}
+
+	return GetRetention(userUUID), nil
+}
+
+// scheduleOf returns uuid of schedule of repeatable task run, it is uuid of
+// the first run. Empty for single tasks
+func scheduleOf(t Action) string {
+	if t.Repeat == "" || t.Repeat == "single" {
+		return ""
+	}
+	if t.ParentUUID != "" {
+		return t.ParentUUID
+	}
+	return t.UUID
+}
+
+// taskFinished reports whether task got result or terminal status, only such
+// tasks are compacted
+func taskFinished(t Action) bool {
+	return t.Result != "" || t.Status != ""
+}
+
+// CompactUser removes finished tasks of user outside of retention policies,
+// and user/tasks entries of missing tasks. With dryRun nothing is changed
+func CompactUser(userUUID string, dryRun bool) CompactionReport {
+	report := CompactionReport{Status: "ok", DryRun: dryRun, Users: 1}
+
+	userPolicy := UserRetention(userUUID)
+	schedules := ScheduleRetentions(userUUID)
+	policyOf := func(t Action) RetentionPolicy {
+		if policy, ok := schedules[scheduleOf(t)]; ok {
+			return policy
+		}
+		return userPolicy
+	}
+
+	members, _ := Client.SMembers("user/tasks/" + userUUID).Result()
+
+	var tasks []Action
+	sizes := map[string]int64{}
+	for start := 0; start < len(members); start += compactBatch {
+		end := start + compactBatch
+		if end > len(members) {
+			end = len(members)
+		}
+
+		keys := make([]string, end-start)
+		for i, taskUUID := range members[start:end] {
+			keys[i] = "task/" + taskUUID
+		}
+
+		items, _ := Client.MGet(keys...).Result()
+		for i, val := range items {
+			if val == nil {
+				report.Indexes++
+				if !dryRun {
+					IndexRemove(listTasks, userUUID, members[start+i])
+				}
+				continue
+			}
+
+			var t Action
+			if err := json.Unmarshal([]byte(val.(string)), &t); err != nil {
+				log.Println(members[start+i], err.Error())
+				continue
+			}
+			if taskFinished(t) {
+				tasks = append(tasks, t)
+				sizes[t.UUID] = int64(len(val.(string)))
+			}
+		}
+	}
+
+	now := time.Now().Unix()
+	remove := map[string]bool{}
+	runs := map[string][]Action{}
+	for _, t := range tasks {
+		policy := policyOf(t)
+		if policy.MaxAge > 0 && now-t.Created > policy.MaxAge {
+			remove[t.UUID] = true
+		}
+		if scheduleUUID := scheduleOf(t); scheduleUUID != "" {
+			runs[scheduleUUID] = append(runs[scheduleUUID], t)
+		}
+	}
+	for _, list := range runs {
+		policy := policyOf(list[0])
+		if policy.MaxRuns <= 0 || int64(len(list)) <= policy.MaxRuns {
+			continue
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Created > list[j].Created })
+		for _, t := range list[policy.MaxRuns:] {
+			remove[t.UUID] = true
+		}
+	}
+
+	// removed tasks by archive file, tasks of file that can't be written are
+	// kept until the next compaction
+	archive := map[string][]Action{}
+	for _, t := range tasks {
+		if !remove[t.UUID] {
+			continue
+		}
+		path := ""
+		if *archiveDir != "" {
+			path = filepath.Join(*archiveDir, userUUID, time.Unix(t.Created, 0).UTC().Format("2006-01-02")+".jsonl")
+		}
+		archive[path] = append(archive[path], t)
+	}
+
+	for path, list := range archive {
+		if path != "" && !dryRun {
+			lines := make([]string, len(list))
+			for i, t := range list {
+				js, _ := json.Marshal(t)
+				lines[i] = string(js)
+			}
+			if err := appendLines(path, lines); err != nil {
+				log.Println("archive:", err)
+				continue
+			}
+		}
+
+		for _, t := range list {
+			report.Tasks++
+			report.Bytes += sizes[t.UUID]
+			if path != "" {
+				report.Archived++
+			}
+			for _, agentUUID := range []string{t.ZondUUID, t.MngrUUID} {
+				if agentUUID != "" && Client.SIsMember("tasks-done", agentUUID+"/"+t.UUID).Val() {
+					report.Done++
+				}
+			}
+			if !dryRun {
+				deleteTask(t)
+			}
+		}
+	}
+
+	return report
+}
+
+// deleteTask removes task with its indexes, attempt counter, claim history and child set
+func deleteTask(t Action) {
+	Client.Del("task/"+t.UUID, "task/"+t.UUID+"/attempts", "task/"+t.UUID+"/children", claimHistoryKey(t.UUID))
+	IndexRemove(listTasks, t.Creator, t.UUID)
+	for _, agentUUID := range []string{t.ZondUUID, t.MngrUUID} {
+		if agentUUID != "" {
+			Client.SRem("tasks-done", agentUUID+"/"+t.UUID)
+		}
+	}
+	if t.ParentUUID != "" && t.MngrUUID != "" {
+		Client.SRem("task/"+t.ParentUUID+"/children", t.UUID)
+	}
+}
+
+// appendLines appends lines to archive file, creating its directory
+func appendLines(path string, lines []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.WriteString(strings.Join(lines, "\n") + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// CompactTasks applies retention policies of all users and removes tasks-done
+// entries of missing tasks
+func CompactTasks(dryRun bool) CompactionReport {
+	report := CompactionReport{Status: "ok", DryRun: dryRun}
+
+	keys, _ := Client.Keys("user/tasks/*").Result()
+	for _, key := range keys {
+		user := CompactUser(strings.TrimPrefix(key, "user/tasks/"), dryRun)
+		report.Users++
+		report.Tasks += user.Tasks
+		report.Bytes += user.Bytes
+		report.Archived += user.Archived
+		report.Indexes += user.Indexes
+		report.Done += user.Done
+	}
+
+	done, _ := Client.SMembers("tasks-done").Result()
+	for _, entry := range done {
+		s := strings.SplitN(entry, "/", 3)
+		if len(s) < 2 {
+			continue
+		}
+		if Client.Exists("task/"+s[1]).Val() == 0 {
+			report.Done++
+			if !dryRun {
+				Client.SRem("tasks-done", entry)
+			}
+		}
+	}
+
+	log.Printf("compaction (dry run %v): %d users, %d tasks, %d bytes, %d archived, %d indexes, %d done entries",
+		dryRun, report.Users, report.Tasks, report.Bytes, report.Archived, report.Indexes, report.Done)
+
+	return report
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gocc/api"
+)
+
+// storeTestTask saves task of alice created age seconds ago
+func storeTestTask(t *testing.T, task Action, age int64) Action {
+	t.Helper()
+
+	task.Creator = "alice"
+	task.Created = time.Now().Unix() - age
+	js, _ := json.Marshal(task)
+	Client.Set("task/"+task.UUID, string(js), 0)
+	IndexAdd(listTasks, "alice", task.UUID, task.Created)
+	return task
+}
+
+func TestCompactUserPolicy(t *testing.T) {
+	const day = 86400
+
+	type testTask struct {
+		uuid   string
+		age    int64
+		result string
+		status string
+		repeat string
+		parent string
+	}
+	tests := []struct {
+		name     string
+		policy   RetentionPolicy
+		schedule *RetentionPolicy
+		tasks    []testTask
+		removed  []string
+	}{
+		{
+			name:   "age boundary",
+			policy: RetentionPolicy{MaxAge: day},
+			tasks: []testTask{
+				{uuid: "inside", age: day - 1, result: "ok"},
+				{uuid: "outside", age: day + 1, result: "ok"},
+				{uuid: "unfinished", age: 2 * day},
+			},
+			removed: []string{"outside"},
+		},
+		{
+			name:   "no policy keeps all",
+			policy: RetentionPolicy{},
+			tasks: []testTask{
+				{uuid: "old", age: 365 * day, result: "ok"},
+				{uuid: "run1", age: 3, result: "ok", repeat: "1hour"},
+				{uuid: "run2", age: 2, result: "ok", repeat: "1hour", parent: "run1"},
+			},
+		},
+		{
+			name:   "runs boundary",
+			policy: RetentionPolicy{MaxRuns: 2},
+			tasks: []testTask{
+				{uuid: "run1", age: 30, result: "ok", repeat: "1hour"},
+				{uuid: "run2", age: 20, result: "ok", repeat: "1hour", parent: "run1"},
+				{uuid: "other1", age: 30, result: "ok", repeat: "1hour"},
+				{uuid: "other2", age: 20, result: "ok", repeat: "1hour", parent: "other1"},
+				{uuid: "other3", age: 10, result: "ok", repeat: "1hour", parent: "other1"},
+				{uuid: "other4", age: 5, repeat: "1hour", parent: "other1"},
+				{uuid: "single", age: 40, result: "ok"},
+			},
+			removed: []string{"other1"},
+		},
+		{
+			name:     "schedule policy replaces user one",
+			policy:   RetentionPolicy{MaxAge: day},
+			schedule: &RetentionPolicy{MaxRuns: 1},
+			tasks: []testTask{
+				{uuid: "run1", age: 2 * day, result: "ok", repeat: "1hour"},
+				{uuid: "run2", age: 2*day - 1, result: "ok", repeat: "1hour", parent: "run1"},
+				{uuid: "single", age: 2 * day, result: "ok"},
+			},
+			removed: []string{"run1", "single"},
+		},
+		{
+			name:   "canceled and expired are finished",
+			policy: RetentionPolicy{MaxAge: day},
+			tasks: []testTask{
+				{uuid: "canceled", age: day + 1, status: api.TaskStatusCanceled},
+				{uuid: "expired", age: day + 1, status: api.TaskStatusExpired, repeat: "single"},
+			},
+			removed: []string{"canceled", "expired"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useTestRedis(t)
+
+			SetUserRetention("alice", test.policy)
+			for _, task := range test.tasks {
+				storeTestTask(t, Action{UUID: task.uuid, Result: task.result, Status: task.status, Repeat: task.repeat, ParentUUID: task.parent}, task.age)
+			}
+			if test.schedule != nil {
+				js, _ := json.Marshal(test.schedule)
+				Client.HSet(scheduleRetentionKey("alice"), "run1", string(js))
+			}
+
+			dry := CompactUser("alice", true)
+			report := CompactUser("alice", false)
+			if dry.Tasks != int64(len(test.removed)) || report.Tasks != dry.Tasks {
+				t.Errorf("removed %d tasks, dry run %d, want %d", report.Tasks, dry.Tasks, len(test.removed))
+			}
+
+			removed := map[string]bool{}
+			for _, taskUUID := range test.removed {
+				removed[taskUUID] = true
+			}
+			for _, task := range test.tasks {
+				exists := Client.Exists("task/"+task.uuid).Val() == 1
+				if exists == removed[task.uuid] {
+					t.Errorf("task %s exists %v, want %v", task.uuid, exists, !removed[task.uuid])
+				}
+			}
+		})
+	}
+}
+
+func TestCompactUserArchive(t *testing.T) {
+	useTestRedis(t)
+	defer func(dir string) { *archiveDir = dir }(*archiveDir)
+
+	dir := t.TempDir()
+	SetUserRetention("alice", RetentionPolicy{MaxAge: 60})
+	old := storeTestTask(t, Action{UUID: "old", Result: "ok", ZondUUID: "z1"}, 3*86400)
+	Client.SAdd("tasks-done", "z1/old")
+	storeTestTask(t, Action{UUID: "older", Result: "ok"}, 4*86400)
+
+	// directory of user archive can't be created over file
+	*archiveDir = dir
+	if err := ioutil.WriteFile(filepath.Join(dir, "alice"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	report := CompactUser("alice", false)
+	if report.Tasks != 0 || report.Archived != 0 || report.Done != 0 {
+		t.Errorf("report of failed archive = %+v, want nothing removed", report)
+	}
+	if Client.Exists("task/old", "task/older").Val() != 2 || !Client.SIsMember("tasks-done", "z1/old").Val() {
+		t.Error("tasks removed without archive")
+	}
+
+	// file of one day can't be appended, tasks of other days are archived
+	os.Remove(filepath.Join(dir, "alice"))
+	oldPath := filepath.Join(dir, "alice", time.Unix(old.Created, 0).UTC().Format("2006-01-02")+".jsonl")
+	if err := os.MkdirAll(oldPath, 0700); err != nil {
+		t.Fatal(err)
+	}
+	report = CompactUser("alice", false)
+	if report.Tasks != 1 || report.Archived != 1 || report.Done != 0 {
+		t.Errorf("report of partly failed archive = %+v, want older archived", report)
+	}
+	if Client.Exists("task/old").Val() != 1 || Client.Exists("task/older").Val() != 0 {
+		t.Error("partly failed archive removed wrong tasks")
+	}
+
+	os.Remove(oldPath)
+	report = CompactUser("alice", false)
+	if report.Tasks != 1 || report.Archived != 1 || report.Done != 1 {
+		t.Errorf("report of archive = %+v, want old archived", report)
+	}
+	if Client.Exists("task/old").Val() != 0 || Client.SIsMember("tasks-done", "z1/old").Val() {
+		t.Error("archived task not removed")
+	}
+
+	b, err := ioutil.ReadFile(oldPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var archived Action
+	if lines := strings.Split(strings.TrimSpace(string(b)), "\n"); len(lines) != 1 || json.Unmarshal([]byte(lines[0]), &archived) != nil || archived.UUID != "old" {
+		t.Errorf("archive file = %q", b)
+	}
+}
+
+func TestDeleteTask(t *testing.T) {
+	useTestRedis(t)
+
+	parent := storeTestTask(t, Action{UUID: "parent", Type: "measurement", MngrUUID: "m1"}, 10)
+	child := storeTestTask(t, Action{UUID: "child", ParentUUID: parent.UUID, MngrUUID: "m1", ZondUUID: "z1", Result: "ok"}, 5)
+	Client.SAdd("task/parent/children", "child", "sibling")
+	Client.Set("task/child/attempts", 1, 0)
+	Client.SAdd("task/child/children", "grandchild")
+	Client.RPush(claimHistoryKey("child"), "z1")
+	Client.SAdd("tasks-done", "z1/child", "m1/child", "z1/sibling")
+
+	deleteTask(child)
+
+	if n := Client.Exists("task/child", "task/child/attempts", "task/child/children", claimHistoryKey("child")).Val(); n != 0 {
+		t.Errorf("%d keys of deleted task left", n)
+	}
+	if Client.SIsMember(userListKey(listTasks, "alice"), "child").Val() || Client.ZScore(listIndexKey(listTasks, "alice"), "child").Err() == nil {
+		t.Error("deleted task left in user list")
+	}
+	if done, _ := Client.SMembers("tasks-done").Result(); len(done) != 1 || done[0] != "z1/sibling" {
+		t.Errorf("tasks-done after delete = %v", done)
+	}
+	if children, _ := Client.SMembers("task/parent/children").Result(); len(children) != 1 || children[0] != "sibling" {
+		t.Errorf("children of parent after delete = %v", children)
+	}
+	if Client.Exists("task/parent").Val() != 1 {
+		t.Error("parent removed with child")
+	}
+}
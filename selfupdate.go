@@ -41,7 +41,7 @@ func selfUpdate(slug string, version string, fqdn string) error {
 		fmt.Println("Release note:\n", latest.ReleaseNotes)
 
 		// we really need to receive this signal until function die
-		defer Post("http://127.0.0.1:80/pub/"+fqdn, `{"action": "updated", "version": "`+fmt.Sprint(latest.Version)+`"}`)
+		defer Post(*nchanaddr+"/pub/"+fqdn, `{"action": "updated", "version": "`+fmt.Sprint(latest.Version)+`"}`)
 
 		file, err := osext.Executable()
 		if err != nil {
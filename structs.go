@@ -1,65 +1,47 @@
 package main
 
-type Action struct {
-	ZondUUID   string `json:"zond"`
-	MngrUUID   string `json:"manager"`
-	Creator    string `json:"creator"`
-	Type       string `json:"type"` // task/measurement
-	Count      int64  `json:"count"`
-	TimeOut    int64  `json:"timeout"`
-	Action     string `json:"action"`
-	Param      string `json:"param"`
-	Result     string `json:"result"`
-	ParentUUID string `json:"parent"`
-	Created    int64  `json:"created"`
-	Updated    int64  `json:"updated"`
-	Target     string `json:"target"`
-	Repeat     string `json:"repeat"`
-	UUID       string `json:"uuid"`
-}
+import "github.com/ad/gocc/api"
 
-type Result struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
-}
+type Action = api.Action
+type Result = api.Result
+type Zond = api.Zond
+type Mngr = api.Mngr
+type Channels = api.Channels
+type Geodata = api.Geodata
+type ChildTasksResponse = api.ChildTasksResponse
 
-type Zond struct {
-	Creator string `json:"creator"`
-	UUID    string `json:"uuid"`
-	Name    string `json:"name"`
-	Created int64  `json:"created"`
-	Updated int64  `json:"updated"`
-}
-
-type Mngr struct {
-	Creator string `json:"creator"`
-	UUID    string `json:"uuid"`
-	Name    string `json:"name"`
-	Created int64  `json:"created"`
-	Updated int64  `json:"updated"`
-	IP      string `json:"ip"`
-}
-
-type Channels struct {
-	Action    string   `json:"action"`
-	Zonds     []string `json:"zonds"`
-	Countries []string `json:"countries"`
-	Cities    []string `json:"cities"`
-	ASNs      []string `json:"asns"`
-}
+type ApiError = api.Error
+type ApiErrorResponse = api.ErrorResponse
+type ApiStatusResponse = api.StatusResponse
+type TaskCreateRequest = api.TaskCreateRequest
+type TaskCreateResponse = api.TaskCreateResponse
+type TaskResponse = api.TaskResponse
+type TaskListResponse = api.TaskListResponse
+type TaskDetailResponse = api.TaskDetailResponse
+type TaskBulkRequest = api.TaskBulkRequest
+type TaskBulkResult = api.TaskBulkResult
+type TaskBulkResponse = api.TaskBulkResponse
+type TaskAttempt = api.TaskAttempt
+type TaskZond = api.TaskZond
+type DNSConsistencyResponse = api.DNSConsistencyResponse
+type DNSAnswerGroup = api.DNSAnswerGroup
+type DNSZondAnswer = api.DNSZondAnswer
+type CreateRequest = api.CreateRequest
+type CreateResponse = api.CreateResponse
+type ZondCreateLegacyResponse = api.ZondCreateLegacyResponse
+type UpdateRequest = api.UpdateRequest
+type EnrollRequest = api.EnrollRequest
+type EnrollResponse = api.EnrollResponse
+type ZondResponse = api.ZondResponse
+type MngrResponse = api.MngrResponse
+type ZondListResponse = api.ZondListResponse
+type MngrListResponse = api.MngrListResponse
+type RetentionPolicy = api.RetentionPolicy
+type RetentionResponse = api.RetentionResponse
+type CompactionReport = api.CompactionReport
+type ExportRecord = api.ExportRecord
 
 type ErrorMessage struct {
 	Text  string
 	Color string
 }
-
-// Geodata struct
-type Geodata struct {
-	City                         string  `json:"city"`
-	Country                      string  `json:"country"`
-	CountryCode                  string  `json:"country_code"`
-	Longitude                    float64 `json:"lon"`
-	Latitude                     float64 `json:"lat"`
-	AutonomousSystemNumber       uint    `json:"asn"`
-	AutonomousSystemOrganization string  `json:"provider"`
-}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+)
+
+// Outcomes of task attempts
+const (
+	attemptReported = "reported"
+	attemptClaimed  = "claimed"
+	attemptReturned = "returned"
+)
+
+// taskRunsLimit is count of other runs of schedule shown with task
+const taskRunsLimit = 20
+
+// TaskClaims returns claim history of task, oldest first
+func TaskClaims(taskUUID string) []Claim {
+	claims := []Claim{}
+
+	items, _ := Client.LRange(claimHistoryKey(taskUUID), 0, -1).Result()
+	for _, js := range items {
+		var claim Claim
+		if err := json.Unmarshal([]byte(js), &claim); err != nil {
+			log.Println(err.Error())
+			continue
+		}
+		claims = append(claims, claim)
+	}
+
+	return claims
+}
+
+// attemptOutcome tells what happened with claim of task
+func attemptOutcome(t Action, claim Claim) string {
+	if t.Result != "" && claim.Attempt == t.Attempt && (claim.Agent == t.ZondUUID || claim.Agent == t.MngrUUID) {
+		return attemptReported
+	}
+	if active, ok := GetClaim(claim.Agent, t.UUID); ok && active.Attempt == claim.Attempt {
+		return attemptClaimed
+	}
+	return attemptReturned
+}
+
+// loadTasks returns tasks by uuids, missing ones are skipped
+func loadTasks(uuids []string) []Action {
+	tasks := []Action{}
+	if len(uuids) == 0 {
+		return tasks
+	}
+
+	keys := make([]string, len(uuids))
+	for i, taskUUID := range uuids {
+		keys[i] = "task/" + taskUUID
+	}
+
+	items, _ := Client.MGet(keys...).Result()
+	for _, val := range items {
+		if val == nil {
+			continue
+		}
+		var t Action
+		if err := json.Unmarshal([]byte(val.(string)), &t); err != nil {
+			log.Println(err.Error())
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+
+	return tasks
+}
+
+// taskZond returns zond that ran task, geo data is taken from its claim of
+// task or from current geo data when claim has none
+func taskZond(t Action, claims []Claim) *TaskZond {
+	if t.ZondUUID == "" {
+		return nil
+	}
+
+	zond := &TaskZond{UUID: t.ZondUUID}
+
+	var z Zond
+	if js, _ := Client.Get("zonds/" + t.ZondUUID).Result(); js != "" {
+		if err := json.Unmarshal([]byte(js), &z); err != nil {
+			log.Println(err.Error())
+		}
+		zond.Name, zond.Labels = z.Name, z.Labels
+	}
+
+	for i := len(claims) - 1; i >= 0; i-- {
+		if claims[i].Agent == t.ZondUUID {
+			zond.City, zond.Country, zond.ASN = claims[i].City, claims[i].Country, claims[i].ASN
+			break
+		}
+	}
+	if zond.City == "" && zond.Country == "" && zond.ASN == "" {
+		zond.City = Client.HGet("zond:city", t.ZondUUID).Val()
+		zond.Country = Client.HGet("zond:country", t.ZondUUID).Val()
+		zond.ASN = Client.HGet("zond:asn", t.ZondUUID).Val()
+	}
+
+	return zond
+}
+
+// GetTaskDetail returns task of user with its result fields, zond, claims,
+// child tasks and other runs of its schedule
+func GetTaskDetail(userUUID string, taskUUID string) (TaskDetailResponse, *ApiError) {
+	t, apiErr := GetUserTask(userUUID, taskUUID)
+	if apiErr != nil {
+		return TaskDetailResponse{}, apiErr
+	}
+
+	claims := TaskClaims(t.UUID)
+
+	detail := TaskDetailResponse{
+		Status:     "ok",
+		Task:       t,
+		TaskStatus: ListStatus(t),
+		Fields:     ResultFields(t),
+		Zond:       taskZond(t, claims),
+		Created:    t.Created,
+		Deadline:   TaskDeadline(t),
+		Attempts:   []TaskAttempt{},
+		Runs:       []Action{},
+	}
+	if taskFinished(t) {
+		detail.Finished = t.Updated
+	}
+
+	for _, claim := range claims {
+		if detail.Claimed == 0 {
+			detail.Claimed = claim.Claimed
+		}
+		detail.Attempts = append(detail.Attempts, TaskAttempt{
+			Attempt:  claim.Attempt,
+			Agent:    claim.Agent,
+			Claimed:  claim.Claimed,
+			Deadline: claim.Deadline,
+			Outcome:  attemptOutcome(t, claim),
+		})
+	}
+
+	children, _ := Client.SMembers("task/" + t.UUID + "/children").Result()
+	detail.Children = loadTasks(children)
+	sort.Slice(detail.Children, func(i, j int) bool { return detail.Children[i].Created < detail.Children[j].Created })
+
+	if scheduleUUID := scheduleOf(t); scheduleUUID != "" {
+		ensureIndex(listTasks, userUUID)
+		filter := TaskFilter{Schedule: scheduleUUID}
+		scanIndex(listTasks, userUUID, ListQuery{Sort: sortCreatedDesc, Filter: filter}, func(entryUUID string, created int64, js string) bool {
+			if entryUUID == t.UUID {
+				return true
+			}
+			var run Action
+			if err := json.Unmarshal([]byte(js), &run); err != nil {
+				log.Println(err.Error())
+				return true
+			}
+			if filter.Match(run) {
+				detail.Runs = append(detail.Runs, run)
+			}
+			return len(detail.Runs) < taskRunsLimit
+		})
+	}
+
+	return detail, nil
+}
@@ -22,9 +22,10 @@ import (
 var (
 	// Regular expression used to validate RFC1035 hostnames*/
 	hostnameRegex = regexp.MustCompile(`^(([a-zA-Z]|[a-zA-Z][a-zA-Z0-9\-]*[a-zA-Z0-9])\.)*([A-Za-z]|[A-Za-z][A-Za-z0-9\-]*[A-Za-z0-9])$`)
-
-	// Simple regular expression for IPv4 values, more rigorous checking is done via net.ParseIP
-	ipv4Regex = regexp.MustCompile(`^(?:[0-9]{1,3}\.){3}[0-9]{1,3}$`)
+	// Characters allowed in RFC 3986 URI, percent sign must start escape
+	uriRegex = regexp.MustCompile(`^([A-Za-z0-9\-._~:/?#\[\]@!$&'()*+,;=]|%[0-9A-Fa-f]{2})*$`)
+	// HTTP token of RFC 7230, e.g. method or header name
+	httpTokenRegex = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z]+$")
 )
 
 func IPToWSChannels(ip string, gogeoaddr *string) string {
@@ -145,11 +146,22 @@ func GetActiveDestinations() {
 
 	// log.Println(zonds, cities, countries, asns)
 
-	channels := Channels{Action: "destinations", Zonds: zonds, Countries: countries, Cities: cities, ASNs: asns}
+	mngrs, _ := Client.SMembers("mngr-online").Result()
+
+	channels := Channels{Action: "destinations", Zonds: zonds, Countries: countries, Cities: cities, ASNs: asns, Mngrs: mngrs, Labels: ActiveLabels()}
 	js, _ := json.Marshal(channels)
 	// log.Println(string(js))
 
-	go Post("http://127.0.0.1:80/pub/destinations", string(js))
+	go Post(*nchanaddr+"/pub/destinations", string(js))
+}
+
+// PublishMngrState tells dashboards that manager is online or offline
+func PublishMngrState(mngrUUID string, state string) {
+	action := Action{Action: state, MngrUUID: mngrUUID, Updated: time.Now().Unix()}
+	js, _ := json.Marshal(action)
+	go Post(*nchanaddr+"/pub/destinations", string(js))
+
+	GetActiveDestinations()
 }
 
 func SliceUniqMap(s []string) []string {